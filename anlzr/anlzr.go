@@ -17,14 +17,36 @@ type Analyzer interface {
 
 // A Result holds all source code analysis metrics output by srcanlzr.
 type Result struct {
-	ProgLangs      []Language        `json:"programming_languages" xml:"programming-languages"`
-	AverageFuncLen float32           `json:"average_function_length" xml:"average-function-length"`
-	MaxFuncLen     int64             `json:"max_function_length" xml:"max-function-length"`
-	MinFuncLen     int64             `json:"min_function_length" xml:"min-function-length"`
-	MedianFuncLen  int64             `json:"median_function_length" xml:"median-function-length"`
-	TotalLoC       int64             `json:"total_loc" xml:"total-loc"`
-	Complexity     ComplexityMetrics `json:"complexity" xml:"complexity"`
-	DocCoverage    CommentRatios     `json:"documentation_coverage" xml:"documentation-coverage"`
+	ProgLangs       []Language             `json:"programming_languages" xml:"programming-languages"`
+	AverageFuncLen  float32                `json:"average_function_length" xml:"average-function-length"`
+	MaxFuncLen      int64                  `json:"max_function_length" xml:"max-function-length"`
+	MinFuncLen      int64                  `json:"min_function_length" xml:"min-function-length"`
+	MedianFuncLen   int64                  `json:"median_function_length" xml:"median-function-length"`
+	TotalLoC        int64                  `json:"total_loc" xml:"total-loc"`
+	FuncLengths     []FileFuncLengths      `json:"function_lengths,omitempty" xml:"function-lengths>file,omitempty"`
+	Complexity      ComplexityMetrics      `json:"complexity" xml:"complexity"`
+	DocCoverage     CommentRatios          `json:"documentation_coverage" xml:"documentation-coverage"`
+	Comments        CommentMetrics         `json:"comments" xml:"comments"`
+	Dependencies    DependencyMetrics      `json:"dependencies" xml:"dependencies"`
+	DeadCode        DeadCodeMetrics        `json:"dead_code" xml:"dead-code"`
+	Conformance     ConformanceMetrics     `json:"conformance" xml:"conformance"`
+	Halstead        HalsteadMetrics        `json:"halstead" xml:"halstead"`
+	Maintainability MaintainabilityMetrics `json:"maintainability" xml:"maintainability"`
+	Cognitive       CognitiveMetrics       `json:"cognitive_complexity" xml:"cognitive-complexity"`
+	Nesting         NestingMetrics         `json:"nesting" xml:"nesting"`
+	Distributions   DistributionMetrics    `json:"distributions" xml:"distributions"`
+	Clones          CloneMetrics           `json:"clones" xml:"clones"`
+	Smells          SmellMetrics           `json:"smells" xml:"smells"`
+
+	// Metrics computed by the analyzers which have no section of their own
+	// (see AddMetric).
+	Metrics []Metric `json:"metrics" xml:"metrics>metric"`
+
+	// Per-package breakdown of the metrics, present only when requested (see
+	// Entities).
+	Entities []PackageEntity `json:"entities,omitempty" xml:"entities>package,omitempty"`
+
+	cache *sharedData
 }
 
 // A Language represents a programming language used by the project.
@@ -33,10 +55,39 @@ type Language struct {
 	Lines int64 `json:"lines" xml:"lines"`
 }
 
+// FileFuncLengths holds the lengths of the functions of a source file.
+type FileFuncLengths struct {
+	Path  string       `json:"path" xml:"path"`
+	Funcs []FuncLength `json:"functions" xml:"functions>function"`
+}
+
+// FuncLength holds the number of lines of code of a function.
+type FuncLength struct {
+	Name string `json:"name" xml:"name"`
+	LoC  int64  `json:"loc" xml:"loc"`
+}
+
 // CyclomaticComplexity metrics, also known as McCabe metric.
 type ComplexityMetrics struct {
-	AveragePerFunc float32 `json:"average_per_func" xml:"average-per-func"` // Average complexity per function.
-	AveragePerFile float32 `json:"average_per_file" xml:"average-per-file"` // Average complexity per file.
+	AveragePerFunc float32          `json:"average_per_func" xml:"average-per-func"` // Average complexity per function.
+	AveragePerFile float32          `json:"average_per_file" xml:"average-per-file"` // Average complexity per file.
+	Total          int64            `json:"total" xml:"total"`
+	Max            int64            `json:"max" xml:"max"` // Highest complexity of a function.
+	Files          []FileComplexity `json:"files,omitempty" xml:"files>file,omitempty"`
+}
+
+// FileComplexity holds the cyclomatic complexity of a source file and of each
+// of its functions.
+type FileComplexity struct {
+	Path  string           `json:"path" xml:"path"`
+	Total int64            `json:"total" xml:"total"`
+	Funcs []FuncComplexity `json:"functions,omitempty" xml:"functions>function,omitempty"`
+}
+
+// FuncComplexity holds the cyclomatic complexity of a function.
+type FuncComplexity struct {
+	Name       string `json:"name" xml:"name"`
+	Complexity int64  `json:"complexity" xml:"complexity"`
 }
 
 // CommentRatios contains various ratios about documentation coverage.
@@ -51,27 +102,392 @@ type CommentRatios struct {
 	MethComRatio   float32 `json:"method_comment"`
 	AttrComRatio   float32 `json:"attribute_comment_ratio"`
 	EnumComRatio   float32 `json:"enumeration_comment_ratio"`
+
+	// Number of public functions and methods (constructors, destructors and
+	// prototypes included), and how many of them are documented.
+	PublicFuncs           int64 `json:"public_functions"`
+	DocumentedPublicFuncs int64 `json:"documented_public_functions"`
+}
+
+// CommentMetrics holds metrics about the comments of the source files.
+type CommentMetrics struct {
+	Lines   int64   `json:"lines" xml:"lines"`     // Number of comment lines.
+	Density float32 `json:"density" xml:"density"` // Comment lines over total lines, comments included.
+
+	Todos  int64 `json:"todo_count" xml:"todo-count"`   // Number of TODO markers.
+	Fixmes int64 `json:"fixme_count" xml:"fixme-count"` // Number of FIXME markers.
+	Hacks  int64 `json:"hack_count" xml:"hack-count"`   // Number of HACK markers.
+	XXXs   int64 `json:"xxx_count" xml:"xxx-count"`     // Number of XXX markers.
+
+	Files       []FileComments `json:"files,omitempty" xml:"files>file,omitempty"`
+	TaskMarkers []TaskMarker   `json:"task_markers" xml:"task-markers>task-marker"`
+}
+
+// FileComments holds the comment metrics of a source file.
+type FileComments struct {
+	Path        string  `json:"path" xml:"path"`
+	Lines       int64   `json:"lines" xml:"lines"`
+	Density     float32 `json:"density" xml:"density"`
+	TaskMarkers int64   `json:"task_markers" xml:"task-markers"`
+}
+
+// TaskMarker represents a task marker, such as TODO or FIXME, found in a
+// comment.
+type TaskMarker struct {
+	Marker string `json:"marker" xml:"marker"`
+	File   string `json:"file" xml:"file"`
+	Line   int64  `json:"line" xml:"line"`
+	Text   string `json:"text" xml:"text"` // text following the marker
+}
+
+// DependencyMetrics holds the package dependency graph of the project and the
+// package metrics derived from it.
+type DependencyMetrics struct {
+	Packages         []PackageDeps `json:"packages" xml:"packages>package"`
+	ExternalPackages []string      `json:"external_packages" xml:"external-packages>package"`
+	Cycles           []ImportCycle `json:"cycles" xml:"cycles>cycle"`
+}
+
+// PackageDeps holds the dependencies and the coupling metrics of a package of
+// the project.
+type PackageDeps struct {
+	Path            string   `json:"path" xml:"path"`
+	Imports         []string `json:"imports" xml:"imports>import"`                            // Imported project packages.
+	ExternalImports []string `json:"external_imports" xml:"external-imports>external-import"` // Imported external packages.
+
+	AfferentCoupling int64   `json:"afferent_coupling" xml:"afferent-coupling"` // Ca: number of importing packages.
+	EfferentCoupling int64   `json:"efferent_coupling" xml:"efferent-coupling"` // Ce: number of imported packages.
+	Instability      float32 `json:"instability" xml:"instability"`             // Ce / (Ca + Ce).
+	Abstractness     float32 `json:"abstractness" xml:"abstractness"`           // Abstract types over all types.
+	Distance         float32 `json:"distance" xml:"distance"`                   // Distance from the main sequence.
+}
+
+// ImportCycle represents a set of project packages importing each other.
+type ImportCycle struct {
+	Packages []string `json:"packages" xml:"package"`
+}
+
+// DeadCodeMetrics holds the declarations that are never referenced in the
+// project.
+type DeadCodeMetrics struct {
+	Declarations int64      `json:"declarations" xml:"declarations"` // Number of checked private declarations.
+	Dead         int64      `json:"dead" xml:"dead"`                 // Number of unreferenced declarations.
+	Ratio        float32    `json:"ratio" xml:"ratio"`               // Dead declarations over checked private declarations.
+	Candidates   []DeadDecl `json:"candidates" xml:"candidates>candidate"`
+}
+
+// DeadDecl represents an unreferenced declaration.
+type DeadDecl struct {
+	Kind string `json:"kind" xml:"kind"` // kind of declaration (see the Decl constants)
+	Name string `json:"name" xml:"name"` // name, prefixed by the enclosing types for members
+	File string `json:"file" xml:"file"`
+}
+
+// ConformanceMetrics holds the interface implementation and method override
+// issues of the project.
+type ConformanceMetrics struct {
+	MissingImpls      int64              `json:"missing_implementations" xml:"missing-implementations"`
+	SpuriousOverrides int64              `json:"spurious_overrides" xml:"spurious-overrides"`
+	InvalidOverrides  int64              `json:"invalid_overrides" xml:"invalid-overrides"`
+	Issues            []ConformanceIssue `json:"issues" xml:"issues>issue"`
+}
+
+// ConformanceIssue represents a method that is either missing or wrongly
+// flagged as overriding.
+type ConformanceIssue struct {
+	Kind      string `json:"kind" xml:"kind"` // kind of issue (e.g. MissingImpl)
+	Type      string `json:"type" xml:"type"` // qualified name of the faulty type
+	Method    string `json:"method" xml:"method"`
+	Params    int64  `json:"parameters" xml:"parameters"`
+	Interface string `json:"interface,omitempty" xml:"interface,omitempty"` // interface declaring a missing method
+	File      string `json:"file" xml:"file"`
+}
+
+// HalsteadMetrics holds the Halstead metrics of the project and of each of
+// its source files.
+type HalsteadMetrics struct {
+	HalsteadValues
+	Files []FileHalstead `json:"files,omitempty" xml:"files>file,omitempty"`
+}
+
+// FileHalstead holds the Halstead metrics of a source file and of each of its
+// functions.
+type FileHalstead struct {
+	Path string `json:"path" xml:"path"`
+	HalsteadValues
+	Funcs []FuncHalstead `json:"functions,omitempty" xml:"functions>function,omitempty"`
+}
+
+// FuncHalstead holds the Halstead metrics of a function.
+type FuncHalstead struct {
+	Name string `json:"name" xml:"name"`
+	HalsteadValues
+}
+
+// HalsteadValues holds the Halstead software science metrics.
+type HalsteadValues struct {
+	DistinctOperators int64 `json:"distinct_operators" xml:"distinct-operators"` // n1
+	DistinctOperands  int64 `json:"distinct_operands" xml:"distinct-operands"`   // n2
+	TotalOperators    int64 `json:"total_operators" xml:"total-operators"`       // N1
+	TotalOperands     int64 `json:"total_operands" xml:"total-operands"`         // N2
+
+	Vocabulary int64   `json:"vocabulary" xml:"vocabulary"` // n = n1 + n2
+	Length     int64   `json:"length" xml:"length"`         // N = N1 + N2
+	Volume     float32 `json:"volume" xml:"volume"`         // V = N * log2(n)
+	Difficulty float32 `json:"difficulty" xml:"difficulty"` // D = n1/2 * N2/n2
+	Effort     float32 `json:"effort" xml:"effort"`         // E = D * V
+	Time       float32 `json:"time" xml:"time"`             // T = E / 18, in seconds
+	Bugs       float32 `json:"bugs" xml:"bugs"`             // B = V / 3000
+}
+
+// MaintainabilityMetrics holds the maintainability indices of the project, of
+// its packages, source files and functions.
+type MaintainabilityMetrics struct {
+	MaintainabilityValues
+	Packages []PackageMaintainability `json:"packages,omitempty" xml:"packages>package,omitempty"`
+	Worst    []FuncMaintainability    `json:"worst_functions" xml:"worst-functions>function"` // Functions with the lowest indices.
+}
+
+// PackageMaintainability holds the maintainability indices of a package.
+type PackageMaintainability struct {
+	Path string `json:"path" xml:"path"`
+	MaintainabilityValues
+	Files []FileMaintainability `json:"files,omitempty" xml:"files>file,omitempty"`
+}
+
+// FileMaintainability holds the maintainability indices of a source file.
+type FileMaintainability struct {
+	Path string `json:"path" xml:"path"`
+	MaintainabilityValues
+	Funcs []FuncMaintainability `json:"functions,omitempty" xml:"functions>function,omitempty"`
+}
+
+// FuncMaintainability holds the maintainability index of a function and the
+// metrics it is computed from.
+type FuncMaintainability struct {
+	Name         string  `json:"name" xml:"name"`
+	File         string  `json:"file" xml:"file"`
+	Volume       float32 `json:"volume" xml:"volume"`               // Halstead volume.
+	Complexity   int64   `json:"complexity" xml:"complexity"`       // Cyclomatic complexity.
+	LoC          int64   `json:"loc" xml:"loc"`                     // Lines of code.
+	CommentRatio float32 `json:"comment_ratio" xml:"comment-ratio"` // Documentation lines over total lines.
+	MaintainabilityValues
+}
+
+// MaintainabilityValues holds the classic and the normalized (0-100)
+// maintainability indices.
+type MaintainabilityValues struct {
+	Index      float32 `json:"index" xml:"index"`
+	Normalized float32 `json:"normalized_index" xml:"normalized-index"`
+}
+
+// CognitiveMetrics holds the cognitive complexity of the project, of its
+// source files and functions.
+type CognitiveMetrics struct {
+	Total          int64           `json:"total" xml:"total"`
+	Max            int64           `json:"max" xml:"max"`                           // Highest complexity of a function.
+	AveragePerFunc float32         `json:"average_per_func" xml:"average-per-func"` // Average complexity per function.
+	Files          []FileCognitive `json:"files,omitempty" xml:"files>file,omitempty"`
+}
+
+// FileCognitive holds the cognitive complexity of a source file and of each of
+// its functions.
+type FileCognitive struct {
+	Path  string          `json:"path" xml:"path"`
+	Total int64           `json:"total" xml:"total"`
+	Funcs []FuncCognitive `json:"functions,omitempty" xml:"functions>function,omitempty"`
+}
+
+// FuncCognitive holds the cognitive complexity of a function.
+type FuncCognitive struct {
+	Name       string `json:"name" xml:"name"`
+	Complexity int64  `json:"complexity" xml:"complexity"`
+}
+
+// NestingMetrics holds the nesting depth metrics of the project, of its source
+// files and functions.
+type NestingMetrics struct {
+	MaxDepth        int64         `json:"max_depth" xml:"max-depth"`
+	AverageMaxDepth float32       `json:"average_max_depth" xml:"average-max-depth"` // Average maximum depth per function.
+	Blocks          int64         `json:"blocks" xml:"blocks"`
+	Files           []FileNesting `json:"files,omitempty" xml:"files>file,omitempty"`
+	DeepFuncs       []FuncNesting `json:"deep_functions" xml:"deep-functions>function"` // Functions exceeding the maximum depth.
 }
 
-// RunAnalyzers runs several analyzers on a project.
-func RunAnalyzers(p *src.Project, a ...Analyzer) (*Result, error) {
-	r := &Result{
+// FileNesting holds the nesting depth metrics of a source file and of each of
+// its functions.
+type FileNesting struct {
+	Path     string        `json:"path" xml:"path"`
+	MaxDepth int64         `json:"max_depth" xml:"max-depth"`
+	Funcs    []FuncNesting `json:"functions,omitempty" xml:"functions>function,omitempty"`
+}
+
+// FuncNesting holds the nesting depth metrics of a function.
+type FuncNesting struct {
+	Name         string  `json:"name" xml:"name"`
+	File         string  `json:"file" xml:"file"`
+	MaxDepth     int64   `json:"max_depth" xml:"max-depth"`
+	AverageDepth float32 `json:"average_depth" xml:"average-depth"` // Average depth of the statements.
+	Blocks       int64   `json:"blocks" xml:"blocks"`
+
+	// Kind (statement or expression name) and line of the deepest structure.
+	DeepestKind string `json:"deepest_kind,omitempty" xml:"deepest-kind,omitempty"`
+	DeepestLine int64  `json:"deepest_line,omitempty" xml:"deepest-line,omitempty"`
+}
+
+// PackageEntity holds the metrics of a package.
+type PackageEntity struct {
+	Path            string `json:"path" xml:"path"`
+	Name            string `json:"name" xml:"name"`
+	LoC             int64  `json:"loc" xml:"loc"`
+	Files           int64  `json:"file_count" xml:"file-count"`
+	Funcs           int64  `json:"function_count" xml:"function-count"`
+	DocumentedFuncs int64  `json:"documented_functions" xml:"documented-functions"`
+	Complexity      int64  `json:"complexity" xml:"complexity"` // Sum of the complexities of the functions.
+
+	FileEntities []FileEntity `json:"source_files,omitempty" xml:"source-files>source-file,omitempty"`
+}
+
+// FileEntity holds the metrics of a source file.
+type FileEntity struct {
+	Path            string `json:"path" xml:"path"`
+	Lang            string `json:"language,omitempty" xml:"language,omitempty"`
+	LoC             int64  `json:"loc" xml:"loc"`
+	Funcs           int64  `json:"function_count" xml:"function-count"`
+	DocumentedFuncs int64  `json:"documented_functions" xml:"documented-functions"`
+	Complexity      int64  `json:"complexity" xml:"complexity"` // Sum of the complexities of the functions.
+
+	FuncEntities []FuncEntity `json:"functions,omitempty" xml:"functions>function,omitempty"`
+}
+
+// FuncEntity holds the metrics of a function, a method, a constructor or a
+// destructor.
+type FuncEntity struct {
+	Name          string `json:"name" xml:"name"`                     // name, prefixed by the enclosing types
+	QualifiedName string `json:"qualified_name" xml:"qualified-name"` // name prefixed by the package path
+	Kind          string `json:"kind" xml:"kind"`                     // kind of declaration (see the Decl constants)
+	LoC           int64  `json:"loc" xml:"loc"`
+	Complexity    int64  `json:"complexity" xml:"complexity"`
+	Params        int64  `json:"parameters" xml:"parameters"`
+	Documented    bool   `json:"documented" xml:"documented"`
+}
+
+// DistributionMetrics holds the distributions of the function lengths and
+// cyclomatic complexities, and of the file sizes, in lines of code.
+type DistributionMetrics struct {
+	FuncLength Distribution `json:"function_length" xml:"function-length"`
+	Complexity Distribution `json:"complexity" xml:"complexity"`
+	FileSize   Distribution `json:"file_size" xml:"file-size"`
+}
+
+// A Distribution summarizes a set of values. The weighted mean and median use
+// the lines of code of the function or file as weights: they describe the
+// function or file that a line of code typically belongs to.
+type Distribution struct {
+	Count          int64             `json:"count" xml:"count"`
+	Min            float32           `json:"min" xml:"min"`
+	Max            float32           `json:"max" xml:"max"`
+	Mean           float32           `json:"mean" xml:"mean"`
+	WeightedMean   float32           `json:"weighted_mean" xml:"weighted-mean"`
+	Median         float32           `json:"median" xml:"median"`
+	WeightedMedian float32           `json:"weighted_median" xml:"weighted-median"`
+	StdDev         float32           `json:"standard_deviation" xml:"standard-deviation"`
+	Gini           float32           `json:"gini" xml:"gini"`
+	Percentiles    Percentiles       `json:"percentiles" xml:"percentiles"`
+	Histogram      []HistogramBucket `json:"histogram" xml:"histogram>bucket"`
+}
+
+// Percentiles holds the usual percentiles of a distribution.
+type Percentiles struct {
+	P50 float32 `json:"p50" xml:"p50"`
+	P75 float32 `json:"p75" xml:"p75"`
+	P90 float32 `json:"p90" xml:"p90"`
+	P95 float32 `json:"p95" xml:"p95"`
+	P99 float32 `json:"p99" xml:"p99"`
+}
+
+// A HistogramBucket counts the values lower or equal to Max and greater than
+// the Max of the previous bucket.
+type HistogramBucket struct {
+	Max   float32 `json:"max" xml:"max"`
+	Count int64   `json:"count" xml:"count"`
+}
+
+// CloneMetrics holds the duplicated code of the project.
+type CloneMetrics struct {
+	Nodes           int64        `json:"nodes" xml:"nodes"`                       // Number of AST nodes of the function bodies.
+	DuplicatedNodes int64        `json:"duplicated_nodes" xml:"duplicated-nodes"` // Number of nodes belonging to a clone.
+	Duplication     float32      `json:"duplication" xml:"duplication"`           // Percentage of duplicated nodes.
+	Classes         []CloneClass `json:"classes" xml:"classes>class"`
+}
+
+// A CloneClass is a set of identical code fragments, names and values aside.
+type CloneClass struct {
+	Kind      string          `json:"kind" xml:"kind"` // statement name of the fragments, or "body" for function bodies
+	Size      int64           `json:"size" xml:"size"` // number of AST nodes of each fragment
+	Instances []CloneInstance `json:"instances" xml:"instances>instance"`
+}
+
+// A CloneInstance locates a fragment of a clone class.
+type CloneInstance struct {
+	File string `json:"file" xml:"file"`
+	Func string `json:"function" xml:"function"`
+	Line int64  `json:"line,omitempty" xml:"line,omitempty"` // line of the fragment, when known
+}
+
+// SmellMetrics holds the code smells of the project.
+type SmellMetrics struct {
+	Total  int64        `json:"total" xml:"total"`
+	Counts []SmellCount `json:"counts" xml:"counts>count"` // Number of smells of each detected kind.
+	Smells []Smell      `json:"smells" xml:"smell"`
+}
+
+// SmellCount holds the number of smells of a kind.
+type SmellCount struct {
+	Kind  string `json:"kind" xml:"kind"`
+	Count int64  `json:"count" xml:"count"`
+}
+
+// A Smell is a code smell, as detected by Smells.
+type Smell struct {
+	Kind        string `json:"kind" xml:"kind"`
+	Severity    string `json:"severity" xml:"severity"`
+	File        string `json:"file" xml:"file"`
+	Entity      string `json:"entity" xml:"entity"`                 // name of the function or the class
+	Line        int64  `json:"line,omitempty" xml:"line,omitempty"` // line of the smell, when known
+	Explanation string `json:"explanation" xml:"explanation"`
+}
+
+// newResult returns a result in which every metric is unknown.
+func newResult() *Result {
+	return &Result{
 		ProgLangs:      []Language{},
 		AverageFuncLen: -1.0,
 		MaxFuncLen:     -1,
 		MinFuncLen:     -1,
 		MedianFuncLen:  -1,
 		TotalLoC:       -1,
-		Complexity:     ComplexityMetrics{AveragePerFunc: -1, AveragePerFile: -1},
+		Complexity:     ComplexityMetrics{AveragePerFunc: -1, AveragePerFile: -1, Files: []FileComplexity{}},
 		DocCoverage:    CommentRatios{},
+		Comments:       CommentMetrics{Files: []FileComments{}, TaskMarkers: []TaskMarker{}},
+		Dependencies:   DependencyMetrics{Packages: []PackageDeps{}, ExternalPackages: []string{}, Cycles: []ImportCycle{}},
+		DeadCode:       DeadCodeMetrics{Candidates: []DeadDecl{}},
+		Conformance:    ConformanceMetrics{Issues: []ConformanceIssue{}},
+		Halstead:       HalsteadMetrics{Files: []FileHalstead{}},
+		Maintainability: MaintainabilityMetrics{
+			MaintainabilityValues: MaintainabilityValues{Index: -1, Normalized: -1},
+			Packages:              []PackageMaintainability{},
+			Worst:                 []FuncMaintainability{},
+		},
+		Cognitive: CognitiveMetrics{Files: []FileCognitive{}},
+		Nesting:   NestingMetrics{Files: []FileNesting{}, DeepFuncs: []FuncNesting{}},
+		Distributions: DistributionMetrics{
+			FuncLength: Distribution{Histogram: []HistogramBucket{}},
+			Complexity: Distribution{Histogram: []HistogramBucket{}},
+			FileSize:   Distribution{Histogram: []HistogramBucket{}},
+		},
+		Clones:  CloneMetrics{Duplication: -1, Classes: []CloneClass{}},
+		Smells:  SmellMetrics{Counts: []SmellCount{}, Smells: []Smell{}},
+		Metrics: []Metric{},
 	}
-
-	for _, anlzr := range a {
-		err := anlzr.Analyze(p, r)
-		if err != nil {
-			return nil, err
-		}
-	}
-
-	return r, nil
 }
@@ -0,0 +1,230 @@
+// Copyright 2014-2015 The DevMine Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package anlzr
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"hash/fnv"
+	"reflect"
+	"sort"
+
+	"github.com/DevMine/srcanlzr/src"
+	"github.com/DevMine/srcanlzr/src/ast"
+)
+
+// DefaultMinCloneSize is the minimum size, in AST nodes, of the code fragments
+// reported by Clones when no size is given.
+const DefaultMinCloneSize = 30
+
+// Clones detects duplicated code: function bodies and statements, nested
+// ones included, whose syntax trees are identical once the identifier names
+// and the literal values are ignored (type-2 clones). Operators and the kinds
+// of the literals do matter.
+//
+// Each fragment is hashed from the hashes of its children, so that the whole
+// project is processed in linear time. Duplicated fragments are grouped into
+// clone classes; a class is not reported when all its fragments are part of
+// larger duplicated fragments. The duplication is the percentage of the nodes
+// of the function bodies that belong to a duplicated fragment.
+type Clones struct {
+	// MinSize is the minimum size, in AST nodes, of the reported fragments.
+	// When 0, DefaultMinCloneSize is used.
+	MinSize int64
+}
+
+// cloneFragment is a function body or a statement.
+type cloneFragment struct {
+	hash   uint64
+	size   int64 // number of nodes
+	parent int   // index of the enclosing fragment, or -1 for a function body
+	kind   string
+	loc    CloneInstance
+}
+
+func (c Clones) Analyze(p *src.Project, r *Result) error {
+	minSize := c.MinSize
+	if minSize <= 0 {
+		minSize = DefaultMinCloneSize
+	}
+
+	var frags []cloneFragment
+	var total int64
+	for _, pkg := range p.Packages {
+		for _, sf := range pkg.SrcFiles {
+			for _, f := range fileFuncs(sf) {
+				loc := CloneInstance{File: sf.Path, Func: f.Name}
+				frags = hashBody(frags, f.Body, loc)
+				total += frags[len(frags)-1].size
+			}
+		}
+	}
+
+	groups := make(map[uint64][]int)
+	var hashes []uint64 // in order of appearance, for a stable output
+	for i, f := range frags {
+		if f.size < minSize {
+			continue
+		}
+		if _, ok := groups[f.hash]; !ok {
+			hashes = append(hashes, f.hash)
+		}
+		groups[f.hash] = append(groups[f.hash], i)
+	}
+	duplicated := func(i int) bool {
+		return i >= 0 && frags[i].size >= minSize && len(groups[frags[i].hash]) > 1
+	}
+
+	m := CloneMetrics{Classes: []CloneClass{}, Duplication: -1}
+	for _, h := range hashes {
+		members := groups[h]
+		if len(members) < 2 {
+			continue
+		}
+		maximal := false
+		for _, i := range members {
+			maximal = maximal || !duplicated(frags[i].parent)
+		}
+		if !maximal {
+			continue
+		}
+		class := CloneClass{Kind: frags[members[0]].kind, Size: frags[members[0]].size}
+		for _, i := range members {
+			class.Instances = append(class.Instances, frags[i].loc)
+		}
+		m.Classes = append(m.Classes, class)
+	}
+	sort.SliceStable(m.Classes, func(i, j int) bool { return m.Classes[i].Size > m.Classes[j].Size })
+
+	// the fragments are in post-order: the enclosing fragments come after
+	// the ones they enclose
+	covered := make([]bool, len(frags))
+	for i := len(frags) - 1; i >= 0; i-- {
+		parent := frags[i].parent
+		inside := parent >= 0 && covered[parent]
+		covered[i] = inside || duplicated(i)
+		if duplicated(i) && !inside {
+			m.DuplicatedNodes += frags[i].size
+		}
+	}
+	m.Nodes = total
+	if total > 0 {
+		m.Duplication = 100 * float32(m.DuplicatedNodes) / float32(total)
+	}
+
+	r.Clones = m
+
+	return nil
+}
+
+// hashFrame is a node being hashed.
+type hashFrame struct {
+	label    []byte
+	stmt     bool
+	kind     string
+	line     int64
+	children []uint64
+	size     int64
+	frags    []int // indices of the closest enclosed fragments
+}
+
+// hashBody appends to frags the statements of a function body, then the body
+// itself.
+func hashBody(frags []cloneFragment, body []ast.Stmt, loc CloneInstance) []cloneFragment {
+	root := &hashFrame{label: []byte(fmt.Sprint("body ", len(body))), stmt: true, kind: "body"}
+	stack := []*hashFrame{root}
+
+	visit := func(node interface{}) bool {
+		if node == nil {
+			fr := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			frags = closeFrame(frags, fr, stack[len(stack)-1], loc)
+			return false
+		}
+		label, kind, stmt := nodeLabel(node)
+		stack = append(stack, &hashFrame{label: label, stmt: stmt, kind: kind, line: stmtLine(node)})
+		return true
+	}
+	for _, s := range body {
+		ast.Inspect(s, visit)
+	}
+	if len(body) > 0 {
+		root.line = stmtLine(body[0])
+	}
+	return closeFrame(frags, root, nil, loc)
+}
+
+// closeFrame computes the hash of a node once all its children are hashed,
+// appends the node to frags if it is a fragment, and adds it to its parent
+// frame, if any.
+func closeFrame(frags []cloneFragment, fr, parent *hashFrame, loc CloneInstance) []cloneFragment {
+	h := fnv.New64a()
+	h.Write(fr.label)
+	var buf [8]byte
+	for _, c := range fr.children {
+		binary.LittleEndian.PutUint64(buf[:], c)
+		h.Write(buf[:])
+	}
+	sum, size := h.Sum64(), fr.size+1
+
+	enclosed := fr.frags
+	if fr.stmt {
+		idx := len(frags)
+		for _, i := range fr.frags {
+			frags[i].parent = idx
+		}
+		loc.Line = fr.line
+		frags = append(frags, cloneFragment{hash: sum, size: size, parent: -1, kind: fr.kind, loc: loc})
+		enclosed = []int{idx}
+	}
+
+	if parent != nil {
+		parent.children = append(parent.children, sum)
+		parent.size += size
+		parent.frags = append(parent.frags, enclosed...)
+	}
+	return frags
+}
+
+// nodeLabel returns the label of a node, made of its type, its operator or
+// kind if any, the lengths of its lists and the presence of its optional
+// children, but neither names nor values. The lengths and the presence of the
+// children tell apart the trees whose children are the same but differently
+// arranged (e.g. "if c {a; b}" and "if c {a} else {b}"). nodeLabel also tells
+// whether the node is a statement, and returns its statement name if so.
+func nodeLabel(node interface{}) (label []byte, kind string, stmt bool) {
+	var buf bytes.Buffer
+	var walk func(v reflect.Value)
+	walk = func(v reflect.Value) {
+		for i := 0; i < v.NumField(); i++ {
+			f, name := v.Field(i), v.Type().Field(i).Name
+			switch f.Kind() {
+			case reflect.Struct:
+				walk(f)
+			case reflect.Slice:
+				fmt.Fprint(&buf, " ", f.Len())
+			case reflect.Ptr, reflect.Interface:
+				if f.IsNil() {
+					buf.WriteString(" -")
+				} else {
+					buf.WriteString(" +")
+				}
+			case reflect.String:
+				switch name {
+				case "Op", "Kind":
+					buf.WriteString(" " + f.String())
+				case "StmtName":
+					kind, stmt = f.String(), true
+				}
+			}
+		}
+	}
+
+	v := reflect.ValueOf(node).Elem()
+	buf.WriteString(v.Type().Name())
+	walk(v)
+	return buf.Bytes(), kind, stmt
+}
@@ -0,0 +1,80 @@
+// Copyright 2014-2015 The DevMine Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package anlzr_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/DevMine/srcanlzr/anlzr"
+	"github.com/DevMine/srcanlzr/src"
+	"github.com/DevMine/srcanlzr/src/ast"
+	"github.com/DevMine/srcanlzr/src/token"
+)
+
+func TestClones(t *testing.T) {
+	lit := func(v string) *ast.BasicLit {
+		return &ast.BasicLit{ExprName: token.BasicLitName, Kind: token.IntLit, Value: v}
+	}
+	assign := func(x, v string) ast.Stmt {
+		return &ast.AssignStmt{StmtName: token.AssignStmtName, LHS: []ast.Expr{ident(x)}, RHS: []ast.Expr{lit(v)}}
+	}
+	// if x > v { y = w; f() }, or if x > v { y = w } else { f() } when split
+	cond := func(line int64, x, v, y, w, f string, split bool) ast.Stmt {
+		s := &ast.IfStmt{StmtName: token.IfStmtName, Line: line, Cond: binary(ident(x), token.GTR, lit(v))}
+		s.Body = []ast.Stmt{assign(y, w), callStmt("", f)}
+		if split {
+			s.Body, s.Else = s.Body[:1], s.Body[1:]
+		}
+		return s
+	}
+	ret := func(x string) ast.Stmt {
+		return &ast.ReturnStmt{StmtName: token.ReturnStmtName, Results: []ast.Expr{ident(x)}}
+	}
+
+	p := &src.Project{
+		Packages: []*src.Package{
+			&src.Package{
+				SrcFiles: []*src.SrcFile{
+					&src.SrcFile{
+						Path: "a",
+						Funcs: []*ast.FuncDecl{
+							&ast.FuncDecl{Name: "f", Body: []ast.Stmt{cond(1, "x", "1", "y", "2", "g", false), ret("y")}},
+							&ast.FuncDecl{Name: "g", Body: []ast.Stmt{cond(5, "a", "3", "b", "4", "h", false), ret("b")}},
+						},
+					},
+					&src.SrcFile{
+						Path: "b",
+						Funcs: []*ast.FuncDecl{
+							&ast.FuncDecl{Name: "h", Body: []ast.Stmt{assign("z", "0"), cond(2, "z", "0", "z", "1", "f", false)}},
+							&ast.FuncDecl{Name: "k", Body: []ast.Stmt{cond(7, "z", "0", "z", "1", "f", true), ret("z")}},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	res, err := anlzr.RunAnalyzers(p, anlzr.Clones{MinSize: 5})
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := res.Clones
+
+	expected := []anlzr.CloneClass{
+		{Kind: "body", Size: 13, Instances: []anlzr.CloneInstance{{File: "a", Func: "f", Line: 1}, {File: "a", Func: "g", Line: 5}}},
+		{Kind: token.IfStmtName, Size: 10, Instances: []anlzr.CloneInstance{
+			{File: "a", Func: "f", Line: 1}, {File: "a", Func: "g", Line: 5}, {File: "b", Func: "h", Line: 2},
+		}},
+	}
+	if !reflect.DeepEqual(m.Classes, expected) {
+		t.Errorf("classes: found %+v, expected %+v", m.Classes, expected)
+	}
+	// the bodies of f and g, and the if statement of h; the if statement of
+	// k is laid out differently
+	if m.Nodes != 53 || m.DuplicatedNodes != 36 {
+		t.Errorf("nodes: found %d nodes, %d duplicated, expected 53 and 36", m.Nodes, m.DuplicatedNodes)
+	}
+}
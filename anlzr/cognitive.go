@@ -0,0 +1,211 @@
+// Copyright 2014-2015 The DevMine Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package anlzr
+
+import (
+	"strings"
+
+	"github.com/DevMine/srcanlzr/src"
+	"github.com/DevMine/srcanlzr/src/ast"
+	"github.com/DevMine/srcanlzr/src/token"
+)
+
+// CognitiveComplexity computes the cognitive complexity of each function, as
+// specified by SonarSource ("Cognitive Complexity, a new way of measuring
+// understandability", G. Ann Campbell, 2017):
+//
+//   - if statements, ternary expressions, switch statements, loops and catch
+//     clauses increment the complexity by one plus their nesting level;
+//   - else and else if clauses, each sequence of like logical operators (&&,
+//     ||) and recursive calls increment the complexity by one;
+//   - the bodies of the above structures, except else if clauses, as well as
+//     function literals and class literals, increase the nesting level.
+//
+// Labelled jumps (break and continue to a label, goto) cannot be counted since
+// the src/ast package does not represent jump statements.
+type CognitiveComplexity struct{}
+
+func (cc CognitiveComplexity) Analyze(p *src.Project, r *Result) error {
+	m := CognitiveMetrics{Files: []FileCognitive{}}
+
+	var funcs int64
+	for _, pkg := range p.Packages {
+		for _, sf := range pkg.SrcFiles {
+			fc := FileCognitive{Path: sf.Path, Funcs: []FuncCognitive{}}
+			for _, f := range fileFuncs(sf) {
+				c := cognitiveComplexity(f)
+				fc.Funcs = append(fc.Funcs, FuncCognitive{Name: f.Name, Complexity: c})
+				fc.Total += c
+				if c > m.Max {
+					m.Max = c
+				}
+			}
+			funcs += int64(len(fc.Funcs))
+			m.Total += fc.Total
+			m.Files = append(m.Files, fc)
+		}
+	}
+	if funcs > 0 {
+		m.AveragePerFunc = float32(m.Total) / float32(funcs)
+	}
+
+	r.Cognitive = m
+
+	return nil
+}
+
+// cognitiveComplexity returns the cognitive complexity of a function.
+func cognitiveComplexity(f function) int64 {
+	name := f.Name
+	if i := strings.LastIndex(name, "."); i >= 0 {
+		name = name[i+1:]
+	}
+	cv := &cognitiveVisitor{name: name}
+	cv.body(f.Body, 0)
+	return cv.complexity
+}
+
+// A cognitiveVisitor computes the cognitive complexity of a function body.
+type cognitiveVisitor struct {
+	name       string // name of the function, to detect recursive calls
+	complexity int64
+}
+
+func (cv *cognitiveVisitor) body(stmts []ast.Stmt, nesting int64) {
+	for _, s := range stmts {
+		cv.walk(s, nesting)
+	}
+}
+
+// walk visits the tree rooted at node, at the given nesting level.
+func (cv *cognitiveVisitor) walk(node interface{}, nesting int64) {
+	ast.Inspect(node, func(node interface{}) bool {
+		switch n := node.(type) {
+		case nil:
+			return false
+		case *ast.IfStmt:
+			cv.complexity += 1 + nesting
+			cv.ifStmt(n, nesting)
+		case *ast.LoopStmt:
+			cv.complexity += 1 + nesting
+			cv.body(n.Init, nesting)
+			cv.walk(n.Cond, nesting)
+			cv.body(n.Post, nesting)
+			cv.body(n.Body, nesting+1)
+			cv.elseClause(n.Else, nesting)
+		case *ast.RangeLoopStmt:
+			cv.complexity += 1 + nesting
+			for _, v := range n.Vars {
+				cv.walk(v, nesting)
+			}
+			cv.walk(n.Iterable, nesting)
+			cv.body(n.Body, nesting+1)
+		case *ast.SwitchStmt:
+			cv.complexity += 1 + nesting
+			cv.walk(n.Init, nesting)
+			cv.walk(n.Cond, nesting)
+			for _, cc := range n.CaseClauses {
+				for _, cond := range cc.Conds {
+					cv.walk(cond, nesting)
+				}
+				cv.body(cc.Body, nesting+1)
+			}
+			cv.body(n.Default, nesting+1)
+		case *ast.TryStmt:
+			cv.body(n.Body, nesting)
+			for _, cc := range n.CatchClauses {
+				cv.complexity += 1 + nesting
+				cv.body(cc.Body, nesting+1)
+			}
+			cv.body(n.Finally, nesting)
+		case *ast.TernaryExpr:
+			cv.complexity += 1 + nesting
+			cv.walk(n.Cond, nesting)
+			cv.walk(n.Then, nesting+1)
+			cv.walk(n.Else, nesting+1)
+		case *ast.BinaryExpr:
+			if !isLogicalOp(n.Op) {
+				return true
+			}
+			var ops []string
+			for _, operand := range cv.logicalSequence(n, &ops) {
+				cv.walk(operand, nesting)
+			}
+			for i, op := range ops {
+				if i == 0 || op != ops[i-1] {
+					cv.complexity++
+				}
+			}
+		case *ast.CallExpr:
+			cv.call(n.Fun)
+			return true
+		case *ast.FuncLit:
+			cv.body(n.Body, nesting+1)
+		case *ast.ClassLit:
+			for _, c := range n.Constructors {
+				cv.body(c.Body, nesting+1)
+			}
+			for _, d := range n.Destructors {
+				cv.body(d.Body, nesting+1)
+			}
+			for _, m := range n.Methods {
+				cv.body(m.Body, nesting+1)
+			}
+		default:
+			return true
+		}
+		return false
+	})
+}
+
+func (cv *cognitiveVisitor) ifStmt(is *ast.IfStmt, nesting int64) {
+	cv.walk(is.Init, nesting)
+	cv.walk(is.Cond, nesting)
+	cv.body(is.Body, nesting+1)
+
+	// else if: the nesting level is the one of the first if
+	if len(is.Else) == 1 {
+		if elif, ok := is.Else[0].(*ast.IfStmt); ok {
+			cv.complexity++
+			cv.ifStmt(elif, nesting)
+			return
+		}
+	}
+	cv.elseClause(is.Else, nesting)
+}
+
+func (cv *cognitiveVisitor) elseClause(stmts []ast.Stmt, nesting int64) {
+	if len(stmts) > 0 {
+		cv.complexity++
+		cv.body(stmts, nesting+1)
+	}
+}
+
+// call increments the complexity for recursive calls.
+func (cv *cognitiveVisitor) call(fun *ast.FuncRef) {
+	if fun == nil || fun.FuncName != cv.name {
+		return
+	}
+	switch fun.Namespace {
+	case "", "this", "self":
+		cv.complexity++
+	}
+}
+
+// logicalSequence flattens a sequence of logical operators into ops, in order
+// of appearance, and returns the operands of the sequence.
+func (cv *cognitiveVisitor) logicalSequence(e ast.Expr, ops *[]string) []ast.Expr {
+	be, ok := e.(*ast.BinaryExpr)
+	if !ok || !isLogicalOp(be.Op) {
+		return []ast.Expr{e}
+	}
+	operands := cv.logicalSequence(be.LeftExpr, ops)
+	*ops = append(*ops, be.Op)
+	return append(operands, cv.logicalSequence(be.RightExpr, ops)...)
+}
+
+func isLogicalOp(op string) bool {
+	return op == token.LAND || op == token.LOR
+}
@@ -0,0 +1,82 @@
+// Copyright 2014-2015 The DevMine Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package anlzr_test
+
+import (
+	"testing"
+
+	"github.com/DevMine/srcanlzr/anlzr"
+	"github.com/DevMine/srcanlzr/src"
+	"github.com/DevMine/srcanlzr/src/ast"
+	"github.com/DevMine/srcanlzr/src/token"
+)
+
+func TestCognitiveComplexity(t *testing.T) {
+	ternary := &ast.TernaryExpr{ExprName: token.TernaryExprName, Cond: ident("x"), Then: ident("y"), Else: ident("z")}
+	f := &ast.FuncDecl{
+		Name: "f",
+		Body: []ast.Stmt{
+			&ast.IfStmt{ // +1
+				StmtName: token.IfStmtName,
+				Cond:     binary(binary(ident("a"), token.LAND, ident("b")), token.LOR, ident("c")), // +2
+				Body: []ast.Stmt{
+					&ast.LoopStmt{ // +2
+						StmtName: token.LoopStmtName,
+						Body:     []ast.Stmt{&ast.ExprStmt{StmtName: token.ExprStmtName, X: ternary}}, // +3
+					},
+				},
+				Else: []ast.Stmt{
+					&ast.IfStmt{ // +1
+						StmtName: token.IfStmtName,
+						Cond:     ident("d"),
+						Else:     []ast.Stmt{&ast.ReturnStmt{StmtName: token.ReturnStmtName}}, // +1
+					},
+				},
+			},
+			&ast.TryStmt{
+				StmtName:     token.TryStmtName,
+				CatchClauses: []*ast.CatchClause{&ast.CatchClause{Body: []ast.Stmt{callStmt("", "f")}}}, // +1, +1
+			},
+			&ast.SwitchStmt{StmtName: token.SwitchStmtName}, // +1
+		},
+	}
+	g := &ast.FuncDecl{
+		Name: "g",
+		Body: []ast.Stmt{
+			&ast.ExprStmt{
+				StmtName: token.ExprStmtName,
+				X: &ast.FuncLit{
+					ExprName: token.FuncLitName,
+					Body:     []ast.Stmt{&ast.IfStmt{StmtName: token.IfStmtName, Cond: ident("a")}}, // +2
+				},
+			},
+		},
+	}
+	p := &src.Project{
+		Packages: []*src.Package{
+			&src.Package{
+				SrcFiles: []*src.SrcFile{
+					&src.SrcFile{Path: "foo.go", Funcs: []*ast.FuncDecl{f, g, &ast.FuncDecl{Name: "h"}}},
+				},
+			},
+		},
+	}
+
+	r, err := anlzr.RunAnalyzers(p, anlzr.CognitiveComplexity{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := r.Cognitive
+
+	expected := []int64{13, 2, 0}
+	for i, fc := range m.Files[0].Funcs {
+		if fc.Complexity != expected[i] {
+			t.Errorf("%s: found %d, expected %d", fc.Name, fc.Complexity, expected[i])
+		}
+	}
+	if m.Total != 15 || m.Files[0].Total != 15 || m.Max != 13 || m.AveragePerFunc != 5 {
+		t.Errorf("found total %d, max %d and average %f, expected 15, 13 and 5", m.Total, m.Max, m.AveragePerFunc)
+	}
+}
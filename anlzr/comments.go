@@ -0,0 +1,223 @@
+// Copyright 2014-2015 The DevMine Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package anlzr
+
+import (
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf16"
+	"unicode/utf8"
+
+	"github.com/DevMine/srcanlzr/src"
+	"github.com/DevMine/srcanlzr/src/ast"
+)
+
+// Task markers recognized in the comments.
+const (
+	TodoMarker  = "TODO"
+	FixmeMarker = "FIXME"
+	HackMarker  = "HACK"
+	XXXMarker   = "XXX"
+)
+
+var taskMarkers = []string{TodoMarker, FixmeMarker, HackMarker, XXXMarker}
+
+// CommentDensity computes the comment density of each source file and
+// collects the task markers (TODO, FIXME, etc.) found in the comments. It
+// relies on the comments attached to the source files (see src.SrcFile).
+type CommentDensity struct{}
+
+func (cd CommentDensity) Analyze(p *src.Project, r *Result) error {
+	m := CommentMetrics{
+		Files:       []FileComments{},
+		TaskMarkers: []TaskMarker{},
+	}
+
+	var loc int64
+	for _, pkg := range p.Packages {
+		for _, sf := range pkg.SrcFiles {
+			fc := FileComments{Path: sf.Path}
+			for _, c := range sf.Comments {
+				fc.Lines += commentLines(c)
+				for _, tm := range findTaskMarkers(c) {
+					tm.File = sf.Path
+					m.TaskMarkers = append(m.TaskMarkers, tm)
+					fc.TaskMarkers++
+				}
+			}
+			fc.Density = density(fc.Lines, sf.LoC)
+
+			m.Lines += fc.Lines
+			loc += sf.LoC
+			m.Files = append(m.Files, fc)
+		}
+	}
+	m.Density = density(m.Lines, loc)
+
+	for _, tm := range m.TaskMarkers {
+		switch tm.Marker {
+		case TodoMarker:
+			m.Todos++
+		case FixmeMarker:
+			m.Fixmes++
+		case HackMarker:
+			m.Hacks++
+		case XXXMarker:
+			m.XXXs++
+		}
+	}
+
+	r.Comments = m
+
+	return nil
+}
+
+// density returns the ratio of comment lines over the total number of lines,
+// comments included.
+func density(comLines, loc int64) float32 {
+	if comLines+loc == 0 {
+		return 0
+	}
+	return float32(comLines) / float32(comLines+loc)
+}
+
+// commentLines returns the number of lines spanned by a comment.
+func commentLines(c *ast.Comment) int64 {
+	return int64(len(textLines(c.Text)))
+}
+
+// textLines splits the text of a comment into lines, once unescaped.
+func textLines(text string) []string {
+	return strings.Split(unescape(text), "\n")
+}
+
+// unescape replaces the JSON escape sequences of a decoded string (see
+// ast.Comment.Text) by the characters they stand for. Invalid sequences are
+// left as is.
+func unescape(s string) string {
+	if strings.IndexByte(s, '\\') < 0 {
+		return s
+	}
+
+	var buf []rune
+	for i := 0; i < len(s); {
+		if s[i] != '\\' || i+1 == len(s) {
+			r, size := utf8.DecodeRuneInString(s[i:])
+			buf = append(buf, r)
+			i += size
+			continue
+		}
+		switch c := s[i+1]; c {
+		case '"', '\\', '/':
+			buf = append(buf, rune(c))
+		case 'b':
+			buf = append(buf, '\b')
+		case 'f':
+			buf = append(buf, '\f')
+		case 'n':
+			buf = append(buf, '\n')
+		case 'r':
+			buf = append(buf, '\r')
+		case 't':
+			buf = append(buf, '\t')
+		case 'u':
+			r, n := unescapeUnicode(s[i:])
+			if n == 0 {
+				buf = append(buf, '\\')
+				i++
+				continue
+			}
+			buf = append(buf, r)
+			i += n
+			continue
+		default:
+			buf = append(buf, '\\', rune(c))
+		}
+		i += 2
+	}
+	return string(buf)
+}
+
+// unescapeUnicode decodes the \uXXXX sequence at the beginning of s, followed
+// by the second half of a surrogate pair if needed. It returns the decoded
+// rune and the length of the sequences, or 0 if s does not start with a valid
+// sequence.
+func unescapeUnicode(s string) (rune, int) {
+	hex := func(s string) (rune, bool) {
+		if len(s) < 6 || s[0] != '\\' || s[1] != 'u' {
+			return 0, false
+		}
+		v, err := strconv.ParseUint(s[2:6], 16, 16)
+		return rune(v), err == nil
+	}
+
+	r, ok := hex(s)
+	if !ok {
+		return 0, 0
+	}
+	if utf16.IsSurrogate(r) {
+		if r2, ok := hex(s[6:]); ok {
+			if dec := utf16.DecodeRune(r, r2); dec != unicode.ReplacementChar {
+				return dec, 12
+			}
+		}
+	}
+	return r, 6
+}
+
+// findTaskMarkers returns the task markers of a comment. Only the first
+// marker of each line is reported.
+func findTaskMarkers(c *ast.Comment) []TaskMarker {
+	var tms []TaskMarker
+	for i, line := range textLines(c.Text) {
+		var tm *TaskMarker
+		pos := -1
+		for _, marker := range taskMarkers {
+			text, j := cutMarker(line, marker)
+			if j >= 0 && (pos < 0 || j < pos) {
+				tm = &TaskMarker{Marker: marker, Line: c.Line + int64(i), Text: text}
+				pos = j
+			}
+		}
+		if tm != nil {
+			tms = append(tms, *tm)
+		}
+	}
+	return tms
+}
+
+// cutMarker looks for marker as a whole word in line. If found, it returns the
+// text following the marker, stripped of the usual punctuation (e.g. the
+// colon of "TODO: foo" or the author of "TODO(bob) foo"), and the index of
+// the marker in line. Otherwise, the index is -1.
+func cutMarker(line, marker string) (string, int) {
+	for off := 0; ; {
+		i := strings.Index(line[off:], marker)
+		if i < 0 {
+			return "", -1
+		}
+		start, end := off+i, off+i+len(marker)
+		off = end
+		if start > 0 && isWordChar(rune(line[start-1])) {
+			continue
+		}
+		if end < len(line) && isWordChar(rune(line[end])) {
+			continue
+		}
+
+		text := line[end:]
+		if strings.HasPrefix(text, "(") {
+			if j := strings.Index(text, ")"); j >= 0 {
+				text = text[j+1:]
+			}
+		}
+		return strings.TrimSpace(strings.TrimLeft(strings.TrimSpace(text), ":-")), start
+	}
+}
+
+func isWordChar(r rune) bool {
+	return r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r)
+}
@@ -0,0 +1,104 @@
+// Copyright 2014-2015 The DevMine Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package anlzr_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/DevMine/srcanlzr/anlzr"
+	"github.com/DevMine/srcanlzr/src"
+	"github.com/DevMine/srcanlzr/src/ast"
+	"github.com/DevMine/srcanlzr/src/token"
+)
+
+func TestCommentDensity(t *testing.T) {
+	p := &src.Project{
+		Packages: []*src.Package{
+			&src.Package{
+				SrcFiles: []*src.SrcFile{
+					&src.SrcFile{
+						Path: "foo.go",
+						LoC:  12,
+						Comments: []*ast.Comment{
+							&ast.Comment{Kind: token.HeaderComment, Text: `Copyright\nLicense`, Line: 1},
+							&ast.Comment{Kind: token.LineComment, Text: "TODO(bob): handle errors", Line: 10},
+							&ast.Comment{Kind: token.BlockComment, Text: "FIXMEs are not\n  FIXME - split", Line: 14},
+							&ast.Comment{Kind: token.LineComment, Text: "XXX", Line: 20},
+							&ast.Comment{Kind: token.LineComment, Text: "HACK: TODO later", Line: 21},
+						},
+					},
+					&src.SrcFile{Path: "bar.go", LoC: 4},
+				},
+			},
+		},
+	}
+
+	r, err := anlzr.RunAnalyzers(p, anlzr.CommentDensity{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := r.Comments
+
+	if m.Lines != 7 {
+		t.Errorf("lines: found %d, expected 7", m.Lines)
+	}
+	if m.Density != 7.0/23.0 {
+		t.Errorf("density: found %f, expected %f", m.Density, 7.0/23.0)
+	}
+	if m.Todos != 1 || m.Fixmes != 1 || m.Hacks != 1 || m.XXXs != 1 {
+		t.Errorf("markers: found %d/%d/%d/%d, expected 1/1/1/1", m.Todos, m.Fixmes, m.Hacks, m.XXXs)
+	}
+
+	expected := []anlzr.TaskMarker{
+		{Marker: anlzr.TodoMarker, File: "foo.go", Line: 10, Text: "handle errors"},
+		{Marker: anlzr.FixmeMarker, File: "foo.go", Line: 15, Text: "split"},
+		{Marker: anlzr.XXXMarker, File: "foo.go", Line: 20, Text: ""},
+		{Marker: anlzr.HackMarker, File: "foo.go", Line: 21, Text: "TODO later"},
+	}
+	if len(m.TaskMarkers) != len(expected) {
+		t.Fatalf("task markers: found %d, expected %d", len(m.TaskMarkers), len(expected))
+	}
+	for i, tm := range m.TaskMarkers {
+		if tm != expected[i] {
+			t.Errorf("task marker %d: found %+v, expected %+v", i, tm, expected[i])
+		}
+	}
+
+	if len(m.Files) != 2 || m.Files[0].TaskMarkers != 4 || m.Files[1].Density != 0 {
+		t.Errorf("files: found %+v", m.Files)
+	}
+}
+
+func TestCommentEscapes(t *testing.T) {
+	p := &src.Project{
+		Packages: []*src.Package{
+			&src.Package{
+				SrcFiles: []*src.SrcFile{
+					&src.SrcFile{
+						Path: "foo.go",
+						Comments: []*ast.Comment{
+							&ast.Comment{Kind: token.BlockComment, Text: `C:\\new\nTODO: say \"hi\" \u00e9\ud83d\ude00`, Line: 3},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	r, err := anlzr.RunAnalyzers(p, anlzr.CommentDensity{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := r.Comments
+
+	if m.Lines != 2 {
+		t.Errorf("lines: found %d, expected 2", m.Lines)
+	}
+	expected := []anlzr.TaskMarker{{Marker: anlzr.TodoMarker, File: "foo.go", Line: 4, Text: "say \"hi\" \u00e9\U0001F600"}}
+	if !reflect.DeepEqual(m.TaskMarkers, expected) {
+		t.Errorf("task markers: found %+v, expected %+v", m.TaskMarkers, expected)
+	}
+}
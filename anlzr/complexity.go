@@ -7,201 +7,143 @@ package anlzr
 import (
 	"github.com/DevMine/srcanlzr/src"
 	"github.com/DevMine/srcanlzr/src/ast"
+	"github.com/DevMine/srcanlzr/src/token"
 )
 
-type Complexity struct{}
+// ComplexityRules tells which constructs are decision points, in addition to
+// the if statements and the loops which always are.
+type ComplexityRules struct {
+	Cases      bool // each case clause of a switch (the default clause is not)
+	Catches    bool // each catch clause of a try statement
+	Ternaries  bool // each ternary expression
+	LogicalOps bool // each && and || operator
+	LoopElses  bool // the else clause of a loop (Python's for...else)
+}
 
-func (c Complexity) Analyze(p *src.Project, r *Result) error {
-	cm := ComplexityMetrics{}
+// DefaultComplexityRules is the per-language rule table used by the
+// Complexity analyzer. The rules follow the constructs available in each
+// language:
+//
+//	language  cases  catches  ternaries  logical ops  loop elses
+//	Go        yes    -        -          yes          -
+//	C         yes    -        yes        yes          -
+//	Java      yes    yes      yes        yes          -
+//	Scala     yes    yes      -          yes          -
+//	Python    -      yes      yes        yes          yes
+//	Ruby      yes    yes      yes        yes          -
+//
+// Languages that are not listed use FallbackComplexityRules.
+var DefaultComplexityRules = map[string]ComplexityRules{
+	src.Go:     {Cases: true, LogicalOps: true},
+	src.C:      {Cases: true, Ternaries: true, LogicalOps: true},
+	src.Java:   {Cases: true, Catches: true, Ternaries: true, LogicalOps: true},
+	src.Scala:  {Cases: true, Catches: true, LogicalOps: true},
+	src.Python: {Catches: true, Ternaries: true, LogicalOps: true, LoopElses: true},
+	src.Ruby:   {Cases: true, Catches: true, Ternaries: true, LogicalOps: true},
+}
 
-	var totalFuncs int64
-	var totalFiles int64
+// FallbackComplexityRules are the rules used for the languages that are not
+// in the rule table.
+var FallbackComplexityRules = ComplexityRules{Cases: true, Catches: true, Ternaries: true, LogicalOps: true}
+
+// Complexity computes the cyclomatic complexity, also known as McCabe metric,
+// of every function, method, constructor and destructor of the project. The
+// complexity of a function is one plus its number of decision points, as
+// defined by the rules of its language. The decision points of the function
+// and class literals count for the function in which they appear.
+type Complexity struct {
+	// Rules overrides DefaultComplexityRules for the given languages; it may
+	// be nil.
+	Rules map[string]ComplexityRules
+}
 
-	var totalComplexityPerFunc int64
-	var totalComplexityPerFile float32
+func (c Complexity) Analyze(p *src.Project, r *Result) error {
+	cm := ComplexityMetrics{AveragePerFunc: -1, AveragePerFile: -1, Files: []FileComplexity{}}
 
+	var totalFuncs, totalFiles int64
+	var totalPerFile float32
 	for _, pkg := range p.Packages {
 		for _, sf := range pkg.SrcFiles {
-			var fileComplexity int64
-			var numFuncs int64
-
-			for _, f := range sf.Funcs {
-				numFuncs++
-				fileComplexity += functionCyclomaticComplexity(f)
-
-				/*for _, stmt := range f.Body {
-					switch stmt.(type) {
-					case src.IfStmt, src.LoopStmt:
-						fileComplexity++
-					}
-				}
-
-				fileComplexity += int64(len(f.Type.Results))*/
-			}
-
-			for _, cls := range sf.Classes {
-				for _, m := range cls.Methods {
-					numFuncs++
-					fileComplexity += methodCyclomaticComplexity(m)
-
-					/*for _, stmt := range m.Body {
-						switch stmt.(type) {
-						case src.IfStmt, src.LoopStmt:
-							fileComplexity++
-						}
-					}
-
-					fileComplexity += int64(len(m.Type.Results))*/
-				}
-			}
-
-			for _, mod := range sf.Traits {
-				for _, m := range mod.Methods {
-					numFuncs++
-					fileComplexity += methodCyclomaticComplexity(m)
-
-					/*for _, stmt := range m.Body {
-						switch stmt.(type) {
-						case src.IfStmt, src.LoopStmt:
-							fileComplexity++
-						}
-					}
-
-					fileComplexity += int64(len(m.Type.Results))*/
+			rules := c.rules(sf)
+			fc := FileComplexity{Path: sf.Path, Funcs: []FuncComplexity{}}
+			for _, f := range fileFuncs(sf) {
+				cc := cyclomaticComplexity(f.Body, rules)
+				fc.Funcs = append(fc.Funcs, FuncComplexity{Name: f.Name, Complexity: cc})
+				fc.Total += cc
+				if cc > cm.Max {
+					cm.Max = cc
 				}
 			}
+			cm.Files = append(cm.Files, fc)
 
-			if numFuncs > 0 {
+			if n := int64(len(fc.Funcs)); n > 0 {
 				totalFiles++
-				totalFuncs += numFuncs
-				totalComplexityPerFunc += fileComplexity
-				totalComplexityPerFile += float32(fileComplexity) / float32(numFuncs)
+				totalFuncs += n
+				cm.Total += fc.Total
+				totalPerFile += float32(fc.Total) / float32(n)
 			}
 		}
 	}
 
-	cm.AveragePerFunc = float32(totalComplexityPerFunc) / float32(totalFuncs)
-	cm.AveragePerFile = totalComplexityPerFile / float32(totalFiles)
+	if totalFuncs > 0 {
+		cm.AveragePerFunc = float32(cm.Total) / float32(totalFuncs)
+		cm.AveragePerFile = totalPerFile / float32(totalFiles)
+	}
 
 	r.Complexity = cm
 
 	return nil
 }
 
-func functionCyclomaticComplexity(f *ast.FuncDecl) int64 {
-	cc := int64(1) // cyclomatic complexity
-
-	for _, s := range f.Body {
-		cc += statementComplexity(s)
+// rules returns the complexity rules of the language of a source file.
+func (c Complexity) rules(sf *src.SrcFile) ComplexityRules {
+	if sf.Lang == nil {
+		return FallbackComplexityRules
 	}
-
-	return cc
-}
-
-func methodCyclomaticComplexity(m *ast.MethodDecl) int64 {
-	cc := int64(1) // cyclomatic complexity
-
-	for _, s := range m.Body {
-		cc += statementComplexity(s)
+	if rules, ok := c.Rules[sf.Lang.Lang]; ok {
+		return rules
 	}
-
-	return cc
-}
-
-func exprComplexity(e ast.Expr) int64 {
-	var c int64
-
-	switch e.(type) {
-	case *ast.BinaryExpr:
-		be := e.(*ast.BinaryExpr)
-		// TODO check the operator
-		c += exprComplexity(be.LeftExpr)
-		c += exprComplexity(be.RightExpr)
+	if rules, ok := DefaultComplexityRules[sf.Lang.Lang]; ok {
+		return rules
 	}
-
-	return c
+	return FallbackComplexityRules
 }
 
-func statementComplexity(s ast.Stmt) int64 {
-	var c int64
-
-	switch s.(type) {
-	case *ast.IfStmt:
-		c++
-
-		is := s.(*ast.IfStmt)
-
-		if is.Cond != nil {
-			c += exprComplexity(is.Cond)
-		}
-
-		for _, s := range is.Body {
-			c += statementComplexity(s)
-		}
-
-		if is.Else != nil && len(is.Else) > 0 {
-			for _, s := range is.Else {
-				c += statementComplexity(s)
+// cyclomaticComplexity returns the cyclomatic complexity of a function body.
+func cyclomaticComplexity(body []ast.Stmt, rules ComplexityRules) int64 {
+	cc := int64(1)
+	visit := func(node interface{}) bool {
+		switch n := node.(type) {
+		case nil:
+			return false
+		case *ast.IfStmt, *ast.RangeLoopStmt:
+			cc++
+		case *ast.LoopStmt:
+			cc++
+			if rules.LoopElses && len(n.Else) > 0 {
+				cc++
 			}
-		}
-	case *ast.LoopStmt:
-		c++
-
-		ls := s.(*ast.LoopStmt)
-
-		if ls.Cond != nil {
-			c += exprComplexity(ls.Cond)
-		}
-
-		for _, s := range ls.Body {
-			c += statementComplexity(s)
-		}
-
-		if ls.Else != nil && len(ls.Else) > 0 {
-			for _, s := range ls.Else {
-				c += statementComplexity(s)
+		case *ast.CaseClause:
+			if rules.Cases {
+				cc++
 			}
-		}
-	case *ast.RangeLoopStmt:
-		c++
-
-		rls := s.(*ast.RangeLoopStmt)
-
-		for _, s := range rls.Body {
-			c += statementComplexity(s)
-		}
-	case *ast.SwitchStmt:
-		c++
-
-		ss := s.(*ast.SwitchStmt)
-
-		if ss.Cond != nil {
-			c += exprComplexity(ss.Cond)
-		}
-
-		if ss.CaseClauses != nil {
-			for _, cc := range ss.CaseClauses {
-				c++
-
-				for _, cond := range cc.Conds {
-					if cond != nil {
-						c += exprComplexity(cond)
-					}
-				}
-
-				for _, s := range cc.Body {
-					c += statementComplexity(s)
-				}
+		case *ast.CatchClause:
+			if rules.Catches {
+				cc++
 			}
-		}
-
-		if ss.Default != nil && len(ss.Default) > 0 {
-			for _, s := range ss.Default {
-				c += statementComplexity(s)
+		case *ast.TernaryExpr:
+			if rules.Ternaries {
+				cc++
+			}
+		case *ast.BinaryExpr:
+			if rules.LogicalOps && (n.Op == token.LAND || n.Op == token.LOR) {
+				cc++
 			}
 		}
-
+		return true
 	}
-
-	return c
+	for _, stmt := range body {
+		ast.Inspect(stmt, visit)
+	}
+	return cc
 }
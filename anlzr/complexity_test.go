@@ -0,0 +1,97 @@
+// Copyright 2014-2015 The DevMine Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package anlzr_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/DevMine/srcanlzr/anlzr"
+	"github.com/DevMine/srcanlzr/src"
+	"github.com/DevMine/srcanlzr/src/ast"
+	"github.com/DevMine/srcanlzr/src/token"
+)
+
+func TestComplexity(t *testing.T) {
+	// 1 + if + && + || + 2 cases + catch + ternary + loop in a function literal
+	body := []ast.Stmt{
+		&ast.IfStmt{
+			StmtName: token.IfStmtName,
+			Cond:     binary(binary(ident("a"), token.LAND, ident("b")), token.LOR, ident("c")),
+		},
+		&ast.SwitchStmt{
+			StmtName:    token.SwitchStmtName,
+			CaseClauses: []*ast.CaseClause{&ast.CaseClause{Conds: []ast.Expr{ident("x")}}, &ast.CaseClause{Conds: []ast.Expr{ident("y")}}},
+			Default:     []ast.Stmt{&ast.ReturnStmt{StmtName: token.ReturnStmtName}},
+		},
+		&ast.TryStmt{StmtName: token.TryStmtName, CatchClauses: []*ast.CatchClause{&ast.CatchClause{}}},
+		&ast.ReturnStmt{
+			StmtName: token.ReturnStmtName,
+			Results: []ast.Expr{
+				&ast.TernaryExpr{ExprName: token.TernaryExprName, Cond: ident("a"), Then: ident("b"), Else: ident("c")},
+				&ast.FuncLit{ExprName: token.FuncLitName, Body: []ast.Stmt{&ast.LoopStmt{StmtName: token.LoopStmtName}}},
+			},
+		},
+	}
+	loopElse := []ast.Stmt{
+		&ast.LoopStmt{StmtName: token.LoopStmtName, Else: []ast.Stmt{&ast.ReturnStmt{StmtName: token.ReturnStmtName}}},
+	}
+	method := func(name string, body []ast.Stmt) *ast.MethodDecl {
+		return &ast.MethodDecl{FuncDecl: ast.FuncDecl{Name: name, Body: body}}
+	}
+
+	p := &src.Project{
+		Packages: []*src.Package{
+			&src.Package{
+				SrcFiles: []*src.SrcFile{
+					&src.SrcFile{
+						Path: "Foo.java",
+						Lang: &src.Language{Lang: src.Java},
+						Classes: []*ast.ClassDecl{
+							&ast.ClassDecl{
+								Name:          "Foo",
+								Constructors:  []*ast.ConstructorDecl{&ast.ConstructorDecl{Name: "Foo", Body: body}},
+								NestedClasses: []*ast.ClassDecl{&ast.ClassDecl{Name: "Bar", Methods: []*ast.MethodDecl{method("m", loopElse)}}},
+							},
+						},
+						Enums: []*ast.EnumDecl{&ast.EnumDecl{Name: "Color", Methods: []*ast.MethodDecl{method("m", nil)}}},
+					},
+					&src.SrcFile{
+						Path:  "foo.py",
+						Lang:  &src.Language{Lang: src.Python},
+						Funcs: []*ast.FuncDecl{&ast.FuncDecl{Name: "f", Body: loopElse}, &ast.FuncDecl{Name: "g", Body: body}},
+					},
+				},
+			},
+		},
+	}
+
+	r, err := anlzr.RunAnalyzers(p, anlzr.Complexity{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := r.Complexity
+
+	expected := []anlzr.FileComplexity{
+		{Path: "Foo.java", Total: 12, Funcs: []anlzr.FuncComplexity{{Name: "Foo.Foo", Complexity: 9}, {Name: "Foo.Bar.m", Complexity: 2}, {Name: "Color.m", Complexity: 1}}},
+		{Path: "foo.py", Total: 10, Funcs: []anlzr.FuncComplexity{{Name: "f", Complexity: 3}, {Name: "g", Complexity: 7}}},
+	}
+	if !reflect.DeepEqual(m.Files, expected) {
+		t.Errorf("files: found %+v, expected %+v", m.Files, expected)
+	}
+	if m.Total != 22 || m.Max != 9 || m.AveragePerFunc != 22.0/5 || m.AveragePerFile != (4+5)/2.0 {
+		t.Errorf("found total %d, max %d, averages %f and %f, expected 22, 9, %f and %f",
+			m.Total, m.Max, m.AveragePerFunc, m.AveragePerFile, 22.0/5, (4+5)/2.0)
+	}
+
+	// custom rules
+	rules := map[string]anlzr.ComplexityRules{src.Python: {}}
+	if r, err = anlzr.RunAnalyzers(p, anlzr.Complexity{Rules: rules}); err != nil {
+		t.Fatal(err)
+	}
+	if found := r.Complexity.Files[1].Total; found != 2+3 {
+		t.Errorf("custom rules: found %d, expected 5", found)
+	}
+}
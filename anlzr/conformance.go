@@ -0,0 +1,274 @@
+// Copyright 2014-2015 The DevMine Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package anlzr
+
+import (
+	"github.com/DevMine/srcanlzr/hierarchy"
+	"github.com/DevMine/srcanlzr/src"
+	"github.com/DevMine/srcanlzr/src/ast"
+)
+
+// Kinds of conformance issues.
+const (
+	MissingImpl      = "missing implementation" // a prototype of an implemented interface is not implemented
+	SpuriousOverride = "spurious override"      // a method is marked as overriding in a type without supertype
+	InvalidOverride  = "invalid override"       // a method is marked as overriding but no supertype declares it
+)
+
+// rootMethods lists, per language, the methods inherited by every class from
+// an implicit root class (e.g. java.lang.Object), that may be overridden
+// without any explicit supertype.
+var rootMethods = map[string][]signature{
+	src.Java:  {{"equals", 1}, {"hashCode", 0}, {"toString", 0}, {"clone", 0}, {"finalize", 0}},
+	src.Scala: {{"equals", 1}, {"hashCode", 0}, {"toString", 0}, {"clone", 0}, {"finalize", 0}},
+}
+
+// signature identifies a method by its name and its number of parameters.
+type signature struct {
+	name   string
+	params int
+}
+
+// Conformance checks that the classes and enums of the project implement the
+// prototypes of the interfaces they implement, and that the methods flagged as
+// overriding (see ast.MethodDecl.Override) do override a method of a
+// supertype. Methods match when they have the same name and number of
+// parameters.
+//
+// Only the interfaces and supertypes declared in the project can be checked:
+// missing implementations are not reported when a superclass or a mixin is
+// external or cannot be resolved, since it may provide them, and overrides
+// are not checked when any supertype is unknown. Abstract classes (see
+// Dependencies) are not required to implement their interfaces.
+type Conformance struct{}
+
+func (c Conformance) Analyze(p *src.Project, r *Result) error {
+	h := r.hierarchy(p)
+
+	m := ConformanceMetrics{Issues: []ConformanceIssue{}}
+	for _, typ := range h.Types {
+		if typ.IsExternal() {
+			continue
+		}
+		for _, issue := range missingImpls(h, typ) {
+			m.Issues = append(m.Issues, issue)
+			m.MissingImpls++
+		}
+		for _, issue := range invalidOverrides(h, typ) {
+			m.Issues = append(m.Issues, issue)
+			if issue.Kind == SpuriousOverride {
+				m.SpuriousOverrides++
+			} else {
+				m.InvalidOverrides++
+			}
+		}
+	}
+
+	r.Conformance = m
+
+	return nil
+}
+
+// missingImpls returns the prototypes of the interfaces implemented by a
+// concrete class or by an enum that are not implemented.
+func missingImpls(h *hierarchy.Hierarchy, typ *hierarchy.Type) []ConformanceIssue {
+	switch d := typ.Symbol.Decl.(type) {
+	case *ast.ClassDecl:
+		if isAbstractClass(d) {
+			return nil
+		}
+	case *ast.EnumDecl:
+	default:
+		return nil
+	}
+
+	// implementations provided by the type, its superclasses and its mixins
+	impls := make(map[signature]bool)
+	chain := []*hierarchy.Type{typ}
+	for i := 0; i < len(chain); i++ {
+		t := chain[i]
+		if !resolvedClassChain(t) {
+			return nil
+		}
+		for _, m := range methodsOf(t) {
+			if !isAbstractMethod(m) {
+				impls[methodSignature(m)] = true
+			}
+		}
+		for _, l := range t.Supers {
+			if (l.Relation == hierarchy.Extends || l.Relation == hierarchy.Mixes) && !contains(chain, l.Super) {
+				chain = append(chain, l.Super)
+			}
+		}
+	}
+
+	var issues []ConformanceIssue
+	for _, anc := range h.Ancestors(typ) {
+		iface, ok := declOf(anc).(*ast.Interface)
+		if !ok {
+			continue
+		}
+		for _, proto := range iface.Protos {
+			sig := protoSignature(proto)
+			if !impls[sig] {
+				issues = append(issues, ConformanceIssue{
+					Kind:      MissingImpl,
+					Type:      typ.Name,
+					Method:    sig.name,
+					Params:    int64(sig.params),
+					Interface: anc.Name,
+					File:      typ.Symbol.File.Path,
+				})
+				impls[sig] = true // only report it once
+			}
+		}
+	}
+	return issues
+}
+
+// invalidOverrides returns the methods of a type flagged as overriding that
+// do not override anything.
+func invalidOverrides(h *hierarchy.Hierarchy, typ *hierarchy.Type) []ConformanceIssue {
+	ancestors := h.Ancestors(typ)
+	if !resolvedSupers(typ) {
+		return nil
+	}
+	for _, anc := range ancestors {
+		if anc.IsExternal() || !resolvedSupers(anc) {
+			return nil
+		}
+	}
+
+	inherited := make(map[signature]bool)
+	if typ.Symbol.File.Lang != nil {
+		for _, sig := range rootMethods[typ.Symbol.File.Lang.Lang] {
+			inherited[sig] = true
+		}
+	}
+	for _, anc := range ancestors {
+		for _, m := range methodsOf(anc) {
+			inherited[methodSignature(m)] = true
+		}
+		if iface, ok := declOf(anc).(*ast.Interface); ok {
+			for _, proto := range iface.Protos {
+				inherited[protoSignature(proto)] = true
+			}
+		}
+	}
+
+	var issues []ConformanceIssue
+	for _, m := range methodsOf(typ) {
+		sig := methodSignature(m)
+		if !m.Override || inherited[sig] {
+			continue
+		}
+		issue := ConformanceIssue{
+			Kind:   InvalidOverride,
+			Type:   typ.Name,
+			Method: sig.name,
+			Params: int64(sig.params),
+			File:   typ.Symbol.File.Path,
+		}
+		if len(ancestors) == 0 {
+			issue.Kind = SpuriousOverride
+		}
+		issues = append(issues, issue)
+	}
+	return issues
+}
+
+// resolvedSupers reports whether all the supertypes referenced by the
+// declaration of a type have been resolved.
+func resolvedSupers(typ *hierarchy.Type) bool {
+	if typ.IsExternal() {
+		return false
+	}
+	var refs int
+	switch d := declOf(typ).(type) {
+	case *ast.ClassDecl:
+		refs = len(d.ExtendedClasses) + len(d.ImplementedInterfaces) + len(d.Mixins)
+	case *ast.Interface:
+		refs = len(d.ImplementedInterfaces)
+	case *ast.EnumDecl:
+		refs = len(d.ImplementedInterfaces)
+	}
+	return refs == len(typ.Supers)
+}
+
+// resolvedClassChain reports whether the superclasses and mixins referenced by
+// the declaration of a type have been resolved within the project.
+func resolvedClassChain(typ *hierarchy.Type) bool {
+	if typ.IsExternal() {
+		return false
+	}
+	d, ok := declOf(typ).(*ast.ClassDecl)
+	if !ok {
+		return true
+	}
+	var links int
+	for _, l := range typ.Supers {
+		if l.Relation == hierarchy.Extends || l.Relation == hierarchy.Mixes {
+			if l.Super.IsExternal() {
+				return false
+			}
+			links++
+		}
+	}
+	return links == len(d.ExtendedClasses)+len(d.Mixins)
+}
+
+// declOf returns the declaration of a type, or nil for external types.
+func declOf(typ *hierarchy.Type) interface{} {
+	if typ.Symbol == nil {
+		return nil
+	}
+	return typ.Symbol.Decl
+}
+
+// methodsOf returns the methods declared by a class, an enum or a trait.
+func methodsOf(typ *hierarchy.Type) []*ast.MethodDecl {
+	switch d := declOf(typ).(type) {
+	case *ast.ClassDecl:
+		return d.Methods
+	case *ast.EnumDecl:
+		return d.Methods
+	case *ast.Trait:
+		return d.Methods
+	}
+	return nil
+}
+
+func methodSignature(m *ast.MethodDecl) signature {
+	sig := signature{name: m.Name}
+	if m.Type != nil {
+		sig.params = len(m.Type.Params)
+	}
+	return sig
+}
+
+func protoSignature(proto *ast.ProtoDecl) signature {
+	var sig signature
+	if proto.Name != nil {
+		sig.name = proto.Name.Name
+	}
+	if proto.Type != nil {
+		sig.params = len(proto.Type.Params)
+	}
+	return sig
+}
+
+// isAbstractMethod reports whether a method has no body.
+func isAbstractMethod(m *ast.MethodDecl) bool {
+	return m.Body == nil && m.LoC == 0
+}
+
+func contains(types []*hierarchy.Type, typ *hierarchy.Type) bool {
+	for _, t := range types {
+		if t == typ {
+			return true
+		}
+	}
+	return false
+}
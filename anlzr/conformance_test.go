@@ -0,0 +1,108 @@
+// Copyright 2014-2015 The DevMine Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package anlzr_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/DevMine/srcanlzr/anlzr"
+	"github.com/DevMine/srcanlzr/src"
+	"github.com/DevMine/srcanlzr/src/ast"
+	"github.com/DevMine/srcanlzr/src/token"
+)
+
+func params(n int) *ast.FuncType {
+	ft := &ast.FuncType{}
+	for i := 0; i < n; i++ {
+		ft.Params = append(ft.Params, &ast.Field{})
+	}
+	return ft
+}
+
+func proto(name string, nparams int) *ast.ProtoDecl {
+	return &ast.ProtoDecl{Name: ident(name), Type: params(nparams)}
+}
+
+func impl(name string, nparams int, override bool) *ast.MethodDecl {
+	body := []ast.Stmt{&ast.ReturnStmt{StmtName: token.ReturnStmtName}}
+	return &ast.MethodDecl{FuncDecl: ast.FuncDecl{Name: name, Type: params(nparams), Body: body, LoC: 1}, Override: override}
+}
+
+func TestConformance(t *testing.T) {
+	classRefs := func(names ...string) []*ast.ClassRef {
+		var refs []*ast.ClassRef
+		for _, n := range names {
+			refs = append(refs, &ast.ClassRef{ClassName: n})
+		}
+		return refs
+	}
+	ifaceRefs := func(names ...string) []*ast.InterfaceRef {
+		var refs []*ast.InterfaceRef
+		for _, n := range names {
+			refs = append(refs, &ast.InterfaceRef{InterfaceName: n})
+		}
+		return refs
+	}
+
+	p := &src.Project{
+		Packages: []*src.Package{
+			&src.Package{
+				Path: "p",
+				SrcFiles: []*src.SrcFile{
+					&src.SrcFile{
+						Path: "p/Shapes.java",
+						Lang: &src.Language{Lang: src.Java},
+						Interfaces: []*ast.Interface{
+							&ast.Interface{Name: "Shape", Protos: []*ast.ProtoDecl{proto("area", 0), proto("scale", 1)}},
+							&ast.Interface{Name: "Solid", ImplementedInterfaces: ifaceRefs("Shape"), Protos: []*ast.ProtoDecl{proto("volume", 0)}},
+						},
+						Classes: []*ast.ClassDecl{
+							&ast.ClassDecl{Name: "Base", ImplementedInterfaces: ifaceRefs("Shape"), Methods: []*ast.MethodDecl{impl("area", 0, false)}},
+							&ast.ClassDecl{
+								Name:                  "Cube",
+								ExtendedClasses:       classRefs("Base"),
+								ImplementedInterfaces: ifaceRefs("Solid"),
+								Methods: []*ast.MethodDecl{
+									impl("volume", 0, false), impl("scale", 1, false), impl("area", 0, true), impl("toString", 0, true),
+								},
+							},
+							&ast.ClassDecl{Name: "Lonely", Methods: []*ast.MethodDecl{impl("toString", 0, true), impl("run", 0, true)}},
+							&ast.ClassDecl{Name: "Child", ExtendedClasses: classRefs("Cube"), Methods: []*ast.MethodDecl{impl("scale", 2, true)}},
+							&ast.ClassDecl{Name: "Failure", ExtendedClasses: classRefs("Exception"), ImplementedInterfaces: ifaceRefs("Shape"), Methods: []*ast.MethodDecl{impl("foo", 0, true)}},
+							&ast.ClassDecl{
+								Name:                  "AbstractShape",
+								ImplementedInterfaces: ifaceRefs("Shape"),
+								Methods:               []*ast.MethodDecl{&ast.MethodDecl{FuncDecl: ast.FuncDecl{Name: "area", Type: params(0)}}},
+							},
+						},
+						Enums: []*ast.EnumDecl{
+							&ast.EnumDecl{Name: "Color", ImplementedInterfaces: ifaceRefs("Shape"), Methods: []*ast.MethodDecl{impl("area", 0, false)}},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	r, err := anlzr.RunAnalyzers(p, anlzr.Conformance{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := r.Conformance
+
+	expected := []anlzr.ConformanceIssue{
+		{Kind: anlzr.MissingImpl, Type: "p.Base", Method: "scale", Params: 1, Interface: "p.Shape", File: "p/Shapes.java"},
+		{Kind: anlzr.SpuriousOverride, Type: "p.Lonely", Method: "run", Params: 0, File: "p/Shapes.java"},
+		{Kind: anlzr.InvalidOverride, Type: "p.Child", Method: "scale", Params: 2, File: "p/Shapes.java"},
+		{Kind: anlzr.MissingImpl, Type: "p.Color", Method: "scale", Params: 1, Interface: "p.Shape", File: "p/Shapes.java"},
+	}
+	if !reflect.DeepEqual(m.Issues, expected) {
+		t.Errorf("issues: found %+v, expected %+v", m.Issues, expected)
+	}
+	if m.MissingImpls != 2 || m.SpuriousOverrides != 1 || m.InvalidOverrides != 1 {
+		t.Errorf("found %d/%d/%d issues, expected 2/1/1", m.MissingImpls, m.SpuriousOverrides, m.InvalidOverrides)
+	}
+}
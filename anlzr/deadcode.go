@@ -0,0 +1,381 @@
+// Copyright 2014-2015 The DevMine Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package anlzr
+
+import (
+	"path"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/DevMine/srcanlzr/resolver"
+	"github.com/DevMine/srcanlzr/src"
+	"github.com/DevMine/srcanlzr/src/ast"
+	"github.com/DevMine/srcanlzr/src/token"
+)
+
+// Kinds of declarations
+const (
+	DeclFunc        = "function"
+	DeclMethod      = "method"
+	DeclConstructor = "constructor"
+	DeclDestructor  = "destructor"
+	DeclClass       = "class"
+	DeclConstant    = "constant"
+	DeclGlobal      = "global"
+)
+
+// DeadCode finds the private and package visible functions, methods, classes,
+// constants and global variables that are never referenced in the project.
+//
+// Public and protected declarations are part of the API of the project and are
+// never reported, nor are the main and init functions, the methods overriding
+// another one or having a special meaning (e.g. Python's __str__), and the
+// functions and methods run by the test framework of their language (see
+// isTest). The ratio is computed over the private declarations which are
+// checked.
+//
+// Functions, methods and classes are referenced by calls, constructor calls
+// and class references, as resolved by the resolver package. A reference from
+// within the declaration itself (e.g. a recursive call) does not count. Since
+// the types of the variables are unknown, a method is also considered as used
+// when a call of its package with the same name could not be resolved (e.g.
+// w.draw()). The resolver does not declare the constants and global
+// variables: they, as well as the functions and classes used as values (e.g.
+// callbacks), are referenced by the identifiers of their package which do not
+// denote a parameter or a local variable.
+type DeadCode struct{}
+
+func (dc DeadCode) Analyze(p *src.Project, r *Result) error {
+	t := r.symbols(p)
+
+	m := DeadCodeMetrics{Candidates: []DeadDecl{}}
+
+	names := identNames(p)
+	calls := unresolvedCalls(t)
+	idents := make(map[*resolver.Symbol]bool)
+	for _, pkg := range p.Packages {
+		for name := range names[pkg] {
+			for _, sym := range t.Lookup(pkg, name) {
+				idents[sym] = true
+			}
+		}
+	}
+	members := make(map[*resolver.Symbol][]*resolver.Symbol)
+	for _, sym := range t.Symbols {
+		if sym.Parent != nil {
+			members[sym.Parent] = append(members[sym.Parent], sym)
+		}
+	}
+
+	// used reports whether sym or one of its members is referenced from
+	// outside of sym.
+	var used func(sym, from *resolver.Symbol) bool
+	used = func(sym, from *resolver.Symbol) bool {
+		if sym.Kind == resolver.Method && calls[sym.Pkg][sym.Name] {
+			return true
+		}
+		for _, ref := range t.References(sym.Decl) {
+			if !within(ref.Scope, from) {
+				return true
+			}
+		}
+		for _, mem := range members[sym] {
+			if used(mem, from) {
+				return true
+			}
+		}
+		return false
+	}
+
+	for _, sym := range t.Symbols {
+		var kind, vis string
+		switch d := sym.Decl.(type) {
+		case *ast.FuncDecl:
+			kind, vis = DeclFunc, d.Visibility
+		case *ast.MethodDecl:
+			if d.Override {
+				continue
+			}
+			kind, vis = DeclMethod, d.Visibility
+		case *ast.ClassDecl:
+			kind, vis = DeclClass, d.Visibility
+		default:
+			continue
+		}
+
+		if !isPrivate(vis) || isRoot(sym) {
+			continue
+		}
+		m.Declarations++
+		if used(sym, sym) || idents[sym] {
+			continue
+		}
+		m.Candidates = append(m.Candidates, DeadDecl{Kind: kind, Name: declName(sym), File: sym.File.Path})
+	}
+
+	for _, pkg := range p.Packages {
+		for _, sf := range pkg.SrcFiles {
+			globals := func(kind string, decls []*ast.GlobalDecl) {
+				for _, gd := range decls {
+					if gd.Name == nil || !isPrivate(gd.Visibility) {
+						continue
+					}
+					m.Declarations++
+					if !names[pkg][gd.Name.Name] {
+						m.Candidates = append(m.Candidates, DeadDecl{Kind: kind, Name: gd.Name.Name, File: sf.Path})
+					}
+				}
+			}
+			globals(DeclConstant, sf.Constants)
+			globals(DeclGlobal, sf.Vars)
+		}
+	}
+
+	m.Dead = int64(len(m.Candidates))
+	if m.Declarations > 0 {
+		m.Ratio = float32(m.Dead) / float32(m.Declarations)
+	}
+
+	r.DeadCode = m
+
+	return nil
+}
+
+// identNames returns, for each package, the names of its identifiers which do
+// not denote a parameter or a local variable of the enclosing function. The
+// names of the attributes (e.g. b in a.b) are left out.
+func identNames(p *src.Project) map[*src.Package]map[string]bool {
+	names := make(map[*src.Package]map[string]bool, len(p.Packages))
+	for _, pkg := range p.Packages {
+		set := make(map[string]bool)
+
+		var visit func(locals map[string]bool) func(n interface{}) bool
+		// body visits a function body, in which the parameters and the local
+		// variables hide the names of the package.
+		body := func(locals map[string]bool, ft *ast.FuncType, params []*ast.Field, stmts []ast.Stmt) {
+			if ft != nil {
+				params = ft.Params
+			}
+			inner := localNames(params, stmts)
+			for name := range locals {
+				inner[name] = true
+			}
+			for _, stmt := range stmts {
+				ast.Inspect(stmt, visit(inner))
+			}
+		}
+		visit = func(locals map[string]bool) func(n interface{}) bool {
+			var f func(n interface{}) bool
+			f = func(n interface{}) bool {
+				switch n := n.(type) {
+				case *ast.FuncDecl:
+					body(locals, n.Type, nil, n.Body)
+					return false
+				case *ast.MethodDecl:
+					body(locals, n.Type, nil, n.Body)
+					return false
+				case *ast.FuncLit:
+					body(locals, n.Type, nil, n.Body)
+					return false
+				case *ast.ConstructorDecl:
+					body(locals, nil, n.Params, n.Body)
+					return false
+				case *ast.DestructorDecl:
+					body(locals, nil, n.Params, n.Body)
+					return false
+				case *ast.Ident:
+					if !locals[n.Name] {
+						set[n.Name] = true
+					}
+				case *ast.AttrRef:
+					ast.Inspect(n.X, f)
+					return false
+				}
+				return true
+			}
+			return f
+		}
+
+		top := visit(nil)
+		for _, sf := range pkg.SrcFiles {
+			for _, gd := range append(append([]*ast.GlobalDecl{}, sf.Constants...), sf.Vars...) {
+				// the name of the declaration is not a reference
+				ast.Inspect(gd.Value, top)
+			}
+			for _, f := range sf.Funcs {
+				ast.Inspect(f, top)
+			}
+			for _, c := range sf.Classes {
+				ast.Inspect(c, top)
+			}
+			for _, e := range sf.Enums {
+				ast.Inspect(e, top)
+			}
+			for _, tr := range sf.Traits {
+				ast.Inspect(tr, top)
+			}
+		}
+		names[pkg] = set
+	}
+	return names
+}
+
+// localNames returns the names of the parameters and of the local variables of
+// a function body: declarations, range loop variables, caught exceptions and
+// parameters of the nested function literals.
+func localNames(params []*ast.Field, body []ast.Stmt) map[string]bool {
+	names := make(map[string]bool)
+	fields := func(fs []*ast.Field) {
+		for _, f := range fs {
+			names[f.Name] = true
+		}
+	}
+	idents := func(xs []ast.Expr) {
+		for _, x := range xs {
+			if id, ok := x.(*ast.Ident); ok {
+				names[id.Name] = true
+			}
+		}
+	}
+
+	fields(params)
+	for _, stmt := range body {
+		ast.Inspect(stmt, func(n interface{}) bool {
+			switch n := n.(type) {
+			case *ast.DeclStmt:
+				idents(n.LHS)
+			case *ast.RangeLoopStmt:
+				idents(n.Vars)
+			case *ast.CatchClause:
+				fields(n.Params)
+			case *ast.FuncLit:
+				if n.Type != nil {
+					fields(n.Type.Params)
+				}
+			}
+			return true
+		})
+	}
+	return names
+}
+
+// unresolvedCalls returns, for each package, the names of the functions called
+// in the package through a namespace that the resolver could not bind to a
+// declaration, nor to an external one (e.g. a method call on a variable).
+func unresolvedCalls(t *resolver.Table) map[*src.Package]map[string]bool {
+	calls := make(map[*src.Package]map[string]bool)
+	for _, ref := range t.Refs {
+		if _, ok := ref.Node.(*ast.CallExpr); !ok || ref.Target != nil || ref.External || ref.Namespace == "" {
+			continue
+		}
+		if calls[ref.Pkg] == nil {
+			calls[ref.Pkg] = make(map[string]bool)
+		}
+		calls[ref.Pkg][ref.Name] = true
+	}
+	return calls
+}
+
+// within reports whether scope is sym or a declaration nested in sym.
+func within(scope, sym *resolver.Symbol) bool {
+	for s := scope; s != nil; s = s.Parent {
+		if s == sym {
+			return true
+		}
+	}
+	return false
+}
+
+// isPrivate reports whether a visibility restricts the use of a declaration to
+// its package or its type.
+func isPrivate(vis string) bool {
+	return vis == token.PrivateVisibility || vis == token.PackageVisibility
+}
+
+// isRoot reports whether a function, a method or a class is an entry point of
+// the program: main or init function, special method or test.
+func isRoot(sym *resolver.Symbol) bool {
+	switch name := sym.Name; {
+	case name == "main", name == "init":
+		return true
+	case strings.HasPrefix(name, "__") && strings.HasSuffix(name, "__"):
+		return true
+	}
+	return isTest(sym)
+}
+
+// isTest reports whether a function or a method is run by the test framework
+// of the language of its source file:
+//
+//   - Go: TestXxx, BenchmarkXxx, ExampleXxx and FuzzXxx functions of the
+//     _test.go files;
+//   - Java and Scala: methods named test* (JUnit 3), setUp and tearDown of the
+//     test classes;
+//   - Python: functions and methods named test* (pytest, unittest), setUp and
+//     tearDown of the test modules;
+//   - Ruby: methods named test_* (minitest, test-unit), setup and teardown of
+//     the test files.
+func isTest(sym *resolver.Symbol) bool {
+	sf := sym.File
+	if sf == nil || sf.Lang == nil || !isTestFile(sf.Lang.Lang, sf.Path) {
+		return false
+	}
+
+	name := sym.Name
+	switch sf.Lang.Lang {
+	case src.Go:
+		if sym.Kind != resolver.Func {
+			return false
+		}
+		for _, prefix := range []string{"Test", "Benchmark", "Example", "Fuzz"} {
+			if strings.HasPrefix(name, prefix) {
+				// the prefix must not be followed by a lower case letter
+				r, _ := utf8.DecodeRuneInString(name[len(prefix):])
+				return !unicode.IsLower(r)
+			}
+		}
+	case src.Java, src.Scala:
+		return sym.Kind == resolver.Method && (strings.HasPrefix(name, "test") || name == "setUp" || name == "tearDown")
+	case src.Python:
+		return sym.Kind != resolver.Class && (strings.HasPrefix(name, "test") || name == "setUp" || name == "tearDown")
+	case src.Ruby:
+		return sym.Kind == resolver.Method && (strings.HasPrefix(name, "test_") || name == "setup" || name == "teardown")
+	}
+	return false
+}
+
+// isTestFile reports whether a source file of the given language holds tests,
+// according to the naming conventions of its test frameworks.
+func isTestFile(lang, p string) bool {
+	base := path.Base(p)
+	name := strings.TrimSuffix(base, path.Ext(base))
+	switch lang {
+	case src.Go:
+		return strings.HasSuffix(base, "_test.go")
+	case src.Java, src.Scala:
+		if strings.HasPrefix(name, "Test") || strings.HasSuffix(name, "Test") || strings.HasSuffix(name, "Tests") {
+			return true
+		}
+		for _, dir := range strings.Split(path.Dir(p), "/") {
+			if dir == "test" {
+				return true
+			}
+		}
+	case src.Python, src.Ruby:
+		return strings.HasPrefix(name, "test_") || strings.HasSuffix(name, "_test")
+	}
+	return false
+}
+
+// declName returns the name of a symbol prefixed by the names of its enclosing
+// declarations (e.g. "Outer.Inner.method").
+func declName(sym *resolver.Symbol) string {
+	name := sym.Name
+	for p := sym.Parent; p != nil; p = p.Parent {
+		name = p.Name + "." + name
+	}
+	return name
+}
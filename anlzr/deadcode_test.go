@@ -0,0 +1,131 @@
+// Copyright 2014-2015 The DevMine Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package anlzr_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/DevMine/srcanlzr/anlzr"
+	"github.com/DevMine/srcanlzr/src"
+	"github.com/DevMine/srcanlzr/src/ast"
+	"github.com/DevMine/srcanlzr/src/token"
+)
+
+func callStmt(namespace, name string) ast.Stmt {
+	return &ast.ExprStmt{
+		StmtName: token.ExprStmtName,
+		X: &ast.CallExpr{
+			ExprName: token.CallExprName,
+			Fun:      &ast.FuncRef{Namespace: namespace, FuncName: name},
+		},
+	}
+}
+
+func ident(name string) *ast.Ident {
+	return &ast.Ident{ExprName: token.IdentName, Name: name}
+}
+
+func TestDeadCode(t *testing.T) {
+	private := token.PrivateVisibility
+	method := func(name, vis string, override bool, body ...ast.Stmt) *ast.MethodDecl {
+		return &ast.MethodDecl{FuncDecl: ast.FuncDecl{Name: name, Visibility: vis, Body: body}, Override: override}
+	}
+
+	exprStmt := func(x ast.Expr) ast.Stmt {
+		return &ast.ExprStmt{StmtName: token.ExprStmtName, X: x}
+	}
+
+	p := &src.Project{
+		Packages: []*src.Package{
+			&src.Package{
+				Path: "p",
+				SrcFiles: []*src.SrcFile{
+					&src.SrcFile{
+						Path: "p/p.go",
+						Lang: &src.Language{Lang: src.Go},
+						Constants: []*ast.GlobalDecl{
+							&ast.GlobalDecl{Name: ident("maxSize"), Visibility: token.PackageVisibility},
+							&ast.GlobalDecl{Name: ident("minSize"), Visibility: token.PackageVisibility},
+						},
+						Vars: []*ast.GlobalDecl{
+							&ast.GlobalDecl{Name: ident("cache"), Value: ident("maxSize"), Visibility: private},
+							&ast.GlobalDecl{Name: ident("limit"), Visibility: private},
+						},
+						Funcs: []*ast.FuncDecl{
+							&ast.FuncDecl{Name: "main", Visibility: private, Body: []ast.Stmt{
+								callStmt("", "used"),
+								callStmt("w", "render"),
+								exprStmt(&ast.AttrRef{ExprName: token.AttrRefName, X: ident("w"), Name: ident("limit")}),
+							}},
+							&ast.FuncDecl{
+								Name:       "used",
+								Visibility: private,
+								Type:       &ast.FuncType{Params: []*ast.Field{&ast.Field{Name: "minSize"}}},
+								Body:       []ast.Stmt{exprStmt(ident("minSize"))},
+							},
+							&ast.FuncDecl{Name: "recursive", Visibility: private, Body: []ast.Stmt{callStmt("", "recursive")}},
+							&ast.FuncDecl{Name: "testHelper", Visibility: private},
+							&ast.FuncDecl{Name: "Exported", Visibility: token.PublicVisibility},
+						},
+						Classes: []*ast.ClassDecl{
+							&ast.ClassDecl{
+								Name:       "Widget",
+								Visibility: token.PublicVisibility,
+								Methods: []*ast.MethodDecl{
+									method("draw", token.PublicVisibility, false, callStmt("this", "helper")),
+									method("helper", private, false),
+									method("render", private, false),
+									method("unused", private, false),
+									method("toString", private, true),
+								},
+							},
+							&ast.ClassDecl{Name: "Orphan", Visibility: private},
+						},
+					},
+					&src.SrcFile{
+						Path:  "p/p_test.go",
+						Lang:  &src.Language{Lang: src.Go},
+						Funcs: []*ast.FuncDecl{&ast.FuncDecl{Name: "helperForTests", Visibility: private}},
+					},
+					&src.SrcFile{
+						Path: "p/calc_test.rb",
+						Lang: &src.Language{Lang: src.Ruby},
+						Classes: []*ast.ClassDecl{
+							&ast.ClassDecl{
+								Name:       "CalcTest",
+								Visibility: token.PublicVisibility,
+								Methods:    []*ast.MethodDecl{method("test_adds", private, false)},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	r, err := anlzr.RunAnalyzers(p, anlzr.DeadCode{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := r.DeadCode
+
+	expected := []anlzr.DeadDecl{
+		{Kind: anlzr.DeclFunc, Name: "recursive", File: "p/p.go"},
+		{Kind: anlzr.DeclFunc, Name: "testHelper", File: "p/p.go"},
+		{Kind: anlzr.DeclMethod, Name: "Widget.unused", File: "p/p.go"},
+		{Kind: anlzr.DeclClass, Name: "Orphan", File: "p/p.go"},
+		{Kind: anlzr.DeclFunc, Name: "helperForTests", File: "p/p_test.go"},
+		{Kind: anlzr.DeclConstant, Name: "minSize", File: "p/p.go"},
+		{Kind: anlzr.DeclGlobal, Name: "cache", File: "p/p.go"},
+		{Kind: anlzr.DeclGlobal, Name: "limit", File: "p/p.go"},
+	}
+	if !reflect.DeepEqual(m.Candidates, expected) {
+		t.Errorf("candidates: found %+v, expected %+v", m.Candidates, expected)
+	}
+	if m.Declarations != 12 || m.Dead != 8 || m.Ratio != 8.0/12.0 {
+		t.Errorf("found %d dead declarations out of %d (ratio %f), expected 8 out of 12", m.Dead, m.Declarations, m.Ratio)
+	}
+}
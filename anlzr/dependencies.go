@@ -0,0 +1,196 @@
+// Copyright 2014-2015 The DevMine Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package anlzr
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/DevMine/srcanlzr/internal/graph"
+	"github.com/DevMine/srcanlzr/src"
+	"github.com/DevMine/srcanlzr/src/ast"
+)
+
+// Dependencies builds the package dependency graph of the project from the
+// imports of the source files and computes, for each package of the project,
+// the package metrics of Robert C. Martin:
+//
+//   - afferent coupling (Ca): number of project packages importing the package;
+//   - efferent coupling (Ce): number of packages, internal or external,
+//     imported by the package;
+//   - instability: Ce / (Ca + Ce), or 0 for a package with no coupling;
+//   - abstractness: ratio of abstract types (interfaces, traits and classes
+//     declaring methods without body) over the number of types, or 0 for a
+//     package declaring no type;
+//   - distance from the main sequence: |abstractness + instability - 1|.
+//
+// External imports are counted by package: the imports of several classes of
+// a package, or a wildcard and an explicit import of the same package, count
+// as one dependency. Import cycles between packages of the project are
+// reported as well.
+type Dependencies struct{}
+
+func (d Dependencies) Analyze(p *src.Project, r *Result) error {
+	m := DependencyMetrics{
+		Packages:         []PackageDeps{},
+		ExternalPackages: []string{},
+		Cycles:           []ImportCycle{},
+	}
+
+	// imports of each package, indexed as p.Packages
+	deps := make([][]int, len(p.Packages))
+	index := make(map[string]int, len(p.Packages))
+	for i, pkg := range p.Packages {
+		index[pkg.Path] = i
+	}
+
+	imported := src.ImportedPackages(p)
+	external := make(map[string]bool)
+	for i, pkg := range p.Packages {
+		pd := PackageDeps{Path: pkg.Path, Imports: []string{}, ExternalImports: []string{}}
+
+		internal := make(map[string]bool)
+		ext := make(map[string]bool)
+		var types, abstract int
+		for _, sf := range pkg.SrcFiles {
+			for _, imp := range sf.Imports {
+				ipkg := imported[imp]
+				switch {
+				case ipkg == pkg:
+					// importing a module of the same package
+				case ipkg != nil:
+					internal[ipkg.Path] = true
+				default:
+					ext[externalPackage(imp)] = true
+				}
+			}
+
+			t, a := countTypes(sf)
+			types += t
+			abstract += a
+		}
+
+		pd.Imports = sortedKeys(internal)
+		pd.ExternalImports = sortedKeys(ext)
+		for _, path := range pd.Imports {
+			deps[i] = append(deps[i], index[path])
+		}
+		for path := range ext {
+			external[path] = true
+		}
+
+		pd.EfferentCoupling = int64(len(internal) + len(ext))
+		if types > 0 {
+			pd.Abstractness = float32(abstract) / float32(types)
+		}
+		m.Packages = append(m.Packages, pd)
+	}
+	m.ExternalPackages = sortedKeys(external)
+
+	for i := range m.Packages {
+		for _, j := range deps[i] {
+			m.Packages[j].AfferentCoupling++
+		}
+	}
+	for i := range m.Packages {
+		pd := &m.Packages[i]
+		if ca, ce := pd.AfferentCoupling, pd.EfferentCoupling; ca+ce > 0 {
+			pd.Instability = float32(ce) / float32(ca+ce)
+		}
+		pd.Distance = float32(math.Abs(float64(pd.Abstractness + pd.Instability - 1)))
+	}
+
+	for _, scc := range graph.Cycles(deps) {
+		cycle := ImportCycle{Packages: make([]string, len(scc))}
+		for k, i := range scc {
+			cycle.Packages[k] = p.Packages[i].Path
+		}
+		m.Cycles = append(m.Cycles, cycle)
+	}
+
+	r.Dependencies = m
+
+	return nil
+}
+
+// countTypes returns the number of types declared in a source file and how
+// many of them are abstract.
+func countTypes(sf *src.SrcFile) (types, abstract int) {
+	types = len(sf.TypeSpecs) + len(sf.Enums) + len(sf.Interfaces)
+	abstract = len(sf.Interfaces)
+
+	var classes func(cs []*ast.ClassDecl)
+	classes = func(cs []*ast.ClassDecl) {
+		for _, c := range cs {
+			types++
+			if isAbstractClass(c) {
+				abstract++
+			}
+			classes(c.NestedClasses)
+		}
+	}
+	var traits func(ts []*ast.Trait)
+	traits = func(ts []*ast.Trait) {
+		for _, t := range ts {
+			types++
+			abstract++
+			classes(t.Classes)
+			traits(t.Traits)
+		}
+	}
+	classes(sf.Classes)
+	traits(sf.Traits)
+
+	return types, abstract
+}
+
+// isAbstractClass reports whether a class declares at least one method
+// without body, i.e. an abstract method.
+func isAbstractClass(c *ast.ClassDecl) bool {
+	for _, m := range c.Methods {
+		if isAbstractMethod(m) {
+			return true
+		}
+	}
+	return false
+}
+
+// externalPackage returns the path of the external package denoted by an
+// import. Dot separated paths (Java, Scala, ...) may designate a class or, for
+// static imports, a member of a class: the member and the trailing capitalized
+// elements, taken as class names, are dropped (e.g. "java.util.List" and
+// "java.util.*" both give "java.util").
+func externalPackage(imp *ast.Import) string {
+	p := strings.TrimSuffix(strings.TrimSuffix(imp.Path, ".*"), "/*")
+	wildcard := imp.Wildcard || p != imp.Path
+	if strings.Contains(p, "/") || !strings.Contains(p, ".") {
+		return p
+	}
+
+	elts := strings.Split(p, ".")
+	if imp.Static && !wildcard && len(elts) > 1 {
+		elts = elts[:len(elts)-1]
+	}
+	for len(elts) > 1 {
+		r, _ := utf8.DecodeRuneInString(elts[len(elts)-1])
+		if !unicode.IsUpper(r) {
+			break
+		}
+		elts = elts[:len(elts)-1]
+	}
+	return strings.Join(elts, ".")
+}
+
+func sortedKeys(set map[string]bool) []string {
+	keys := make([]string, 0, len(set))
+	for k := range set {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,123 @@
+// Copyright 2014-2015 The DevMine Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package anlzr_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/DevMine/srcanlzr/anlzr"
+	"github.com/DevMine/srcanlzr/src"
+	"github.com/DevMine/srcanlzr/src/ast"
+)
+
+func imports(paths ...string) []*ast.Import {
+	imps := make([]*ast.Import, len(paths))
+	for i, p := range paths {
+		imps[i] = &ast.Import{Path: p}
+	}
+	return imps
+}
+
+func TestDependencies(t *testing.T) {
+	abstractMethod := &ast.MethodDecl{FuncDecl: ast.FuncDecl{Name: "area"}}
+	p := &src.Project{
+		Name: "foo",
+		Packages: []*src.Package{
+			&src.Package{
+				Path: "a",
+				SrcFiles: []*src.SrcFile{
+					&src.SrcFile{
+						Imports:    imports("foo/b", "fmt"),
+						Interfaces: []*ast.Interface{&ast.Interface{Name: "Shape"}},
+						Classes:    []*ast.ClassDecl{&ast.ClassDecl{Name: "Base", Methods: []*ast.MethodDecl{abstractMethod}}},
+					},
+					&src.SrcFile{
+						Imports: imports("foo/b", "os", "foo/a"),
+						Classes: []*ast.ClassDecl{&ast.ClassDecl{Name: "Square"}, &ast.ClassDecl{Name: "Circle"}},
+					},
+				},
+			},
+			&src.Package{
+				Path:     "b",
+				SrcFiles: []*src.SrcFile{&src.SrcFile{Imports: imports("foo/a")}},
+			},
+			&src.Package{
+				Path:     "c",
+				SrcFiles: []*src.SrcFile{&src.SrcFile{Imports: imports("foo/a", "fmt")}},
+			},
+		},
+	}
+
+	r, err := anlzr.RunAnalyzers(p, anlzr.Dependencies{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := r.Dependencies
+
+	expected := []anlzr.PackageDeps{
+		{Path: "a", Imports: []string{"b"}, ExternalImports: []string{"fmt", "os"},
+			AfferentCoupling: 2, EfferentCoupling: 3, Instability: 0.6, Abstractness: 0.5, Distance: 0.100000024},
+		{Path: "b", Imports: []string{"a"}, ExternalImports: []string{},
+			AfferentCoupling: 1, EfferentCoupling: 1, Instability: 0.5, Abstractness: 0, Distance: 0.5},
+		{Path: "c", Imports: []string{"a"}, ExternalImports: []string{"fmt"},
+			AfferentCoupling: 0, EfferentCoupling: 2, Instability: 1, Abstractness: 0, Distance: 0},
+	}
+	if !reflect.DeepEqual(m.Packages, expected) {
+		t.Errorf("packages: found %+v, expected %+v", m.Packages, expected)
+	}
+
+	if found := m.ExternalPackages; !reflect.DeepEqual(found, []string{"fmt", "os"}) {
+		t.Errorf("external packages: found %v, expected [fmt os]", found)
+	}
+
+	cycles := []anlzr.ImportCycle{{Packages: []string{"a", "b"}}}
+	if !reflect.DeepEqual(m.Cycles, cycles) {
+		t.Errorf("cycles: found %v, expected %v", m.Cycles, cycles)
+	}
+}
+
+func TestDependenciesExternalPackages(t *testing.T) {
+	p := &src.Project{
+		Name: "foo",
+		Packages: []*src.Package{
+			&src.Package{
+				Path: "a",
+				SrcFiles: []*src.SrcFile{
+					&src.SrcFile{
+						Imports: []*ast.Import{
+							&ast.Import{Path: "java.util.List"},
+							&ast.Import{Path: "java.util.Map.Entry"},
+							&ast.Import{Path: "java.util.*"},
+							&ast.Import{Path: "java.util", Wildcard: true},
+							&ast.Import{Path: "java.lang.Math.PI", Static: true},
+							&ast.Import{Path: "java.util.Arrays.asList", Static: true},
+							&ast.Import{Path: "java.lang.Math", Static: true, Wildcard: true},
+							&ast.Import{Path: "org.junit.Test"},
+							&ast.Import{Path: "os.path"},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	r, err := anlzr.RunAnalyzers(p, anlzr.Dependencies{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := r.Dependencies
+
+	expected := []string{"java.lang", "java.util", "org.junit", "os.path"}
+	if found := m.Packages[0].ExternalImports; !reflect.DeepEqual(found, expected) {
+		t.Errorf("external imports: found %v, expected %v", found, expected)
+	}
+	if found := m.Packages[0].EfferentCoupling; found != 4 {
+		t.Errorf("efferent coupling: found %d, expected 4", found)
+	}
+	if found := m.ExternalPackages; !reflect.DeepEqual(found, expected) {
+		t.Errorf("external packages: found %v, expected %v", found, expected)
+	}
+}
@@ -0,0 +1,46 @@
+// Copyright 2014-2015 The DevMine Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package anlzr
+
+import "github.com/DevMine/srcanlzr/src"
+
+// Histogram bounds of the distributions.
+var (
+	FuncLengthBounds = []float64{10, 25, 50, 100, 200}
+	ComplexityBounds = []float64{5, 10, 20, 50} // usual risk categories
+	FileSizeBounds   = []float64{100, 250, 500, 1000, 2000}
+)
+
+// Distributions computes the distributions of the lengths and cyclomatic
+// complexities of the functions, methods, constructors and destructors of the
+// project, and of the sizes of its source files.
+type Distributions struct {
+	// Rules overrides DefaultComplexityRules for the given languages; it may
+	// be nil.
+	Rules map[string]ComplexityRules
+}
+
+func (d Distributions) Analyze(p *src.Project, r *Result) error {
+	var lengths, complexities, sizes []float64
+	for _, pkg := range p.Packages {
+		for _, sf := range pkg.SrcFiles {
+			sizes = append(sizes, float64(sf.LoC))
+
+			rules := Complexity{Rules: d.Rules}.rules(sf)
+			for _, f := range fileFuncs(sf) {
+				lengths = append(lengths, float64(f.LoC))
+				complexities = append(complexities, float64(cyclomaticComplexity(f.Body, rules)))
+			}
+		}
+	}
+
+	r.Distributions = DistributionMetrics{
+		FuncLength: Describe(lengths, lengths, FuncLengthBounds),
+		Complexity: Describe(complexities, lengths, ComplexityBounds),
+		FileSize:   Describe(sizes, sizes, FileSizeBounds),
+	}
+
+	return nil
+}
@@ -127,6 +127,9 @@ func (dc CommentRatios) Analyze(p *src.Project, r *Result) error {
 		dc.EnumComRatio = float32(cnt.nbComEnum) / float32(cnt.nbEnum)
 	}
 
+	dc.PublicFuncs = int64(cnt.nbFunc + cnt.nbFcts)
+	dc.DocumentedPublicFuncs = int64(cnt.nbComFunc + cnt.nbComFcts)
+
 	r.DocCoverage = dc
 
 	return nil
@@ -0,0 +1,202 @@
+// Copyright 2014-2015 The DevMine Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package anlzr
+
+import (
+	"fmt"
+
+	"github.com/DevMine/srcanlzr/src"
+	"github.com/DevMine/srcanlzr/src/ast"
+)
+
+// A Granularity tells down to which level the Entities analyzer breaks down
+// the metrics (see also Result.Trim).
+type Granularity int
+
+// Granularities, from the coarsest to the finest.
+const (
+	ProjectLevel Granularity = iota // no breakdown
+	PackageLevel                    // per package
+	FileLevel                       // per package and source file
+	FuncLevel                       // per package, source file and function
+)
+
+var granularityNames = []string{"project", "package", "file", "function"}
+
+func (g Granularity) String() string {
+	if g < 0 || int(g) >= len(granularityNames) {
+		return fmt.Sprintf("Granularity(%d)", int(g))
+	}
+	return granularityNames[g]
+}
+
+// ParseGranularity returns the granularity of the given name: "project",
+// "package", "file" or "function".
+func ParseGranularity(name string) (Granularity, error) {
+	for i, n := range granularityNames {
+		if n == name {
+			return Granularity(i), nil
+		}
+	}
+	return ProjectLevel, fmt.Errorf("unknown granularity %q", name)
+}
+
+// UnmarshalText sets the granularity from its name (see ParseGranularity), so
+// that it can be given by name in a configuration.
+func (g *Granularity) UnmarshalText(text []byte) error {
+	v, err := ParseGranularity(string(text))
+	if err != nil {
+		return err
+	}
+	*g = v
+	return nil
+}
+
+// Entities breaks down the metrics of the project into a tree of per-package,
+// per-file and per-function records, down to the given granularity. Nothing is
+// added to the result at the project level.
+//
+// The complexity is the cyclomatic complexity (see Complexity) and the
+// functions include the methods, constructors and destructors.
+type Entities struct {
+	Granularity Granularity
+
+	// Rules overrides DefaultComplexityRules for the given languages; it may
+	// be nil.
+	Rules map[string]ComplexityRules
+}
+
+func (e Entities) Analyze(p *src.Project, r *Result) error {
+	if e.Granularity <= ProjectLevel {
+		return nil
+	}
+
+	pkgs := []PackageEntity{}
+	for _, pkg := range p.Packages {
+		pe := PackageEntity{Path: pkg.Path, Name: pkg.Name, LoC: pkg.LoC}
+		for _, sf := range pkg.SrcFiles {
+			fe := FileEntity{Path: sf.Path, LoC: sf.LoC}
+			if sf.Lang != nil {
+				fe.Lang = sf.Lang.Lang
+			}
+
+			rules := Complexity{Rules: e.Rules}.rules(sf)
+			for _, f := range fileFuncs(sf) {
+				fne := FuncEntity{
+					Name:       f.Name,
+					Kind:       funcKind(f.Decl),
+					LoC:        f.LoC,
+					Complexity: cyclomaticComplexity(f.Body, rules),
+					Params:     funcParams(f.Decl),
+					Documented: hasComment(f.Doc),
+				}
+				fne.QualifiedName = fne.Name
+				if pkg.Path != "" {
+					fne.QualifiedName = pkg.Path + "." + fne.Name
+				}
+
+				fe.Funcs++
+				fe.Complexity += fne.Complexity
+				if fne.Documented {
+					fe.DocumentedFuncs++
+				}
+				if e.Granularity >= FuncLevel {
+					fe.FuncEntities = append(fe.FuncEntities, fne)
+				}
+			}
+
+			pe.Files++
+			pe.Funcs += fe.Funcs
+			pe.DocumentedFuncs += fe.DocumentedFuncs
+			pe.Complexity += fe.Complexity
+			if e.Granularity >= FileLevel {
+				pe.FileEntities = append(pe.FileEntities, fe)
+			}
+		}
+		pkgs = append(pkgs, pe)
+	}
+
+	r.Entities = pkgs
+
+	return nil
+}
+
+// Trim drops the breakdowns of the function lengths, complexity, cognitive
+// complexity, nesting, Halstead, maintainability and comment metrics that are finer than the
+// granularity g. The summaries of the sections and their lists of worst
+// functions are kept.
+func (r *Result) Trim(g Granularity) {
+	if g < FuncLevel {
+		r.FuncLengths = nil
+	}
+	if g < FileLevel {
+		r.Comments.Files = nil
+		r.Complexity.Files = nil
+		r.Cognitive.Files = nil
+		r.Nesting.Files = nil
+		r.Halstead.Files = nil
+	}
+	if g < PackageLevel {
+		r.Maintainability.Packages = nil
+	}
+	for i := range r.Maintainability.Packages {
+		if g < FileLevel {
+			r.Maintainability.Packages[i].Files = nil
+			continue
+		}
+		if g < FuncLevel {
+			for j := range r.Maintainability.Packages[i].Files {
+				r.Maintainability.Packages[i].Files[j].Funcs = nil
+			}
+		}
+	}
+	if g >= FuncLevel {
+		return
+	}
+	for i := range r.Complexity.Files {
+		r.Complexity.Files[i].Funcs = nil
+	}
+	for i := range r.Cognitive.Files {
+		r.Cognitive.Files[i].Funcs = nil
+	}
+	for i := range r.Nesting.Files {
+		r.Nesting.Files[i].Funcs = nil
+	}
+	for i := range r.Halstead.Files {
+		r.Halstead.Files[i].Funcs = nil
+	}
+}
+
+// funcKind returns the kind of a function-like declaration.
+func funcKind(decl interface{}) string {
+	switch decl.(type) {
+	case *ast.MethodDecl:
+		return DeclMethod
+	case *ast.ConstructorDecl:
+		return DeclConstructor
+	case *ast.DestructorDecl:
+		return DeclDestructor
+	}
+	return DeclFunc
+}
+
+// funcParams returns the number of parameters of a function-like declaration.
+func funcParams(decl interface{}) int64 {
+	switch d := decl.(type) {
+	case *ast.FuncDecl:
+		if d.Type != nil {
+			return int64(len(d.Type.Params))
+		}
+	case *ast.MethodDecl:
+		if d.Type != nil {
+			return int64(len(d.Type.Params))
+		}
+	case *ast.ConstructorDecl:
+		return int64(len(d.Params))
+	case *ast.DestructorDecl:
+		return int64(len(d.Params))
+	}
+	return 0
+}
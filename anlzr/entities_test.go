@@ -0,0 +1,170 @@
+// Copyright 2014-2015 The DevMine Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package anlzr_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/DevMine/srcanlzr/anlzr"
+	"github.com/DevMine/srcanlzr/src"
+	"github.com/DevMine/srcanlzr/src/ast"
+	"github.com/DevMine/srcanlzr/src/token"
+)
+
+func TestEntities(t *testing.T) {
+	p := &src.Project{
+		Packages: []*src.Package{
+			&src.Package{
+				Name: "foo",
+				Path: "foo",
+				LoC:  20,
+				SrcFiles: []*src.SrcFile{
+					&src.SrcFile{
+						Path: "foo/foo.go",
+						Lang: &src.Language{Lang: src.Go},
+						LoC:  20,
+						Funcs: []*ast.FuncDecl{
+							&ast.FuncDecl{
+								Doc:  []string{"f does nothing."},
+								Name: "f",
+								Type: params(2),
+								Body: []ast.Stmt{&ast.IfStmt{StmtName: token.IfStmtName}},
+								LoC:  5,
+							},
+						},
+						Classes: []*ast.ClassDecl{
+							&ast.ClassDecl{
+								Name:         "Foo",
+								Constructors: []*ast.ConstructorDecl{&ast.ConstructorDecl{Name: "Foo", Params: []*ast.Field{&ast.Field{}}, LoC: 2}},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	funcs := []anlzr.FuncEntity{
+		{Name: "f", QualifiedName: "foo.f", Kind: anlzr.DeclFunc, LoC: 5, Complexity: 2, Params: 2, Documented: true},
+		{Name: "Foo.Foo", QualifiedName: "foo.Foo.Foo", Kind: anlzr.DeclConstructor, LoC: 2, Complexity: 1, Params: 1},
+	}
+	file := anlzr.FileEntity{Path: "foo/foo.go", Lang: src.Go, LoC: 20, Funcs: 2, DocumentedFuncs: 1, Complexity: 3}
+	pkg := anlzr.PackageEntity{Path: "foo", Name: "foo", LoC: 20, Files: 1, Funcs: 2, DocumentedFuncs: 1, Complexity: 3}
+
+	input := map[anlzr.Granularity][]anlzr.PackageEntity{
+		anlzr.ProjectLevel: nil,
+		anlzr.PackageLevel: {pkg},
+	}
+	withFiles := pkg
+	withFiles.FileEntities = []anlzr.FileEntity{file}
+	input[anlzr.FileLevel] = []anlzr.PackageEntity{withFiles}
+	withFuncs := pkg
+	withFuncs.FileEntities = []anlzr.FileEntity{file}
+	withFuncs.FileEntities[0].FuncEntities = funcs
+	input[anlzr.FuncLevel] = []anlzr.PackageEntity{withFuncs}
+
+	for g, expected := range input {
+		r, err := anlzr.RunAnalyzers(p, anlzr.Entities{Granularity: g})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(r.Entities, expected) {
+			t.Errorf("%s: found %+v, expected %+v", g, r.Entities, expected)
+		}
+	}
+}
+
+func TestEntitiesRules(t *testing.T) {
+	cond := binary(ident("a"), token.LAND, ident("b"))
+	p := &src.Project{
+		Packages: []*src.Package{
+			&src.Package{
+				SrcFiles: []*src.SrcFile{
+					&src.SrcFile{
+						Lang:  &src.Language{Lang: src.Go},
+						Funcs: []*ast.FuncDecl{&ast.FuncDecl{Name: "f", Body: []ast.Stmt{&ast.IfStmt{StmtName: token.IfStmtName, Cond: cond}}}},
+					},
+				},
+			},
+		},
+	}
+
+	input := map[int64]map[string]anlzr.ComplexityRules{
+		3: nil,
+		2: {src.Go: {}},
+	}
+	for expected, rules := range input {
+		r, err := anlzr.RunAnalyzers(p, anlzr.Entities{Granularity: anlzr.FuncLevel, Rules: rules})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if found := r.Entities[0].Complexity; found != expected {
+			t.Errorf("rules %v: found complexity %d, expected %d", rules, found, expected)
+		}
+	}
+}
+
+func TestParseGranularity(t *testing.T) {
+	for _, g := range []anlzr.Granularity{anlzr.ProjectLevel, anlzr.PackageLevel, anlzr.FileLevel, anlzr.FuncLevel} {
+		if found, err := anlzr.ParseGranularity(g.String()); err != nil || found != g {
+			t.Errorf("%s: found %s (%v), expected %s", g, found, err, g)
+		}
+	}
+	if _, err := anlzr.ParseGranularity("method"); err == nil {
+		t.Error("method: found no error, expected an error")
+	}
+}
+
+func TestTrim(t *testing.T) {
+	newResult := func() *anlzr.Result {
+		return &anlzr.Result{
+			Complexity: anlzr.ComplexityMetrics{
+				Total: 3,
+				Files: []anlzr.FileComplexity{{Path: "foo.go", Total: 3, Funcs: []anlzr.FuncComplexity{{Name: "f", Complexity: 3}}}},
+			},
+			Maintainability: anlzr.MaintainabilityMetrics{
+				Packages: []anlzr.PackageMaintainability{{
+					Path:  "foo",
+					Files: []anlzr.FileMaintainability{{Path: "foo.go", Funcs: []anlzr.FuncMaintainability{{Name: "f"}}}},
+				}},
+				Worst: []anlzr.FuncMaintainability{{Name: "f"}},
+			},
+		}
+	}
+
+	// number of packages, files and functions expected in the maintainability
+	// breakdown, and of files and functions in the complexity one
+	input := map[anlzr.Granularity][5]int{
+		anlzr.ProjectLevel: {0, 0, 0, 0, 0},
+		anlzr.PackageLevel: {1, 0, 0, 0, 0},
+		anlzr.FileLevel:    {1, 1, 0, 1, 0},
+		anlzr.FuncLevel:    {1, 1, 1, 1, 1},
+	}
+	for g, expected := range input {
+		r := newResult()
+		r.Trim(g)
+
+		var found [5]int
+		m := r.Maintainability
+		found[0] = len(m.Packages)
+		if len(m.Packages) > 0 {
+			found[1] = len(m.Packages[0].Files)
+			if len(m.Packages[0].Files) > 0 {
+				found[2] = len(m.Packages[0].Files[0].Funcs)
+			}
+		}
+		found[3] = len(r.Complexity.Files)
+		if len(r.Complexity.Files) > 0 {
+			found[4] = len(r.Complexity.Files[0].Funcs)
+		}
+		if found != expected {
+			t.Errorf("%s: found %v, expected %v", g, found, expected)
+		}
+		if r.Complexity.Total != 3 || len(m.Worst) != 1 {
+			t.Errorf("%s: found total %d and %d worst functions, expected 3 and 1", g, r.Complexity.Total, len(m.Worst))
+		}
+	}
+}
@@ -0,0 +1,77 @@
+// Copyright 2014-2015 The DevMine Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package anlzr
+
+import (
+	"github.com/DevMine/srcanlzr/src"
+	"github.com/DevMine/srcanlzr/src/ast"
+)
+
+// A function is a function-like declaration of a source file: a function, a
+// method, a constructor or a destructor.
+type function struct {
+	// Name of the function, prefixed by the names of its enclosing types
+	// (e.g. "Outer.Inner.method").
+	Name string
+
+	// The declaration: *ast.FuncDecl, *ast.MethodDecl, *ast.ConstructorDecl
+	// or *ast.DestructorDecl.
+	Decl interface{}
+
+	Doc  []string
+	Body []ast.Stmt
+	LoC  int64
+}
+
+// fileFuncs returns the functions declared in a source file, including the
+// members of its classes, enums and traits, in declaration order.
+func fileFuncs(sf *src.SrcFile) []function {
+	var fs []function
+	for _, f := range sf.Funcs {
+		fs = append(fs, function{Name: f.Name, Decl: f, Doc: f.Doc, Body: f.Body, LoC: f.LoC})
+	}
+
+	members := func(prefix string, cstrs []*ast.ConstructorDecl, dstrs []*ast.DestructorDecl, mthds []*ast.MethodDecl) {
+		for _, c := range cstrs {
+			fs = append(fs, function{Name: prefix + c.Name, Decl: c, Doc: c.Doc, Body: c.Body, LoC: c.LoC})
+		}
+		for _, d := range dstrs {
+			fs = append(fs, function{Name: prefix + d.Name, Decl: d, Doc: d.Doc, Body: d.Body, LoC: d.LoC})
+		}
+		for _, m := range mthds {
+			fs = append(fs, function{Name: prefix + m.Name, Decl: m, Doc: m.Doc, Body: m.Body, LoC: m.LoC})
+		}
+	}
+	var class func(prefix string, c *ast.ClassDecl)
+	class = func(prefix string, c *ast.ClassDecl) {
+		prefix += c.Name + "."
+		members(prefix, c.Constructors, c.Destructors, c.Methods)
+		for _, nested := range c.NestedClasses {
+			class(prefix, nested)
+		}
+	}
+	var trait func(prefix string, t *ast.Trait)
+	trait = func(prefix string, t *ast.Trait) {
+		prefix += t.Name + "."
+		members(prefix, nil, nil, t.Methods)
+		for _, c := range t.Classes {
+			class(prefix, c)
+		}
+		for _, nested := range t.Traits {
+			trait(prefix, nested)
+		}
+	}
+
+	for _, c := range sf.Classes {
+		class("", c)
+	}
+	for _, e := range sf.Enums {
+		members(e.Name+".", e.Constructors, e.Destructors, e.Methods)
+	}
+	for _, t := range sf.Traits {
+		trait("", t)
+	}
+	return fs
+}
@@ -0,0 +1,191 @@
+// Copyright 2014-2015 The DevMine Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package anlzr
+
+import (
+	"math"
+
+	"github.com/DevMine/srcanlzr/src"
+	"github.com/DevMine/srcanlzr/src/ast"
+	"github.com/DevMine/srcanlzr/src/token"
+)
+
+// Operators that do not have a node of their own.
+const (
+	elseOperator    = "ELSE"
+	caseOperator    = "CASE"
+	defaultOperator = "DEFAULT"
+	catchOperator   = "CATCH"
+	finallyOperator = "FINALLY"
+	newOperator     = "NEW"
+)
+
+// Halstead computes the Halstead software science metrics of each function,
+// source file and of the whole project.
+//
+// The operands are the identifiers and the literals. The operators are the
+// unary, binary and increment/decrement operators, the statements keywords
+// (if, else, loops, return, ...), the other expressions (indexing, ternary
+// expression, attribute reference, ...) and the calls, identified by the
+// called function. Only the bodies of the functions are taken into account;
+// the metrics of a file or a project are computed over the operators and
+// operands of all its functions.
+type Halstead struct{}
+
+func (h Halstead) Analyze(p *src.Project, r *Result) error {
+	m := HalsteadMetrics{Files: []FileHalstead{}}
+
+	project := newHalsteadCounts()
+	for _, pkg := range p.Packages {
+		for _, sf := range pkg.SrcFiles {
+			fh := FileHalstead{Path: sf.Path, Funcs: []FuncHalstead{}}
+
+			file := newHalsteadCounts()
+			for _, f := range fileFuncs(sf) {
+				counts := newHalsteadCounts()
+				for _, stmt := range f.Body {
+					ast.Inspect(stmt, counts.visit)
+				}
+				fh.Funcs = append(fh.Funcs, FuncHalstead{Name: f.Name, HalsteadValues: counts.values()})
+				file.add(counts)
+			}
+			fh.HalsteadValues = file.values()
+
+			project.add(file)
+			m.Files = append(m.Files, fh)
+		}
+	}
+	m.HalsteadValues = project.values()
+
+	r.Halstead = m
+
+	return nil
+}
+
+// halsteadCounts counts the occurrences of each operator and operand.
+type halsteadCounts struct {
+	operators map[string]int64
+	operands  map[string]int64
+}
+
+func newHalsteadCounts() *halsteadCounts {
+	return &halsteadCounts{operators: make(map[string]int64), operands: make(map[string]int64)}
+}
+
+func (hc *halsteadCounts) add(o *halsteadCounts) {
+	for k, n := range o.operators {
+		hc.operators[k] += n
+	}
+	for k, n := range o.operands {
+		hc.operands[k] += n
+	}
+}
+
+// visit counts the operators and operands of a node. It is meant to be passed
+// to ast.Inspect.
+func (hc *halsteadCounts) visit(node interface{}) bool {
+	switch n := node.(type) {
+	case nil:
+		return false
+
+	// operands
+	case *ast.Ident:
+		hc.operands[n.Name]++
+	case *ast.BasicLit:
+		hc.operands[n.Kind+" "+n.Value]++
+
+	// operators
+	case *ast.UnaryExpr:
+		hc.operators[n.Op]++
+	case *ast.BinaryExpr:
+		hc.operators[n.Op]++
+	case *ast.IncDecExpr:
+		hc.operators[n.Op]++
+	case *ast.CallExpr:
+		hc.operators[callee(n.Fun)]++
+	case *ast.ConstructorCallExpr:
+		hc.operators[newOperator+" "+callee(n.Fun)]++
+	case *ast.IfStmt:
+		hc.operators[token.IfStmtName]++
+		if len(n.Else) > 0 {
+			hc.operators[elseOperator]++
+		}
+	case *ast.SwitchStmt:
+		hc.operators[token.SwitchStmtName]++
+		if len(n.Default) > 0 {
+			hc.operators[defaultOperator]++
+		}
+	case *ast.CaseClause:
+		hc.operators[caseOperator]++
+	case *ast.TryStmt:
+		hc.operators[token.TryStmtName]++
+		if len(n.Finally) > 0 {
+			hc.operators[finallyOperator]++
+		}
+	case *ast.CatchClause:
+		hc.operators[catchOperator]++
+	case *ast.AssignStmt:
+		hc.operators[token.AssignStmtName]++
+	case *ast.DeclStmt:
+		hc.operators[token.DeclStmtName]++
+	case *ast.LoopStmt:
+		hc.operators[token.LoopStmtName]++
+	case *ast.RangeLoopStmt:
+		hc.operators[token.RangeLoopStmtName]++
+	case *ast.ReturnStmt:
+		hc.operators[token.ReturnStmtName]++
+	case *ast.ThrowStmt:
+		hc.operators[token.ThrowStmtName]++
+	case *ast.TernaryExpr:
+		hc.operators[token.TernaryExprName]++
+	case *ast.IndexExpr:
+		hc.operators[token.IndexExprName]++
+	case *ast.AttrRef:
+		hc.operators[token.AttrRefName]++
+	case *ast.ArrayLit:
+		hc.operators[token.ArrayLitName]++
+	case *ast.FuncLit:
+		hc.operators[token.FuncLitName]++
+	}
+	return true
+}
+
+// callee returns the name of a called function, with its namespace.
+func callee(fun *ast.FuncRef) string {
+	if fun == nil {
+		return ""
+	}
+	if fun.Namespace != "" {
+		return fun.Namespace + "." + fun.FuncName
+	}
+	return fun.FuncName
+}
+
+// values computes the Halstead metrics from the counts.
+func (hc *halsteadCounts) values() HalsteadValues {
+	var v HalsteadValues
+	v.DistinctOperators = int64(len(hc.operators))
+	v.DistinctOperands = int64(len(hc.operands))
+	for _, n := range hc.operators {
+		v.TotalOperators += n
+	}
+	for _, n := range hc.operands {
+		v.TotalOperands += n
+	}
+
+	v.Vocabulary = v.DistinctOperators + v.DistinctOperands
+	v.Length = v.TotalOperators + v.TotalOperands
+	if v.Vocabulary > 0 {
+		v.Volume = float32(float64(v.Length) * math.Log2(float64(v.Vocabulary)))
+	}
+	if v.DistinctOperands > 0 {
+		v.Difficulty = float32(v.DistinctOperators) / 2 * float32(v.TotalOperands) / float32(v.DistinctOperands)
+	}
+	v.Effort = v.Difficulty * v.Volume
+	v.Time = v.Effort / 18
+	v.Bugs = v.Volume / 3000
+
+	return v
+}
@@ -0,0 +1,95 @@
+// Copyright 2014-2015 The DevMine Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package anlzr_test
+
+import (
+	"testing"
+
+	"github.com/DevMine/srcanlzr/anlzr"
+	"github.com/DevMine/srcanlzr/src"
+	"github.com/DevMine/srcanlzr/src/ast"
+	"github.com/DevMine/srcanlzr/src/token"
+)
+
+func binary(x ast.Expr, op string, y ast.Expr) *ast.BinaryExpr {
+	return &ast.BinaryExpr{ExprName: token.BinaryExprName, LeftExpr: x, Op: op, RightExpr: y}
+}
+
+func TestHalstead(t *testing.T) {
+	two := &ast.BasicLit{ExprName: token.BasicLitName, Kind: token.IntLit, Value: "2"}
+	p := &src.Project{
+		Packages: []*src.Package{
+			&src.Package{
+				SrcFiles: []*src.SrcFile{
+					&src.SrcFile{
+						Path: "foo.go",
+						Funcs: []*ast.FuncDecl{
+							&ast.FuncDecl{
+								Name: "f",
+								Body: []ast.Stmt{
+									// x = a + b
+									&ast.AssignStmt{
+										StmtName: token.AssignStmtName,
+										LHS:      []ast.Expr{ident("x")},
+										RHS:      []ast.Expr{binary(ident("a"), token.ADD, ident("b"))},
+									},
+									// return x * 2
+									&ast.ReturnStmt{
+										StmtName: token.ReturnStmtName,
+										Results:  []ast.Expr{binary(ident("x"), token.MUL, two)},
+									},
+								},
+							},
+						},
+						Classes: []*ast.ClassDecl{
+							&ast.ClassDecl{
+								Name: "Foo",
+								Methods: []*ast.MethodDecl{
+									&ast.MethodDecl{FuncDecl: ast.FuncDecl{
+										Name: "g",
+										Body: []ast.Stmt{&ast.ReturnStmt{StmtName: token.ReturnStmtName, Results: []ast.Expr{ident("a")}}},
+									}},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	r, err := anlzr.RunAnalyzers(p, anlzr.Halstead{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := r.Halstead
+
+	input := []struct {
+		name     string
+		found    anlzr.HalsteadValues
+		expected anlzr.HalsteadValues
+	}{
+		{"f", m.Files[0].Funcs[0].HalsteadValues, anlzr.HalsteadValues{
+			DistinctOperators: 4, DistinctOperands: 4, TotalOperators: 4, TotalOperands: 5,
+			Vocabulary: 8, Length: 9, Volume: 27, Difficulty: 2.5, Effort: 67.5, Time: 3.75, Bugs: 0.009,
+		}},
+		{"Foo.g", m.Files[0].Funcs[1].HalsteadValues, anlzr.HalsteadValues{
+			DistinctOperators: 1, DistinctOperands: 1, TotalOperators: 1, TotalOperands: 1,
+			Vocabulary: 2, Length: 2, Volume: 2, Difficulty: 0.5, Effort: 1, Time: 1.0 / 18, Bugs: 2.0 / 3000,
+		}},
+		{"project", m.HalsteadValues, anlzr.HalsteadValues{
+			DistinctOperators: 4, DistinctOperands: 4, TotalOperators: 5, TotalOperands: 6,
+			Vocabulary: 8, Length: 11, Volume: 33, Difficulty: 3, Effort: 99, Time: 5.5, Bugs: 0.011,
+		}},
+	}
+	for _, in := range input {
+		if in.found != in.expected {
+			t.Errorf("%s: found %+v, expected %+v", in.name, in.found, in.expected)
+		}
+	}
+	if m.Files[0].Funcs[1].Name != "Foo.g" {
+		t.Errorf("function name: found %s, expected Foo.g", m.Files[0].Funcs[1].Name)
+	}
+}
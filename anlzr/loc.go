@@ -4,13 +4,21 @@
 
 package anlzr
 
-import "github.com/DevMine/srcanlzr/src"
+import (
+	"math"
+
+	"github.com/DevMine/srcanlzr/src"
+)
 
 const (
 	maxInt64 = int64(^uint64(0) >> 1)
 	minInt64 = -maxInt64 - 1
 )
 
+// LoC computes the total number of lines of code of the project and the
+// statistics of the lengths of its functions and methods. The lengths of all
+// the functions, constructors and destructors included, are listed per source
+// file as well.
 type LoC struct{}
 
 func (lca LoC) Analyze(p *src.Project, r *Result) error {
@@ -22,10 +30,18 @@ func (lca LoC) Analyze(p *src.Project, r *Result) error {
 	maxLoCFunc := minInt64
 	minLoCFunc := maxInt64
 
-	hist := make(map[int64]int64)
+	var lengths []float64
+
+	funcLens := []FileFuncLengths{}
 
 	for _, pkg := range p.Packages {
 		for _, sf := range pkg.SrcFiles {
+			fl := FileFuncLengths{Path: sf.Path, Funcs: []FuncLength{}}
+			for _, f := range fileFuncs(sf) {
+				fl.Funcs = append(fl.Funcs, FuncLength{Name: f.Name, LoC: f.LoC})
+			}
+			funcLens = append(funcLens, fl)
+
 			for _, f := range sf.Funcs {
 				totalFuncs++
 				totalLoCFunc += f.LoC
@@ -38,7 +54,7 @@ func (lca LoC) Analyze(p *src.Project, r *Result) error {
 					minLoCFunc = f.LoC
 				}
 
-				hist[f.LoC] += int64(1)
+				lengths = append(lengths, float64(f.LoC))
 			}
 
 			for _, cls := range sf.Classes {
@@ -54,7 +70,7 @@ func (lca LoC) Analyze(p *src.Project, r *Result) error {
 						minLoCFunc = m.LoC
 					}
 
-					hist[m.LoC] += int64(1)
+					lengths = append(lengths, float64(m.LoC))
 
 				}
 			}
@@ -72,31 +88,20 @@ func (lca LoC) Analyze(p *src.Project, r *Result) error {
 						minLoCFunc = m.LoC
 					}
 
-					hist[m.LoC] += int64(1)
+					lengths = append(lengths, float64(m.LoC))
 				}
 			}
 		}
 	}
 
 	r.AverageFuncLen = float32(totalLoCFunc) / float32(totalFuncs)
+	r.FuncLengths = funcLens
 	r.MaxFuncLen = maxLoCFunc
 	r.MinFuncLen = minLoCFunc
-	r.MedianFuncLen = lca.median(hist)
-
-	return nil
-}
-
-// median computes the media of a given histogram.
-func (lca LoC) median(h map[int64]int64) int64 {
-	maxVal := minInt64
-	maxKey := int64(-1)
-
-	for k, v := range h {
-		if v > maxVal {
-			maxVal = v
-			maxKey = k
-		}
+	if len(lengths) > 0 {
+		// the median of an even number of lengths may be fractional
+		r.MedianFuncLen = int64(math.Floor(Median(lengths) + 0.5))
 	}
 
-	return maxKey
+	return nil
 }
@@ -0,0 +1,151 @@
+// Copyright 2014-2015 The DevMine Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package anlzr
+
+import (
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/DevMine/srcanlzr/src"
+	"github.com/DevMine/srcanlzr/src/ast"
+)
+
+// DefaultWorstFuncs is the default number of functions listed as the worst
+// offenders by the Maintainability analyzer.
+const DefaultWorstFuncs = 10
+
+// Maintainability computes the maintainability index of each function, from
+// its Halstead volume (V), its cyclomatic complexity (G, see Complexity) and
+// its number of lines of code (LoC). The classic index is:
+//
+//	MI = 171 - 5.2 * ln(V) - 0.23 * G - 16.2 * ln(LoC)
+//
+// When WithComments is set, the term 50 * sin(sqrt(2.4 * CM)) is added, where
+// CM is the ratio of documentation lines over the total number of lines of the
+// function, documentation included. The normalized index is the classic index
+// brought back to the 0-100 range: max(0, min(100, MI * 100 / 171)).
+//
+// The indices of the source files, packages and of the project are the
+// averages of the indices of their functions, or -1 when there is no function.
+// The functions with the lowest indices are listed as the worst offenders.
+type Maintainability struct {
+	WithComments bool // add the comment term to the index
+	Worst        int  // number of worst offenders to list; DefaultWorstFuncs if 0
+
+	// Rules overrides DefaultComplexityRules for the given languages; it may
+	// be nil.
+	Rules map[string]ComplexityRules
+}
+
+func (mi Maintainability) Analyze(p *src.Project, r *Result) error {
+	m := MaintainabilityMetrics{
+		Packages: []PackageMaintainability{},
+		Worst:    []FuncMaintainability{},
+	}
+
+	var all []FuncMaintainability
+	for _, pkg := range p.Packages {
+		pm := PackageMaintainability{Path: pkg.Path, Files: []FileMaintainability{}}
+
+		var pkgFuncs []FuncMaintainability
+		for _, sf := range pkg.SrcFiles {
+			fm := FileMaintainability{Path: sf.Path, Funcs: []FuncMaintainability{}}
+			for _, f := range fileFuncs(sf) {
+				fm.Funcs = append(fm.Funcs, mi.function(sf, f))
+			}
+			fm.MaintainabilityValues = averageMaintainability(fm.Funcs)
+
+			pkgFuncs = append(pkgFuncs, fm.Funcs...)
+			pm.Files = append(pm.Files, fm)
+		}
+		pm.MaintainabilityValues = averageMaintainability(pkgFuncs)
+
+		all = append(all, pkgFuncs...)
+		m.Packages = append(m.Packages, pm)
+	}
+	m.MaintainabilityValues = averageMaintainability(all)
+
+	worst := mi.Worst
+	if worst <= 0 {
+		worst = DefaultWorstFuncs
+	}
+	sorted := make([]FuncMaintainability, len(all))
+	copy(sorted, all)
+	sort.Stable(byMaintainability(sorted))
+	if len(sorted) > worst {
+		sorted = sorted[:worst]
+	}
+	m.Worst = append(m.Worst, sorted...)
+
+	r.Maintainability = m
+
+	return nil
+}
+
+// function computes the maintainability index of a function.
+func (mi Maintainability) function(sf *src.SrcFile, f function) FuncMaintainability {
+	counts := newHalsteadCounts()
+	for _, stmt := range f.Body {
+		ast.Inspect(stmt, counts.visit)
+	}
+	cc := cyclomaticComplexity(f.Body, Complexity{Rules: mi.Rules}.rules(sf))
+
+	fm := FuncMaintainability{
+		Name:       f.Name,
+		File:       sf.Path,
+		Volume:     counts.values().Volume,
+		Complexity: cc,
+		LoC:        f.LoC,
+	}
+
+	index := 171 - 5.2*ln(float64(fm.Volume)) - 0.23*float64(cc) - 16.2*ln(float64(f.LoC))
+	if mi.WithComments {
+		var docLines int64
+		for _, d := range f.Doc {
+			docLines += int64(strings.Count(d, "\n")) + 1
+		}
+		if docLines+f.LoC > 0 {
+			fm.CommentRatio = float32(docLines) / float32(docLines+f.LoC)
+		}
+		index += 50 * math.Sin(math.Sqrt(2.4*float64(fm.CommentRatio)))
+	}
+
+	fm.Index = float32(index)
+	fm.Normalized = float32(math.Max(0, math.Min(100, index*100/171)))
+	return fm
+}
+
+// ln returns the natural logarithm of x, or 0 when x is lower than 1 so that
+// empty functions do not get an infinite index.
+func ln(x float64) float64 {
+	if x < 1 {
+		return 0
+	}
+	return math.Log(x)
+}
+
+// averageMaintainability returns the average indices of a list of functions,
+// or -1 when the list is empty.
+func averageMaintainability(fs []FuncMaintainability) MaintainabilityValues {
+	if len(fs) == 0 {
+		return MaintainabilityValues{Index: -1, Normalized: -1}
+	}
+	var v MaintainabilityValues
+	for _, f := range fs {
+		v.Index += f.Index
+		v.Normalized += f.Normalized
+	}
+	v.Index /= float32(len(fs))
+	v.Normalized /= float32(len(fs))
+	return v
+}
+
+// byMaintainability sorts functions by increasing maintainability index.
+type byMaintainability []FuncMaintainability
+
+func (s byMaintainability) Len() int           { return len(s) }
+func (s byMaintainability) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
+func (s byMaintainability) Less(i, j int) bool { return s[i].Index < s[j].Index }
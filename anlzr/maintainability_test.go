@@ -0,0 +1,124 @@
+// Copyright 2014-2015 The DevMine Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package anlzr_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/DevMine/srcanlzr/anlzr"
+	"github.com/DevMine/srcanlzr/src"
+	"github.com/DevMine/srcanlzr/src/ast"
+	"github.com/DevMine/srcanlzr/src/token"
+)
+
+func TestMaintainability(t *testing.T) {
+	two := &ast.BasicLit{ExprName: token.BasicLitName, Kind: token.IntLit, Value: "2"}
+	p := &src.Project{
+		Packages: []*src.Package{
+			&src.Package{
+				Path: "foo",
+				SrcFiles: []*src.SrcFile{
+					&src.SrcFile{
+						Path: "foo/foo.go",
+						Funcs: []*ast.FuncDecl{
+							&ast.FuncDecl{
+								Name: "f",
+								Doc:  []string{"f does things\nwith x"},
+								Body: []ast.Stmt{
+									&ast.AssignStmt{
+										StmtName: token.AssignStmtName,
+										LHS:      []ast.Expr{ident("x")},
+										RHS:      []ast.Expr{binary(ident("a"), token.ADD, ident("b"))},
+									},
+									&ast.ReturnStmt{
+										StmtName: token.ReturnStmtName,
+										Results:  []ast.Expr{binary(ident("x"), token.MUL, two)},
+									},
+								},
+								LoC: 4,
+							},
+							&ast.FuncDecl{Name: "empty"},
+						},
+					},
+					&src.SrcFile{Path: "foo/doc.go"},
+				},
+			},
+		},
+	}
+
+	// V = 27, G = 1, LoC = 4, CM = 2/6
+	classic := 171 - 5.2*math.Log(27) - 0.23 - 16.2*math.Log(4)
+	withComments := classic + 50*math.Sin(math.Sqrt(2.4*2/6))
+
+	input := []struct {
+		anlzr    anlzr.Maintainability
+		expected float64
+	}{
+		{anlzr.Maintainability{Worst: 1}, classic},
+		{anlzr.Maintainability{Worst: 1, WithComments: true}, withComments},
+	}
+	for _, in := range input {
+		r, err := anlzr.RunAnalyzers(p, in.anlzr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		m := r.Maintainability
+
+		if len(m.Worst) != 1 || m.Worst[0].Name != "f" || m.Worst[0].File != "foo/foo.go" {
+			t.Fatalf("%+v: found worst functions %+v, expected f", in.anlzr, m.Worst)
+		}
+		f := m.Worst[0]
+		if math.Abs(float64(f.Index)-in.expected) > 1e-3 {
+			t.Errorf("%+v: found index %f, expected %f", in.anlzr, f.Index, in.expected)
+		}
+		if math.Abs(float64(f.Normalized)-in.expected*100/171) > 1e-3 {
+			t.Errorf("%+v: found normalized index %f, expected %f", in.anlzr, f.Normalized, in.expected*100/171)
+		}
+
+		empty := m.Packages[0].Files[0].Funcs[1]
+		if empty.Index != 170.77 || empty.Normalized != float32(170.77*100/171) {
+			t.Errorf("%+v: found index %f (%f) for an empty function, expected 170.77 (%f)", in.anlzr, empty.Index, empty.Normalized, 170.77*100/171)
+		}
+
+		avg := (f.Index + empty.Index) / 2
+		if m.Index != avg || m.Packages[0].Index != avg || m.Packages[0].Files[0].Index != avg {
+			t.Errorf("%+v: found average indices %f/%f/%f, expected %f", in.anlzr, m.Index, m.Packages[0].Index, m.Packages[0].Files[0].Index, avg)
+		}
+		if doc := m.Packages[0].Files[1]; doc.Index != -1 || doc.Normalized != -1 {
+			t.Errorf("%+v: found index %f for a file without function, expected -1", in.anlzr, doc.Index)
+		}
+	}
+}
+
+func TestMaintainabilityRules(t *testing.T) {
+	cond := binary(ident("a"), token.LAND, ident("b"))
+	p := &src.Project{
+		Packages: []*src.Package{
+			&src.Package{
+				SrcFiles: []*src.SrcFile{
+					&src.SrcFile{
+						Lang:  &src.Language{Lang: src.Go},
+						Funcs: []*ast.FuncDecl{&ast.FuncDecl{Name: "f", Body: []ast.Stmt{&ast.IfStmt{StmtName: token.IfStmtName, Cond: cond}}}},
+					},
+				},
+			},
+		},
+	}
+
+	input := map[int64]map[string]anlzr.ComplexityRules{
+		3: nil,
+		2: {src.Go: {}},
+	}
+	for expected, rules := range input {
+		r, err := anlzr.RunAnalyzers(p, anlzr.Maintainability{Rules: rules})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if found := r.Maintainability.Worst[0].Complexity; found != expected {
+			t.Errorf("rules %v: found complexity %d, expected %d", rules, found, expected)
+		}
+	}
+}
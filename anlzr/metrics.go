@@ -0,0 +1,128 @@
+// Copyright 2014-2015 The DevMine Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package anlzr
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"regexp"
+)
+
+// Types of the values of the metrics.
+const (
+	MetricInt    = "int"    // int64
+	MetricFloat  = "float"  // float64
+	MetricString = "string" // string
+	MetricBool   = "bool"   // bool
+)
+
+// A Metric is a named value computed by an analyzer which has no section of
+// its own in Result, typically an analyzer written outside of this package.
+type Metric struct {
+	// Key names the metric. It is made of dot separated lower case segments,
+	// the first ones being a namespace (e.g. "acme.security.unsafe_calls").
+	Key string `json:"key" xml:"key,attr"`
+
+	// Scope is the package path, the file path or the function name to which
+	// the metric applies, or empty for the whole project.
+	Scope string `json:"scope,omitempty" xml:"scope,attr,omitempty"`
+
+	Type  string      `json:"type" xml:"type,attr"`                     // see the Metric constants
+	Unit  string      `json:"unit,omitempty" xml:"unit,attr,omitempty"` // e.g. "lines" or "ms"
+	Value interface{} `json:"value" xml:"value"`
+}
+
+var metricKey = regexp.MustCompile(`^[a-z0-9_]+(\.[a-z0-9_]+)+$`)
+
+// AddMetric adds a metric to the result. The type of the metric is set from
+// its value, which must be an integer, a finite float, a string or a bool.
+// It fails if the key is not namespaced or if the result already holds a
+// metric with the same key and scope.
+func (r *Result) AddMetric(m Metric) error {
+	if !metricKey.MatchString(m.Key) {
+		return fmt.Errorf("invalid metric key %q", m.Key)
+	}
+	if r.Metric(m.Key, m.Scope) != nil {
+		return fmt.Errorf("duplicate metric %s (scope %q)", m.Key, m.Scope)
+	}
+
+	var err error
+	if m.Type, m.Value, err = metricValue(m.Value); err != nil {
+		return fmt.Errorf("metric %s: %v", m.Key, err)
+	}
+
+	r.Metrics = append(r.Metrics, m)
+	return nil
+}
+
+// Metric returns the metric of the given key and scope, or nil if there is
+// none.
+func (r *Result) Metric(key, scope string) *Metric {
+	for i, m := range r.Metrics {
+		if m.Key == key && m.Scope == scope {
+			return &r.Metrics[i]
+		}
+	}
+	return nil
+}
+
+// metricValue returns the type and the normalized value of a metric.
+func metricValue(v interface{}) (string, interface{}, error) {
+	switch v := v.(type) {
+	case int:
+		return MetricInt, int64(v), nil
+	case int32:
+		return MetricInt, int64(v), nil
+	case int64:
+		return MetricInt, v, nil
+	case float32:
+		return metricValue(float64(v))
+	case float64:
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			return "", nil, fmt.Errorf("invalid value %v", v)
+		}
+		return MetricFloat, v, nil
+	case string:
+		return MetricString, v, nil
+	case bool:
+		return MetricBool, v, nil
+	}
+	return "", nil, fmt.Errorf("unsupported value type %T", v)
+}
+
+// UnmarshalJSON decodes a metric, its value having the Go type of its type
+// (e.g. int64 for MetricInt).
+func (m *Metric) UnmarshalJSON(data []byte) error {
+	type metric Metric
+	var aux struct {
+		metric
+		Value json.RawMessage `json:"value"`
+	}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	*m = Metric(aux.metric)
+
+	var v interface{}
+	switch m.Type {
+	case MetricInt:
+		v = new(int64)
+	case MetricFloat:
+		v = new(float64)
+	case MetricString:
+		v = new(string)
+	case MetricBool:
+		v = new(bool)
+	default:
+		return fmt.Errorf("metric %s: unknown type %q", m.Key, m.Type)
+	}
+	if err := json.Unmarshal(aux.Value, v); err != nil {
+		return fmt.Errorf("metric %s: %v", m.Key, err)
+	}
+	m.Value = reflect.ValueOf(v).Elem().Interface()
+	return nil
+}
@@ -0,0 +1,90 @@
+// Copyright 2014-2015 The DevMine Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package anlzr_test
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/DevMine/srcanlzr/anlzr"
+	"github.com/DevMine/srcanlzr/src"
+)
+
+// packages is an analyzer adding custom metrics.
+type packages struct{}
+
+func (packages) Analyze(p *src.Project, r *anlzr.Result) error {
+	if err := r.AddMetric(anlzr.Metric{Key: "acme.packages", Value: len(p.Packages)}); err != nil {
+		return err
+	}
+	for _, pkg := range p.Packages {
+		m := anlzr.Metric{Key: "acme.files", Scope: pkg.Path, Unit: "files", Value: len(pkg.SrcFiles)}
+		if err := r.AddMetric(m); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ratio is an analyzer adding a custom metric.
+type ratio struct{}
+
+func (ratio) Analyze(p *src.Project, r *anlzr.Result) error {
+	return r.AddMetric(anlzr.Metric{Key: "acme.ratio", Value: float32(0.5)})
+}
+
+func TestMetrics(t *testing.T) {
+	p := &src.Project{Packages: []*src.Package{&src.Package{Path: "x", SrcFiles: []*src.SrcFile{&src.SrcFile{}}}}}
+
+	r, err := anlzr.RunAnalyzers(p, packages{}, ratio{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []anlzr.Metric{
+		{Key: "acme.packages", Type: anlzr.MetricInt, Value: int64(1)},
+		{Key: "acme.files", Scope: "x", Type: anlzr.MetricInt, Unit: "files", Value: int64(1)},
+		{Key: "acme.ratio", Type: anlzr.MetricFloat, Value: float64(0.5)},
+	}
+	if !reflect.DeepEqual(r.Metrics, expected) {
+		t.Errorf("RunAnalyzers: found metrics %+v, expected %+v", r.Metrics, expected)
+	}
+
+	bs, err := json.Marshal(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded anlzr.Result
+	if err := json.Unmarshal(bs, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(decoded.Metrics, expected) {
+		t.Errorf("json: found metrics %+v, expected %+v", decoded.Metrics, expected)
+	}
+
+	bs, err = xml.Marshal(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s := `<metric key="acme.files" scope="x" type="int" unit="files"><value>1</value></metric>`; !strings.Contains(string(bs), s) {
+		t.Errorf("xml: %s not found in %s", s, bs)
+	}
+
+	if _, err := anlzr.RunAnalyzers(p, packages{}, packages{}); err == nil {
+		t.Error("RunAnalyzers: found no error for duplicate metrics, expected an error")
+	}
+	for _, m := range []anlzr.Metric{
+		{Key: "packages", Value: 1},
+		{Key: "Acme.packages", Value: 1},
+		{Key: "acme.packages", Value: []int{1}},
+		{Key: "acme.packages"},
+	} {
+		if err := new(anlzr.Result).AddMetric(m); err == nil {
+			t.Errorf("AddMetric %+v: found no error, expected an error", m)
+		}
+	}
+}
@@ -0,0 +1,225 @@
+// Copyright 2014-2015 The DevMine Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package anlzr
+
+import (
+	"github.com/DevMine/srcanlzr/src"
+	"github.com/DevMine/srcanlzr/src/ast"
+	"github.com/DevMine/srcanlzr/src/token"
+)
+
+// DefaultMaxNesting is the default nesting depth above which a function is
+// reported by the Nesting analyzer.
+const DefaultMaxNesting = 4
+
+// Nesting measures the nesting depth of the control structures of each
+// function. The statements of a function body are at depth 0, and the blocks
+// of an if statement, a loop, a switch statement, a try statement, a function
+// literal or a class literal are one level deeper than the structure itself.
+// The else if clauses do not increase the depth.
+//
+// For each function, the maximum depth, the average depth of the statements,
+// the number of blocks and the location of the deepest structure are
+// reported. The functions deeper than MaxDepth are listed as deep functions.
+type Nesting struct {
+	MaxDepth int // maximum allowed depth; DefaultMaxNesting if 0
+}
+
+func (n Nesting) Analyze(p *src.Project, r *Result) error {
+	maxDepth := int64(n.MaxDepth)
+	if maxDepth <= 0 {
+		maxDepth = DefaultMaxNesting
+	}
+
+	m := NestingMetrics{Files: []FileNesting{}, DeepFuncs: []FuncNesting{}}
+
+	var funcs, sumMax int64
+	for _, pkg := range p.Packages {
+		for _, sf := range pkg.SrcFiles {
+			fn := FileNesting{Path: sf.Path, Funcs: []FuncNesting{}}
+			for _, f := range fileFuncs(sf) {
+				v := &nestingVisitor{}
+				v.stmts(f.Body, 0, "", 0)
+
+				fc := FuncNesting{
+					Name:        f.Name,
+					File:        sf.Path,
+					MaxDepth:    v.maxDepth,
+					Blocks:      v.blocks,
+					DeepestKind: v.deepestKind,
+					DeepestLine: v.deepestLine,
+				}
+				if v.nstmts > 0 {
+					fc.AverageDepth = float32(v.sumDepth) / float32(v.nstmts)
+				}
+				fn.Funcs = append(fn.Funcs, fc)
+
+				if fc.MaxDepth > fn.MaxDepth {
+					fn.MaxDepth = fc.MaxDepth
+				}
+				if fc.MaxDepth > maxDepth {
+					m.DeepFuncs = append(m.DeepFuncs, fc)
+				}
+				m.Blocks += fc.Blocks
+				sumMax += fc.MaxDepth
+				funcs++
+			}
+			if fn.MaxDepth > m.MaxDepth {
+				m.MaxDepth = fn.MaxDepth
+			}
+			m.Files = append(m.Files, fn)
+		}
+	}
+	if funcs > 0 {
+		m.AverageMaxDepth = float32(sumMax) / float32(funcs)
+	}
+
+	r.Nesting = m
+
+	return nil
+}
+
+// A nestingVisitor measures the nesting depth of a function body.
+type nestingVisitor struct {
+	maxDepth    int64
+	deepestKind string
+	deepestLine int64
+	blocks      int64
+	nstmts      int64 // number of statements
+	sumDepth    int64 // sum of the depths of the statements
+}
+
+// block visits a block at the given depth, belonging to a structure of the
+// given kind (a statement or an expression name) and line.
+func (v *nestingVisitor) block(stmts []ast.Stmt, depth int64, kind string, line int64) {
+	v.blocks++
+	if depth > v.maxDepth {
+		v.maxDepth, v.deepestKind, v.deepestLine = depth, kind, line
+	}
+	v.stmts(stmts, depth, kind, line)
+}
+
+func (v *nestingVisitor) stmts(stmts []ast.Stmt, depth int64, kind string, line int64) {
+	for _, s := range stmts {
+		v.nstmts++
+		v.sumDepth += depth
+		l := stmtLine(s)
+		if l == 0 {
+			l = line
+		}
+		v.walk(s, depth, l)
+	}
+}
+
+// walk visits the tree rooted at node, at the given depth. line is the line of
+// the closest enclosing statement having one.
+func (v *nestingVisitor) walk(node interface{}, depth, line int64) {
+	ast.Inspect(node, func(node interface{}) bool {
+		switch n := node.(type) {
+		case nil:
+			return false
+		case *ast.IfStmt:
+			v.ifStmt(n, depth, line)
+		case *ast.LoopStmt:
+			line = lineOr(n.Line, line)
+			v.stmts(n.Init, depth, token.LoopStmtName, line)
+			v.walk(n.Cond, depth, line)
+			v.stmts(n.Post, depth, token.LoopStmtName, line)
+			v.block(n.Body, depth+1, token.LoopStmtName, line)
+			if len(n.Else) > 0 {
+				v.block(n.Else, depth+1, token.LoopStmtName, line)
+			}
+		case *ast.RangeLoopStmt:
+			line = lineOr(n.Line, line)
+			v.walk(n.Iterable, depth, line)
+			v.block(n.Body, depth+1, token.RangeLoopStmtName, line)
+		case *ast.SwitchStmt:
+			v.walk(n.Init, depth, line)
+			v.walk(n.Cond, depth, line)
+			for _, cc := range n.CaseClauses {
+				for _, cond := range cc.Conds {
+					v.walk(cond, depth, line)
+				}
+				v.block(cc.Body, depth+1, token.SwitchStmtName, line)
+			}
+			if len(n.Default) > 0 {
+				v.block(n.Default, depth+1, token.SwitchStmtName, line)
+			}
+		case *ast.TryStmt:
+			v.block(n.Body, depth+1, token.TryStmtName, line)
+			for _, cc := range n.CatchClauses {
+				v.block(cc.Body, depth+1, token.TryStmtName, line)
+			}
+			if len(n.Finally) > 0 {
+				v.block(n.Finally, depth+1, token.TryStmtName, line)
+			}
+		case *ast.FuncLit:
+			v.block(n.Body, depth+1, token.FuncLitName, line)
+		case *ast.ClassLit:
+			for _, c := range n.Constructors {
+				v.block(c.Body, depth+1, token.ClassLitName, line)
+			}
+			for _, d := range n.Destructors {
+				v.block(d.Body, depth+1, token.ClassLitName, line)
+			}
+			for _, m := range n.Methods {
+				v.block(m.Body, depth+1, token.ClassLitName, line)
+			}
+		default:
+			return true
+		}
+		return false
+	})
+}
+
+func (v *nestingVisitor) ifStmt(is *ast.IfStmt, depth, line int64) {
+	line = lineOr(is.Line, line)
+	v.walk(is.Init, depth, line)
+	v.walk(is.Cond, depth, line)
+	v.block(is.Body, depth+1, token.IfStmtName, line)
+
+	// else if: same depth as the first if
+	if len(is.Else) == 1 {
+		if elif, ok := is.Else[0].(*ast.IfStmt); ok {
+			v.ifStmt(elif, depth, line)
+			return
+		}
+	}
+	if len(is.Else) > 0 {
+		v.block(is.Else, depth+1, token.IfStmtName, line)
+	}
+}
+
+// stmtLine returns the line number of a statement, or 0 if unknown.
+func stmtLine(s ast.Stmt) int64 {
+	switch s := s.(type) {
+	case *ast.AssignStmt:
+		return s.Line
+	case *ast.DeclStmt:
+		return s.Line
+	case *ast.IfStmt:
+		return s.Line
+	case *ast.LoopStmt:
+		return s.Line
+	case *ast.RangeLoopStmt:
+		return s.Line
+	case *ast.ReturnStmt:
+		return s.Line
+	case *ast.OtherStmt:
+		return s.Line
+	case *ast.ExprStmt:
+		if call, ok := s.X.(*ast.CallExpr); ok {
+			return call.Line
+		}
+	}
+	return 0
+}
+
+func lineOr(line, def int64) int64 {
+	if line != 0 {
+		return line
+	}
+	return def
+}
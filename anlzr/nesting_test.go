@@ -0,0 +1,86 @@
+// Copyright 2014-2015 The DevMine Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package anlzr_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/DevMine/srcanlzr/anlzr"
+	"github.com/DevMine/srcanlzr/src"
+	"github.com/DevMine/srcanlzr/src/ast"
+	"github.com/DevMine/srcanlzr/src/token"
+)
+
+func TestNesting(t *testing.T) {
+	ret := func() ast.Stmt { return &ast.ReturnStmt{StmtName: token.ReturnStmtName} }
+	f := &ast.FuncDecl{
+		Name: "f",
+		Body: []ast.Stmt{
+			&ast.AssignStmt{StmtName: token.AssignStmtName, Line: 1},
+			&ast.IfStmt{
+				StmtName: token.IfStmtName,
+				Line:     2,
+				Body: []ast.Stmt{
+					&ast.LoopStmt{
+						StmtName: token.LoopStmtName,
+						Line:     3,
+						Body: []ast.Stmt{
+							&ast.SwitchStmt{
+								StmtName:    token.SwitchStmtName,
+								CaseClauses: []*ast.CaseClause{&ast.CaseClause{Body: []ast.Stmt{ret()}}},
+							},
+						},
+					},
+				},
+				Else: []ast.Stmt{
+					&ast.IfStmt{StmtName: token.IfStmtName, Line: 6, Body: []ast.Stmt{ret()}, Else: []ast.Stmt{ret()}},
+				},
+			},
+		},
+	}
+	g := &ast.FuncDecl{
+		Name: "g",
+		Body: []ast.Stmt{
+			&ast.ExprStmt{
+				StmtName: token.ExprStmtName,
+				X: &ast.FuncLit{
+					ExprName: token.FuncLitName,
+					Body:     []ast.Stmt{&ast.IfStmt{StmtName: token.IfStmtName, Line: 12, Body: []ast.Stmt{ret()}}},
+				},
+			},
+		},
+	}
+	p := &src.Project{
+		Packages: []*src.Package{
+			&src.Package{
+				SrcFiles: []*src.SrcFile{
+					&src.SrcFile{Path: "foo.go", Funcs: []*ast.FuncDecl{f, g, &ast.FuncDecl{Name: "h"}}},
+				},
+			},
+		},
+	}
+
+	r, err := anlzr.RunAnalyzers(p, anlzr.Nesting{MaxDepth: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := r.Nesting
+
+	expected := []anlzr.FuncNesting{
+		{Name: "f", File: "foo.go", MaxDepth: 3, AverageDepth: 8.0 / 7, Blocks: 5, DeepestKind: token.SwitchStmtName, DeepestLine: 3},
+		{Name: "g", File: "foo.go", MaxDepth: 2, AverageDepth: 1, Blocks: 2, DeepestKind: token.IfStmtName, DeepestLine: 12},
+		{Name: "h", File: "foo.go"},
+	}
+	if !reflect.DeepEqual(m.Files[0].Funcs, expected) {
+		t.Errorf("functions: found %+v, expected %+v", m.Files[0].Funcs, expected)
+	}
+	if m.MaxDepth != 3 || m.Files[0].MaxDepth != 3 || m.Blocks != 7 || m.AverageMaxDepth != 5.0/3 {
+		t.Errorf("found max depth %d, %d blocks and average max depth %f, expected 3, 7 and %f", m.MaxDepth, m.Blocks, m.AverageMaxDepth, 5.0/3)
+	}
+	if len(m.DeepFuncs) != 1 || m.DeepFuncs[0].Name != "f" {
+		t.Errorf("deep functions: found %+v, expected f", m.DeepFuncs)
+	}
+}
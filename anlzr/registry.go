@@ -0,0 +1,285 @@
+// Copyright 2014-2015 The DevMine Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package anlzr
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/DevMine/srcanlzr/hierarchy"
+	"github.com/DevMine/srcanlzr/resolver"
+	"github.com/DevMine/srcanlzr/src"
+)
+
+// An AnalyzerInfo describes a registered analyzer.
+type AnalyzerInfo struct {
+	Name string
+	Doc  string // one line description
+
+	// Requires lists the analyzers that Select adds along with this one,
+	// before it, usually because they compute data shared between the
+	// analyzers. Run waits for them to complete before running this one.
+	Requires []string
+
+	// Sections lists the names of the fields of Result that the analyzer
+	// computes (e.g. "Complexity"). Run copies only these fields from the
+	// result of the analyzer, and two analyzers cannot compute the same one.
+	Sections []string
+
+	// New returns a pointer to a new analyzer with its default configuration.
+	// The configuration of the analyzer, if any, is decoded from JSON into
+	// the returned value.
+	New func() Analyzer
+}
+
+var (
+	registry = make(map[string]*AnalyzerInfo)
+	ordered  []*AnalyzerInfo // in registration order
+)
+
+// Register adds an analyzer to the registry. It panics if the name is empty
+// or already registered, if New is nil, or if a section is not an exported
+// field of Result other than Metrics.
+func Register(info AnalyzerInfo) {
+	if info.Name == "" {
+		panic("anlzr: Register with an empty name")
+	}
+	if info.New == nil {
+		panic("anlzr: Register " + info.Name + " with a nil New")
+	}
+	if _, dup := registry[info.Name]; dup {
+		panic("anlzr: Register called twice for " + info.Name)
+	}
+	for _, name := range info.Sections {
+		if f, ok := reflect.TypeOf(Result{}).FieldByName(name); !ok || f.PkgPath != "" || name == "Metrics" {
+			panic("anlzr: Register " + info.Name + " with an unknown section " + name)
+		}
+	}
+	registry[info.Name] = &info
+	ordered = append(ordered, &info)
+}
+
+// Analyzers returns the registered analyzers, in registration order.
+func Analyzers() []*AnalyzerInfo {
+	infos := make([]*AnalyzerInfo, len(ordered))
+	copy(infos, ordered)
+	return infos
+}
+
+// LookupAnalyzer returns the registered analyzer with the given name.
+func LookupAnalyzer(name string) (*AnalyzerInfo, error) {
+	info, ok := registry[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown analyzer %q", name)
+	}
+	return info, nil
+}
+
+// A Selection tells which registered analyzers to run and how to configure
+// them.
+type Selection struct {
+	// Enable lists the analyzers to run. When empty, all the registered
+	// analyzers run.
+	Enable []string
+
+	// Disable lists the analyzers not to run. An analyzer cannot be disabled
+	// when an enabled analyzer requires it.
+	Disable []string
+
+	// Config holds the JSON configuration of the analyzers, by name.
+	Config map[string]json.RawMessage
+}
+
+// Select creates the analyzers of a selection, along with the analyzers they
+// require. The analyzers are returned in registration order, except that an
+// analyzer always comes after the analyzers it requires.
+func Select(s Selection) ([]Analyzer, error) {
+	disabled := make(map[string]bool)
+	for _, name := range s.Disable {
+		if _, err := LookupAnalyzer(name); err != nil {
+			return nil, err
+		}
+		disabled[name] = true
+	}
+	for name := range s.Config {
+		if _, err := LookupAnalyzer(name); err != nil {
+			return nil, err
+		}
+	}
+
+	roots := ordered
+	if len(s.Enable) > 0 {
+		roots = nil
+		for _, name := range s.Enable {
+			info, err := LookupAnalyzer(name)
+			if err != nil {
+				return nil, err
+			}
+			roots = append(roots, info)
+		}
+	}
+
+	const (
+		visiting = 1
+		done     = 2
+	)
+	state := make(map[string]int)
+	var infos []*AnalyzerInfo
+	var visit func(info *AnalyzerInfo, from string) error
+	visit = func(info *AnalyzerInfo, from string) error {
+		switch state[info.Name] {
+		case visiting:
+			return fmt.Errorf("analyzer %s requires itself", info.Name)
+		case done:
+			return nil
+		}
+		if disabled[info.Name] {
+			if from == "" {
+				return nil
+			}
+			return fmt.Errorf("analyzer %s is disabled but %s requires it", info.Name, from)
+		}
+		state[info.Name] = visiting
+		for _, name := range info.Requires {
+			req, err := LookupAnalyzer(name)
+			if err != nil {
+				return fmt.Errorf("analyzer %s: %v", info.Name, err)
+			}
+			if err := visit(req, info.Name); err != nil {
+				return err
+			}
+		}
+		state[info.Name] = done
+		infos = append(infos, info)
+		return nil
+	}
+	for _, info := range roots {
+		if err := visit(info, ""); err != nil {
+			return nil, err
+		}
+	}
+
+	as := make([]Analyzer, 0, len(infos))
+	for _, info := range infos {
+		a := info.New()
+		if cfg, ok := s.Config[info.Name]; ok {
+			dec := json.NewDecoder(bytes.NewReader(cfg))
+			dec.DisallowUnknownFields()
+			if err := dec.Decode(a); err != nil {
+				return nil, fmt.Errorf("analyzer %s: invalid configuration: %v", info.Name, err)
+			}
+		}
+		as = append(as, a)
+	}
+
+	return as, nil
+}
+
+// ParseNames parses a comma separated list of analyzer names.
+func ParseNames(list string) []string {
+	var names []string
+	for _, name := range strings.Split(list, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+func init() {
+	for _, info := range []AnalyzerInfo{
+		{Name: "symbols", Doc: "resolve the symbols of the project, for the other analyzers", New: func() Analyzer { return new(symbolTable) }},
+		{Name: "hierarchy", Doc: "build the type hierarchy of the project, for the other analyzers", Requires: []string{"symbols"}, New: func() Analyzer { return new(typeHierarchy) }},
+		{Name: "loc", Doc: "lines of code and function lengths", Sections: []string{"AverageFuncLen", "MaxFuncLen", "MinFuncLen", "MedianFuncLen", "TotalLoC", "FuncLengths"}, New: func() Analyzer { return new(LoC) }},
+		{Name: "complexity", Doc: "cyclomatic complexity", Sections: []string{"Complexity"}, New: func() Analyzer { return new(Complexity) }},
+		{Name: "loc-per-lang", Doc: "lines of code per language", Sections: []string{"ProgLangs"}, New: func() Analyzer { return new(LocPerLang) }},
+		{Name: "comment-ratios", Doc: "documentation coverage", Sections: []string{"DocCoverage"}, New: func() Analyzer { return new(CommentRatios) }},
+		{Name: "comment-density", Doc: "comment density and task markers", Sections: []string{"Comments"}, New: func() Analyzer { return new(CommentDensity) }},
+		{Name: "dependencies", Doc: "package coupling metrics and import cycles", Sections: []string{"Dependencies"}, New: func() Analyzer { return new(Dependencies) }},
+		{Name: "dead-code", Doc: "unused private declarations", Requires: []string{"symbols"}, Sections: []string{"DeadCode"}, New: func() Analyzer { return new(DeadCode) }},
+		{Name: "conformance", Doc: "missing implementations and invalid overrides", Requires: []string{"hierarchy"}, Sections: []string{"Conformance"}, New: func() Analyzer { return new(Conformance) }},
+		{Name: "halstead", Doc: "Halstead complexity measures", Sections: []string{"Halstead"}, New: func() Analyzer { return new(Halstead) }},
+		{Name: "maintainability", Doc: "maintainability index", Sections: []string{"Maintainability"}, New: func() Analyzer { return &Maintainability{WithComments: true} }},
+		{Name: "cognitive-complexity", Doc: "cognitive complexity", Sections: []string{"Cognitive"}, New: func() Analyzer { return new(CognitiveComplexity) }},
+		{Name: "nesting", Doc: "nesting depth of the blocks", Sections: []string{"Nesting"}, New: func() Analyzer { return new(Nesting) }},
+		{Name: "distributions", Doc: "distributions of the function lengths, complexities and file sizes", Sections: []string{"Distributions"}, New: func() Analyzer { return new(Distributions) }},
+		{Name: "clones", Doc: "duplicated code", Sections: []string{"Clones"}, New: func() Analyzer { return new(Clones) }},
+		{Name: "smells", Doc: "code smells", Sections: []string{"Smells"}, New: func() Analyzer { return new(Smells) }},
+		{Name: "entities", Doc: "per-package, per-file and per-function breakdown", Sections: []string{"Entities"}, New: func() Analyzer { return new(Entities) }},
+	} {
+		Register(info)
+	}
+}
+
+// symbolTable computes the symbol table shared by the analyzers (see
+// Result.symbols).
+type symbolTable struct{}
+
+func (symbolTable) Analyze(p *src.Project, r *Result) error {
+	r.symbols(p)
+	return nil
+}
+
+// typeHierarchy computes the type hierarchy shared by the analyzers (see
+// Result.hierarchy).
+type typeHierarchy struct{}
+
+func (typeHierarchy) Analyze(p *src.Project, r *Result) error {
+	r.hierarchy(p)
+	return nil
+}
+
+// sharedData holds the data shared between the analyzers of a run. Each piece
+// of data is computed once, even when several analyzers ask for it
+// concurrently.
+type sharedData struct {
+	mu      sync.Mutex
+	entries map[string]*sharedEntry
+}
+
+type sharedEntry struct {
+	once  sync.Once
+	value interface{}
+}
+
+// shared returns the data shared between the analyzers under the given key,
+// computing it the first time.
+func (r *Result) shared(key string, compute func() interface{}) interface{} {
+	if r.cache == nil {
+		r.cache = &sharedData{}
+	}
+
+	r.cache.mu.Lock()
+	if r.cache.entries == nil {
+		r.cache.entries = make(map[string]*sharedEntry)
+	}
+	e, ok := r.cache.entries[key]
+	if !ok {
+		e = &sharedEntry{}
+		r.cache.entries[key] = e
+	}
+	r.cache.mu.Unlock()
+
+	e.once.Do(func() { e.value = compute() })
+	return e.value
+}
+
+// symbols returns the symbol table of the project.
+func (r *Result) symbols(p *src.Project) *resolver.Table {
+	return r.shared("symbols", func() interface{} {
+		return resolver.Resolve(p)
+	}).(*resolver.Table)
+}
+
+// hierarchy returns the type hierarchy of the project.
+func (r *Result) hierarchy(p *src.Project) *hierarchy.Hierarchy {
+	return r.shared("hierarchy", func() interface{} {
+		return hierarchy.FromTable(r.symbols(p))
+	}).(*hierarchy.Hierarchy)
+}
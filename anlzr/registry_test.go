@@ -0,0 +1,66 @@
+// Copyright 2014-2015 The DevMine Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package anlzr_test
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/DevMine/srcanlzr/anlzr"
+)
+
+func TestSelect(t *testing.T) {
+	as, err := anlzr.Select(anlzr.Selection{Enable: []string{"conformance", "loc"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var found []string
+	for _, a := range as {
+		found = append(found, reflect.TypeOf(a).Elem().Name())
+	}
+	expected := []string{"symbolTable", "typeHierarchy", "Conformance", "LoC"}
+	if !reflect.DeepEqual(found, expected) {
+		t.Errorf("Select: found %v, expected %v", found, expected)
+	}
+
+	all, err := anlzr.Select(anlzr.Selection{Disable: []string{"loc"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(all) != len(anlzr.Analyzers())-1 {
+		t.Errorf("Select: found %d analyzers, expected %d", len(all), len(anlzr.Analyzers())-1)
+	}
+
+	as, err = anlzr.Select(anlzr.Selection{
+		Enable: []string{"entities", "maintainability"},
+		Config: map[string]json.RawMessage{
+			"entities":        json.RawMessage(`{"granularity": "file"}`),
+			"maintainability": json.RawMessage(`{"Worst": 3}`),
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if e := as[0].(*anlzr.Entities); e.Granularity != anlzr.FileLevel {
+		t.Errorf("Select: found granularity %v, expected %v", e.Granularity, anlzr.FileLevel)
+	}
+	if m := as[1].(*anlzr.Maintainability); m.Worst != 3 || !m.WithComments {
+		t.Errorf("Select: found %+v, expected the default configuration with Worst set to 3", *m)
+	}
+
+	for _, sel := range []anlzr.Selection{
+		{Enable: []string{"foo"}},
+		{Disable: []string{"foo"}},
+		{Enable: []string{"dead-code"}, Disable: []string{"symbols"}},
+		{Config: map[string]json.RawMessage{"foo": json.RawMessage(`{}`)}},
+		{Config: map[string]json.RawMessage{"loc": json.RawMessage(`{"foo": 1}`)}},
+		{Config: map[string]json.RawMessage{"entities": json.RawMessage(`{"granularity": "foo"}`)}},
+	} {
+		if _, err := anlzr.Select(sel); err == nil {
+			t.Errorf("Select %+v: found no error, expected an error", sel)
+		}
+	}
+}
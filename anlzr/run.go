@@ -0,0 +1,311 @@
+// Copyright 2014-2015 The DevMine Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package anlzr
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/DevMine/srcanlzr/src"
+)
+
+// An AnalyzerRun reports the execution of an analyzer.
+type AnalyzerRun struct {
+	Name     string // registered name of the analyzer, or its type
+	Duration time.Duration
+	Err      error // nil if the analyzer succeeded
+}
+
+// AnalyzerErrors is the error returned by RunAnalyzers when some analyzers
+// fail. It holds the runs of the analyzers which failed.
+type AnalyzerErrors []AnalyzerRun
+
+func (e AnalyzerErrors) Error() string {
+	msgs := make([]string, len(e))
+	for i, run := range e {
+		msgs[i] = run.Name + ": " + run.Err.Error()
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// Errors returns the runs which failed as an AnalyzerErrors, or nil if every
+// analyzer succeeded.
+func Errors(runs []AnalyzerRun) error {
+	var errs AnalyzerErrors
+	for _, run := range runs {
+		if run.Err != nil {
+			errs = append(errs, run)
+		}
+	}
+	if errs == nil {
+		return nil
+	}
+	return errs
+}
+
+// RunAnalyzers runs several analyzers on a project (see Run). When some
+// analyzers fail, the error is an AnalyzerErrors and the result holds the
+// metrics of the analyzers which succeeded.
+func RunAnalyzers(p *src.Project, a ...Analyzer) (*Result, error) {
+	r, runs := Run(context.Background(), p, a...)
+	return r, Errors(runs)
+}
+
+// Run runs several analyzers concurrently on a project, and reports the
+// outcome and the duration of each of them, in the order of the analyzers.
+//
+// The analyzers run in waves: an analyzer starts once the analyzers it
+// requires (see AnalyzerInfo.Requires) which are part of a have completed,
+// and it fails without running if one of them failed. The analyzers only read
+// the project. Each analyzer writes to a result of its own, from which the
+// sections it declares (see AnalyzerInfo.Sections) are copied into the
+// returned result: an analyzer fails without running if it declares a
+// section already declared by another analyzer of a. The analyzers which are
+// not registered have no section, and can only add metrics (see AddMetric).
+// The metrics of the analyzers are gathered, and an analyzer fails if it adds
+// a metric which another one already added. The sections and metrics of the
+// analyzers which failed are left out.
+//
+// The analyzers which have not completed when ctx is done fail with the
+// error of ctx. An analyzer cannot be interrupted though: the ones already
+// running keep running in the background, and their results are discarded.
+func Run(ctx context.Context, p *src.Project, a ...Analyzer) (*Result, []AnalyzerRun) {
+	infos := make([]*AnalyzerInfo, len(a))
+	runs := make([]AnalyzerRun, len(a))
+	owners := make(map[string]string)
+	for i, an := range a {
+		infos[i] = analyzerInfo(an)
+		runs[i].Name = analyzerName(an)
+		if infos[i] == nil {
+			continue
+		}
+		for _, section := range infos[i].Sections {
+			if owner, dup := owners[section]; dup {
+				runs[i].Err = fmt.Errorf("section %s is computed by %s as well", section, owner)
+				break
+			}
+		}
+		if runs[i].Err == nil {
+			for _, section := range infos[i].Sections {
+				owners[section] = infos[i].Name
+			}
+		}
+	}
+
+	cache := &sharedData{}
+	results := make([]*Result, len(a))
+	for _, wave := range waves(infos) {
+		var pending []int
+		for _, i := range wave {
+			if runs[i].Err != nil {
+				continue
+			}
+			if name := failedRequirement(infos, runs, i); name != "" {
+				runs[i].Err = fmt.Errorf("required analyzer %s failed", name)
+				continue
+			}
+			pending = append(pending, i)
+		}
+		runAll(ctx, p, a, pending, cache, runs, results)
+	}
+
+	r := newResult()
+	r.cache = cache
+	for i, res := range results {
+		if runs[i].Err != nil || res == nil {
+			continue
+		}
+		var sections []string
+		if infos[i] != nil {
+			sections = infos[i].Sections
+		}
+		if err := merge(r, res, sections); err != nil {
+			runs[i].Err = err
+		}
+	}
+
+	return r, runs
+}
+
+// runAll runs concurrently the analyzers of a with the given indices, and
+// stores their outcomes and results into runs and results.
+func runAll(ctx context.Context, p *src.Project, a []Analyzer, indices []int, cache *sharedData, runs []AnalyzerRun, results []*Result) {
+	type outcome struct {
+		i   int
+		run AnalyzerRun
+		res *Result
+	}
+
+	if err := ctx.Err(); err != nil {
+		for _, i := range indices {
+			runs[i].Err = err
+		}
+		return
+	}
+
+	out := make(chan outcome, len(indices))
+	sem := make(chan struct{}, runtime.GOMAXPROCS(0))
+	for _, i := range indices {
+		go func(o outcome, an Analyzer) {
+			select {
+			case sem <- struct{}{}:
+				defer func() { <-sem }()
+			case <-ctx.Done():
+				o.run.Err = ctx.Err()
+				out <- o
+				return
+			}
+			o.res = newResult()
+			o.res.cache = cache
+			o.run = runAnalyzer(an, p, o.res, o.run.Name)
+			out <- o
+		}(outcome{i: i, run: runs[i]}, a[i])
+	}
+
+	finished := make(map[int]bool, len(indices))
+	for pending := len(indices); pending > 0; {
+		select {
+		case o := <-out:
+			runs[o.i], results[o.i], finished[o.i] = o.run, o.res, true
+			pending--
+		case <-ctx.Done():
+			for _, i := range indices {
+				if !finished[i] {
+					runs[i].Err = ctx.Err()
+				}
+			}
+			pending = 0
+		}
+	}
+}
+
+// waves groups the indices of the analyzers described by infos by level: the
+// analyzers of a wave only require analyzers of the previous waves. The infos
+// of the analyzers which are not registered are nil.
+func waves(infos []*AnalyzerInfo) [][]int {
+	levels := make([]int, len(infos))
+	visiting := make([]bool, len(infos))
+	var level func(i int) int
+	level = func(i int) int {
+		if levels[i] > 0 || visiting[i] {
+			return levels[i]
+		}
+		visiting[i] = true
+		l := 1
+		for _, j := range requirements(infos, i) {
+			if lj := level(j) + 1; lj > l {
+				l = lj
+			}
+		}
+		visiting[i] = false
+		levels[i] = l
+		return l
+	}
+
+	var ws [][]int
+	for i := range infos {
+		l := level(i)
+		for len(ws) < l {
+			ws = append(ws, nil)
+		}
+		ws[l-1] = append(ws[l-1], i)
+	}
+	return ws
+}
+
+// requirements returns the indices of the analyzers required by the analyzer
+// at index i.
+func requirements(infos []*AnalyzerInfo, i int) []int {
+	if infos[i] == nil {
+		return nil
+	}
+	var reqs []int
+	for _, name := range infos[i].Requires {
+		for j, info := range infos {
+			if info != nil && info.Name == name {
+				reqs = append(reqs, j)
+			}
+		}
+	}
+	return reqs
+}
+
+// failedRequirement returns the name of an analyzer required by the analyzer
+// at index i which failed, or an empty string.
+func failedRequirement(infos []*AnalyzerInfo, runs []AnalyzerRun, i int) string {
+	for _, j := range requirements(infos, i) {
+		if runs[j].Err != nil {
+			return infos[j].Name
+		}
+	}
+	return ""
+}
+
+// merge copies into r the given sections and the metrics of res. Nothing is
+// copied if a metric of res is already in r.
+func merge(r, res *Result, sections []string) error {
+	for _, m := range res.Metrics {
+		if r.Metric(m.Key, m.Scope) != nil {
+			return fmt.Errorf("duplicate metric %s (scope %q)", m.Key, m.Scope)
+		}
+	}
+	r.Metrics = append(r.Metrics, res.Metrics...)
+
+	dst, from := reflect.ValueOf(r).Elem(), reflect.ValueOf(res).Elem()
+	for _, name := range sections {
+		dst.FieldByName(name).Set(from.FieldByName(name))
+	}
+	return nil
+}
+
+// runAnalyzer runs an analyzer and reports its outcome. A panic of the
+// analyzer is reported as an error.
+func runAnalyzer(an Analyzer, p *src.Project, r *Result, name string) (run AnalyzerRun) {
+	run.Name = name
+	start := time.Now()
+	defer func() {
+		run.Duration = time.Since(start)
+		if v := recover(); v != nil {
+			run.Err = fmt.Errorf("panic: %v", v)
+		}
+	}()
+	run.Err = an.Analyze(p, r)
+	return run
+}
+
+// analyzerInfo returns the registered description of an analyzer, or nil if
+// it is not registered.
+func analyzerInfo(an Analyzer) *AnalyzerInfo {
+	typ := analyzerType(an)
+	for _, info := range ordered {
+		if reflect.TypeOf(info.New()).Elem() == typ {
+			return info
+		}
+	}
+	return nil
+}
+
+// analyzerName returns the registered name of an analyzer, or the name of its
+// type if it is not registered.
+func analyzerName(an Analyzer) string {
+	if info := analyzerInfo(an); info != nil {
+		return info.Name
+	}
+	return analyzerType(an).String()
+}
+
+// analyzerType returns the type of an analyzer, dereferenced if it is a
+// pointer.
+func analyzerType(an Analyzer) reflect.Type {
+	typ := reflect.TypeOf(an)
+	if typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+	return typ
+}
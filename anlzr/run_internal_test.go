@@ -0,0 +1,78 @@
+// Copyright 2014-2015 The DevMine Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package anlzr
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/DevMine/srcanlzr/src"
+)
+
+// recorder records the names of the analyzers in the order they complete.
+type recorder struct {
+	mu    sync.Mutex
+	names []string
+}
+
+func (rec *recorder) add(name string) {
+	rec.mu.Lock()
+	rec.names = append(rec.names, name)
+	rec.mu.Unlock()
+}
+
+type first struct{ rec *recorder }
+
+func (a first) Analyze(p *src.Project, r *Result) error {
+	a.rec.add("first")
+	return nil
+}
+
+type second struct{ rec *recorder }
+
+func (a second) Analyze(p *src.Project, r *Result) error {
+	a.rec.add("second")
+	return nil
+}
+
+type broken struct{}
+
+func (broken) Analyze(p *src.Project, r *Result) error {
+	return errors.New("failure")
+}
+
+type dependent struct{ rec *recorder }
+
+func (a dependent) Analyze(p *src.Project, r *Result) error {
+	a.rec.add("dependent")
+	return nil
+}
+
+func TestRunWaves(t *testing.T) {
+	defer func(o []*AnalyzerInfo) { ordered = o }(ordered)
+	ordered = append(ordered[:len(ordered):len(ordered)],
+		&AnalyzerInfo{Name: "first", New: func() Analyzer { return new(first) }},
+		&AnalyzerInfo{Name: "second", Requires: []string{"first"}, New: func() Analyzer { return new(second) }},
+		&AnalyzerInfo{Name: "broken", New: func() Analyzer { return new(broken) }},
+		&AnalyzerInfo{Name: "dependent", Requires: []string{"second", "broken"}, New: func() Analyzer { return new(dependent) }},
+	)
+
+	for i := 0; i < 20; i++ {
+		rec := &recorder{}
+		_, runs := Run(context.Background(), &src.Project{}, dependent{rec}, second{rec}, first{rec}, broken{})
+		if expected := []string{"first", "second"}; !reflect.DeepEqual(rec.names, expected) {
+			t.Fatalf("Run: found analyzers %v, expected %v", rec.names, expected)
+		}
+		if runs[0].Err == nil || runs[0].Err.Error() != "required analyzer broken failed" {
+			t.Fatalf("Run: found error %v, expected required analyzer broken failed", runs[0].Err)
+		}
+		if runs[1].Err != nil || runs[2].Err != nil {
+			t.Fatalf("Run: found errors %v and %v, expected none", runs[1].Err, runs[2].Err)
+		}
+	}
+}
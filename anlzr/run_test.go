@@ -0,0 +1,113 @@
+// Copyright 2014-2015 The DevMine Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package anlzr_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/DevMine/srcanlzr/anlzr"
+	"github.com/DevMine/srcanlzr/src"
+	"github.com/DevMine/srcanlzr/src/ast"
+)
+
+type failing struct{}
+
+func (failing) Analyze(p *src.Project, r *anlzr.Result) error {
+	r.TotalLoC = 42
+	return errors.New("failure")
+}
+
+type unregistered struct{}
+
+func (unregistered) Analyze(p *src.Project, r *anlzr.Result) error {
+	r.TotalLoC = 42
+	return nil
+}
+
+type panicking struct{}
+
+func (panicking) Analyze(p *src.Project, r *anlzr.Result) error {
+	panic("oops")
+}
+
+func TestRun(t *testing.T) {
+	p := &src.Project{
+		LoC: 10,
+		Packages: []*src.Package{
+			&src.Package{
+				Path:     "x",
+				SrcFiles: []*src.SrcFile{&src.SrcFile{Path: "x/x.go", Lang: &src.Language{Lang: src.Go}, Funcs: []*ast.FuncDecl{&ast.FuncDecl{Name: "f", LoC: 3}}}},
+			},
+		},
+	}
+	as, err := anlzr.Select(anlzr.Selection{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r, runs := anlzr.Run(context.Background(), p, append([]anlzr.Analyzer{failing{}, panicking{}}, as...)...)
+	if len(runs) != len(as)+2 {
+		t.Fatalf("Run: found %d runs, expected %d", len(runs), len(as)+2)
+	}
+	for i, expected := range []string{"anlzr_test.failing", "anlzr_test.panicking", "symbols"} {
+		if runs[i].Name != expected {
+			t.Errorf("Run: found analyzer %s, expected %s", runs[i].Name, expected)
+		}
+	}
+	if runs[0].Err == nil || runs[1].Err == nil {
+		t.Errorf("Run: found errors %v and %v, expected errors", runs[0].Err, runs[1].Err)
+	}
+	for _, run := range runs[2:] {
+		if run.Err != nil {
+			t.Errorf("Run: %s: %v", run.Name, run.Err)
+		}
+	}
+	if r.TotalLoC != 10 || r.MaxFuncLen != 3 || len(r.Complexity.Files) != 1 {
+		t.Errorf("Run: found %d total LoC, %d max function length and %d files, expected 10, 3 and 1",
+			r.TotalLoC, r.MaxFuncLen, len(r.Complexity.Files))
+	}
+
+	errs, ok := anlzr.Errors(runs).(anlzr.AnalyzerErrors)
+	if !ok || len(errs) != 2 {
+		t.Errorf("Errors: found %v, expected 2 errors", errs)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	r, runs = anlzr.Run(ctx, p, anlzr.LoC{})
+	if runs[0].Err != context.Canceled || r.TotalLoC != -1 {
+		t.Errorf("Run: found error %v and %d total LoC, expected %v and -1", runs[0].Err, r.TotalLoC, context.Canceled)
+	}
+}
+
+func TestRunSections(t *testing.T) {
+	p := &src.Project{
+		LoC: 10,
+		Packages: []*src.Package{
+			&src.Package{
+				Path:     "x",
+				SrcFiles: []*src.SrcFile{&src.SrcFile{Path: "x/x.go", Lang: &src.Language{Lang: src.Go}, Funcs: []*ast.FuncDecl{&ast.FuncDecl{Name: "f", LoC: 3}}}},
+			},
+		},
+	}
+
+	r, runs := anlzr.Run(context.Background(), p, anlzr.LoC{}, unregistered{}, anlzr.Complexity{}, anlzr.Complexity{})
+	for _, run := range runs[:3] {
+		if run.Err != nil {
+			t.Errorf("Run: %s: %v", run.Name, run.Err)
+		}
+	}
+	if runs[3].Err == nil {
+		t.Error("Run: found no error for a section computed twice, expected an error")
+	}
+	if r.TotalLoC != 10 {
+		t.Errorf("Run: found %d total LoC, expected 10", r.TotalLoC)
+	}
+	if len(r.Complexity.Files) != 1 {
+		t.Errorf("Run: found %d files, expected 1", len(r.Complexity.Files))
+	}
+}
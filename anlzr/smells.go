@@ -0,0 +1,472 @@
+// Copyright 2014-2015 The DevMine Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package anlzr
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/DevMine/srcanlzr/src"
+	"github.com/DevMine/srcanlzr/src/ast"
+)
+
+// Kinds of code smells
+const (
+	SmellLongMethod         = "long-method"
+	SmellLongParamList      = "long-parameter-list"
+	SmellLargeClass         = "large-class"
+	SmellDataClass          = "data-class"
+	SmellTypeSwitch         = "type-switch"
+	SmellNestedConditionals = "nested-conditionals"
+	SmellMessageChain       = "message-chain"
+	SmellEmptyCatch         = "empty-catch"
+)
+
+// SmellKinds lists the kinds of code smells detected by Smells.
+var SmellKinds = []string{
+	SmellLongMethod,
+	SmellLongParamList,
+	SmellLargeClass,
+	SmellDataClass,
+	SmellTypeSwitch,
+	SmellNestedConditionals,
+	SmellMessageChain,
+	SmellEmptyCatch,
+}
+
+// Severities of the code smells
+const (
+	SeverityInfo  = "info"
+	SeverityMinor = "minor"
+	SeverityMajor = "major"
+)
+
+// DefaultSmellSeverities holds the severity of each kind of code smell.
+var DefaultSmellSeverities = map[string]string{
+	SmellLongMethod:         SeverityMinor,
+	SmellLongParamList:      SeverityMinor,
+	SmellLargeClass:         SeverityMajor,
+	SmellDataClass:          SeverityInfo,
+	SmellTypeSwitch:         SeverityMinor,
+	SmellNestedConditionals: SeverityMinor,
+	SmellMessageChain:       SeverityInfo,
+	SmellEmptyCatch:         SeverityMajor,
+}
+
+// Default thresholds of Smells
+const (
+	DefaultMaxFuncLoC     = 50
+	DefaultMaxParams      = 5
+	DefaultMaxAttrs       = 15
+	DefaultMaxMethods     = 20
+	DefaultMaxIfDepth     = 3
+	DefaultMaxChainLength = 3
+)
+
+// Smells detects code smells:
+//   - long method: a function with more lines of code than MaxFuncLoC;
+//   - long parameter list: a function with more parameters than MaxParams;
+//   - large class: a class with more attributes than MaxAttrs or more
+//     methods than MaxMethods;
+//   - data class: a class with attributes whose methods are all accessors,
+//     i.e. methods named get..., set... or is... having at most one
+//     statement;
+//   - type switch: a switch on the type or the kind of a value, i.e. on a
+//     call to a function such as typeof or getClass, or on a name ending with
+//     "type" or "kind";
+//   - nested conditionals: an if or switch statement nested in more than
+//     MaxIfDepth conditionals, else-if chains not counting as nesting;
+//   - message chain: a chain of more than MaxChainLength calls and
+//     attribute accesses (e.g. a.b().c().d() or a.b.c.d);
+//   - empty catch: a catch clause without any statement.
+//
+// The chains are followed through the receivers of the attribute references.
+// The receiver of a call is not an expression of the AST though: it is read
+// from the namespace of the called function, in which the parsers write it
+// (e.g. "a.b(x).c" or "&{a b}"), leaving the arguments out.
+//
+// A zero threshold stands for the corresponding default one.
+type Smells struct {
+	MaxFuncLoC     int64
+	MaxParams      int64
+	MaxAttrs       int64
+	MaxMethods     int64
+	MaxIfDepth     int64
+	MaxChainLength int64
+
+	// Disabled lists the kinds of smells not to detect.
+	Disabled []string
+
+	// Severities overrides DefaultSmellSeverities for the given kinds.
+	Severities map[string]string
+}
+
+func (s Smells) Analyze(p *src.Project, r *Result) error {
+	d, err := s.detector()
+	if err != nil {
+		return err
+	}
+
+	for _, pkg := range p.Packages {
+		for _, sf := range pkg.SrcFiles {
+			d.file = sf.Path
+			for _, f := range fileFuncs(sf) {
+				d.function(f)
+			}
+			for _, c := range fileClasses(sf) {
+				d.class(c.name, c.decl)
+			}
+		}
+	}
+
+	m := SmellMetrics{Counts: []SmellCount{}, Smells: d.smells}
+	for _, kind := range SmellKinds {
+		if d.enabled[kind] {
+			m.Counts = append(m.Counts, SmellCount{Kind: kind, Count: d.counts[kind]})
+		}
+	}
+	m.Total = int64(len(d.smells))
+
+	r.Smells = m
+
+	return nil
+}
+
+// smellDetector collects the smells of a project.
+type smellDetector struct {
+	Smells
+	enabled    map[string]bool
+	severities map[string]string
+	counts     map[string]int64
+	smells     []Smell
+	file       string // path of the current source file
+}
+
+// detector checks the configuration and returns a detector using it, with
+// the default thresholds in place of the zero ones.
+func (s Smells) detector() (*smellDetector, error) {
+	d := &smellDetector{
+		Smells:     s,
+		enabled:    make(map[string]bool),
+		severities: make(map[string]string),
+		counts:     make(map[string]int64),
+		smells:     []Smell{},
+	}
+	for _, kind := range SmellKinds {
+		d.enabled[kind] = true
+		d.severities[kind] = DefaultSmellSeverities[kind]
+	}
+	for _, kind := range s.Disabled {
+		if !d.enabled[kind] {
+			return nil, fmt.Errorf("unknown smell %q", kind)
+		}
+		d.enabled[kind] = false
+	}
+	for kind, sev := range s.Severities {
+		if _, ok := d.severities[kind]; !ok {
+			return nil, fmt.Errorf("unknown smell %q", kind)
+		}
+		if sev != SeverityInfo && sev != SeverityMinor && sev != SeverityMajor {
+			return nil, fmt.Errorf("smell %s: unknown severity %q", kind, sev)
+		}
+		d.severities[kind] = sev
+	}
+
+	for _, t := range []struct {
+		v   *int64
+		def int64
+	}{
+		{&d.MaxFuncLoC, DefaultMaxFuncLoC},
+		{&d.MaxParams, DefaultMaxParams},
+		{&d.MaxAttrs, DefaultMaxAttrs},
+		{&d.MaxMethods, DefaultMaxMethods},
+		{&d.MaxIfDepth, DefaultMaxIfDepth},
+		{&d.MaxChainLength, DefaultMaxChainLength},
+	} {
+		if *t.v <= 0 {
+			*t.v = t.def
+		}
+	}
+	return d, nil
+}
+
+func (d *smellDetector) report(kind, entity string, line int64, format string, args ...interface{}) {
+	if !d.enabled[kind] {
+		return
+	}
+	d.counts[kind]++
+	d.smells = append(d.smells, Smell{
+		Kind:        kind,
+		Severity:    d.severities[kind],
+		File:        d.file,
+		Entity:      entity,
+		Line:        line,
+		Explanation: fmt.Sprintf(format, args...),
+	})
+}
+
+func (d *smellDetector) function(f function) {
+	var line int64
+	if len(f.Body) > 0 {
+		line = stmtLine(f.Body[0])
+	}
+	if f.LoC > d.MaxFuncLoC {
+		d.report(SmellLongMethod, f.Name, line,
+			"%d lines of code, more than %d: split the function into smaller ones", f.LoC, d.MaxFuncLoC)
+	}
+	if n := funcParams(f.Decl); n > d.MaxParams {
+		d.report(SmellLongParamList, f.Name, line,
+			"%d parameters, more than %d: group the related ones into a type", n, d.MaxParams)
+	}
+
+	// nodes being visited, and whether each of them is a nesting conditional
+	var nodes []interface{}
+	var nesting []bool
+	var depth int64
+	visit := func(node interface{}) bool {
+		if node == nil {
+			if nesting[len(nesting)-1] {
+				depth--
+			}
+			nodes, nesting = nodes[:len(nodes)-1], nesting[:len(nesting)-1]
+			return false
+		}
+
+		cond := false
+		switch n := node.(type) {
+		case *ast.IfStmt:
+			cond = !isElseIf(nodes, n)
+			if cond && depth == d.MaxIfDepth {
+				d.report(SmellNestedConditionals, f.Name, n.Line,
+					"conditional nested in %d others: use guard clauses or extract the inner code", depth)
+			}
+		case *ast.SwitchStmt:
+			cond = true
+			if depth == d.MaxIfDepth {
+				d.report(SmellNestedConditionals, f.Name, 0,
+					"conditional nested in %d others: use guard clauses or extract the inner code", depth)
+			}
+			if isTypeTest(n.Cond) {
+				d.report(SmellTypeSwitch, f.Name, 0,
+					"switch on the type of a value: use polymorphism instead")
+			}
+		case *ast.CatchClause:
+			if len(n.Body) == 0 {
+				d.report(SmellEmptyCatch, f.Name, 0,
+					"empty catch clause: handle the exception or at least log it")
+			}
+		case *ast.CallExpr:
+			if l := chainLength(n); l > d.MaxChainLength && !inChain(nodes, n) {
+				d.report(SmellMessageChain, f.Name, n.Line,
+					"chain of %d calls and attribute accesses, more than %d: hide the navigation behind a method",
+					l, d.MaxChainLength)
+			}
+		case *ast.AttrRef:
+			if l := chainLength(n); l > d.MaxChainLength && !inChain(nodes, n) {
+				d.report(SmellMessageChain, f.Name, 0,
+					"chain of %d calls and attribute accesses, more than %d: hide the navigation behind a method",
+					l, d.MaxChainLength)
+			}
+		}
+		if cond {
+			depth++
+		}
+		nodes, nesting = append(nodes, node), append(nesting, cond)
+		return true
+	}
+	for _, stmt := range f.Body {
+		ast.Inspect(stmt, visit)
+	}
+}
+
+// isElseIf tells whether an if statement is the else-if of its parent node.
+func isElseIf(parents []interface{}, s *ast.IfStmt) bool {
+	if len(parents) == 0 {
+		return false
+	}
+	parent, ok := parents[len(parents)-1].(*ast.IfStmt)
+	return ok && len(parent.Else) == 1 && parent.Else[0] == s
+}
+
+// typeFuncs lists functions returning the type of a value, in various
+// languages.
+var typeFuncs = map[string]bool{
+	"type":     true,
+	"typeof":   true,
+	"getClass": true,
+	"class":    true,
+}
+
+// isTypeTest tells whether an expression looks like the type or the kind of
+// a value.
+func isTypeTest(x ast.Expr) bool {
+	var name string
+	switch x := x.(type) {
+	case *ast.CallExpr:
+		if x.Fun == nil {
+			return false
+		}
+		if typeFuncs[x.Fun.FuncName] {
+			return true
+		}
+		name = x.Fun.FuncName
+	case *ast.Ident:
+		name = x.Name
+	case *ast.AttrRef:
+		if x.Name != nil {
+			name = x.Name.Name
+		}
+	}
+	name = strings.ToLower(name)
+	return strings.HasSuffix(name, "type") || strings.HasSuffix(name, "kind")
+}
+
+// inChain tells whether an expression is the receiver of its parent node, in
+// which case the chain is reported for the parent.
+func inChain(parents []interface{}, x ast.Expr) bool {
+	if len(parents) == 0 {
+		return false
+	}
+	parent, ok := parents[len(parents)-1].(*ast.AttrRef)
+	return ok && parent.X == x
+}
+
+// chainLength returns the number of calls and attribute accesses chained in
+// an expression.
+func chainLength(x ast.Expr) int64 {
+	switch x := x.(type) {
+	case *ast.AttrRef:
+		return 1 + chainLength(x.X)
+	case *ast.CallExpr:
+		if x.Fun == nil {
+			return 1
+		}
+		return 1 + namespaceLength(x.Fun.Namespace)
+	case *ast.IndexExpr:
+		return chainLength(x.X)
+	case *ast.Ident:
+		return 1
+	}
+	return 0
+}
+
+// namespaceLength returns the number of calls and attribute accesses of a
+// receiver written in a namespace. The arguments of the calls are left out,
+// and only the identifiers count, since some parsers also write addresses
+// and offsets (e.g. "&{0xc20828fbc0 9641}").
+func namespaceLength(namespace string) int64 {
+	words := strings.FieldsFunc(stripArgs(namespace), func(r rune) bool {
+		return strings.ContainsRune(".&{}()[]* ", r)
+	})
+	var n int64
+	for _, w := range words {
+		if r := rune(w[0]); r == '_' || unicode.IsLetter(r) {
+			n++
+		}
+	}
+	return n
+}
+
+// stripArgs removes the arguments of the calls written in a namespace (e.g.
+// "a.b(x, y).c()" gives "a.b().c()").
+func stripArgs(namespace string) string {
+	var buf []byte
+	depth := 0
+	for i := 0; i < len(namespace); i++ {
+		switch c := namespace[i]; {
+		case c == '(':
+			if depth == 0 {
+				buf = append(buf, c)
+			}
+			depth++
+		case c == ')' && depth > 0:
+			depth--
+			if depth == 0 {
+				buf = append(buf, c)
+			}
+		case depth == 0:
+			buf = append(buf, c)
+		}
+	}
+	return string(buf)
+}
+
+func (d *smellDetector) class(name string, c *ast.ClassDecl) {
+	if n := int64(len(c.Attrs)); n > d.MaxAttrs {
+		d.report(SmellLargeClass, name, 0,
+			"%d attributes, more than %d: split the class", n, d.MaxAttrs)
+	}
+	if n := int64(len(c.Methods)); n > d.MaxMethods {
+		d.report(SmellLargeClass, name, 0,
+			"%d methods, more than %d: split the class", n, d.MaxMethods)
+	}
+
+	if len(c.Attrs) == 0 || len(c.Methods) == 0 {
+		return
+	}
+	for _, m := range c.Methods {
+		if !isAccessor(m) {
+			return
+		}
+	}
+	d.report(SmellDataClass, name, 0,
+		"only attributes and accessors: move the behavior using the data into the class")
+}
+
+// isAccessor tells whether a method looks like a getter or a setter.
+func isAccessor(m *ast.MethodDecl) bool {
+	if len(m.Body) > 1 {
+		return false
+	}
+	for _, prefix := range []string{"get", "set", "is"} {
+		if len(m.Name) < len(prefix) || strings.ToLower(m.Name[:len(prefix)]) != prefix {
+			continue
+		}
+		rest := m.Name[len(prefix):]
+		if rest == "" || rest[0] == '_' || unicode.IsUpper(rune(rest[0])) {
+			return true
+		}
+	}
+	return false
+}
+
+// A namedClass is a class along with its name, prefixed by the names of its
+// enclosing types.
+type namedClass struct {
+	name string
+	decl *ast.ClassDecl
+}
+
+// fileClasses returns the classes declared in a source file, including the
+// nested classes and the classes of the traits.
+func fileClasses(sf *src.SrcFile) []namedClass {
+	var cs []namedClass
+	var class func(prefix string, c *ast.ClassDecl)
+	class = func(prefix string, c *ast.ClassDecl) {
+		cs = append(cs, namedClass{name: prefix + c.Name, decl: c})
+		for _, nested := range c.NestedClasses {
+			class(prefix+c.Name+".", nested)
+		}
+	}
+	var trait func(prefix string, t *ast.Trait)
+	trait = func(prefix string, t *ast.Trait) {
+		prefix += t.Name + "."
+		for _, c := range t.Classes {
+			class(prefix, c)
+		}
+		for _, nested := range t.Traits {
+			trait(prefix, nested)
+		}
+	}
+
+	for _, c := range sf.Classes {
+		class("", c)
+	}
+	for _, t := range sf.Traits {
+		trait("", t)
+	}
+	return cs
+}
@@ -0,0 +1,126 @@
+// Copyright 2014-2015 The DevMine Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package anlzr_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/DevMine/srcanlzr/anlzr"
+	"github.com/DevMine/srcanlzr/src"
+	"github.com/DevMine/srcanlzr/src/ast"
+	"github.com/DevMine/srcanlzr/src/token"
+)
+
+func TestSmells(t *testing.T) {
+	ifStmt := func(line int64, body ...ast.Stmt) *ast.IfStmt {
+		return &ast.IfStmt{StmtName: token.IfStmtName, Line: line, Cond: ident("c"), Body: body}
+	}
+	attrRef := func(x ast.Expr, name string) *ast.AttrRef {
+		return &ast.AttrRef{ExprName: token.AttrRefName, X: x, Name: ident(name)}
+	}
+	elseIf := ifStmt(2, ifStmt(3, ifStmt(4)))
+	nested := ifStmt(1, ifStmt(9))
+	nested.Else = []ast.Stmt{elseIf}
+
+	f := &ast.FuncDecl{
+		Name: "f",
+		Type: params(3),
+		LoC:  12,
+		Body: []ast.Stmt{
+			nested,
+			&ast.SwitchStmt{StmtName: token.SwitchStmtName, Cond: &ast.AttrRef{ExprName: token.AttrRefName, Name: ident("nodeType")}},
+			&ast.TryStmt{StmtName: token.TryStmtName, CatchClauses: []*ast.CatchClause{&ast.CatchClause{}}},
+			callStmt("a.b().c", "d"),
+			callStmt("&{a b}", "c"),
+			callStmt("&{0xc20828fbc0 9641 [0xc20828fbe0] 0 9649}", "c"),
+			callStmt("a(x, y, z).b", "c"),
+			&ast.ExprStmt{StmtName: token.ExprStmtName, X: attrRef(attrRef(attrRef(ident("a"), "b"), "c"), "d")},
+			&ast.ExprStmt{StmtName: token.ExprStmtName, X: attrRef(attrRef(ident("a"), "b"), "c")},
+		},
+	}
+	getter := func(name string) *ast.MethodDecl {
+		return &ast.MethodDecl{FuncDecl: ast.FuncDecl{Name: name, Body: []ast.Stmt{&ast.ReturnStmt{StmtName: token.ReturnStmtName}}}}
+	}
+	attrs := []*ast.Attr{&ast.Attr{}, &ast.Attr{}, &ast.Attr{}}
+
+	p := &src.Project{
+		Packages: []*src.Package{
+			&src.Package{
+				SrcFiles: []*src.SrcFile{
+					&src.SrcFile{
+						Path:  "x",
+						Funcs: []*ast.FuncDecl{f},
+						Classes: []*ast.ClassDecl{
+							&ast.ClassDecl{Name: "Point", Attrs: attrs[:2], Methods: []*ast.MethodDecl{getter("getX"), getter("set_y"), getter("isZero")}},
+							&ast.ClassDecl{Name: "Shape", Attrs: attrs, Methods: []*ast.MethodDecl{getter("getX"), getter("settle")}},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	res, err := anlzr.RunAnalyzers(p, anlzr.Smells{
+		MaxFuncLoC: 10,
+		MaxParams:  2,
+		MaxAttrs:   2,
+		MaxIfDepth: 1,
+		Disabled:   []string{anlzr.SmellMessageChain},
+		Severities: map[string]string{anlzr.SmellDataClass: anlzr.SeverityMajor},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	type smell struct {
+		kind, entity string
+		line         int64
+	}
+	var found []smell
+	for _, s := range res.Smells.Smells {
+		found = append(found, smell{s.Kind, s.Entity, s.Line})
+	}
+	// the else-if chain counts as one level: only its innermost if is nested
+	expected := []smell{
+		{anlzr.SmellLongMethod, "f", 1},
+		{anlzr.SmellLongParamList, "f", 1},
+		{anlzr.SmellNestedConditionals, "f", 9},
+		{anlzr.SmellNestedConditionals, "f", 3},
+		{anlzr.SmellTypeSwitch, "f", 0},
+		{anlzr.SmellEmptyCatch, "f", 0},
+		{anlzr.SmellDataClass, "Point", 0},
+		{anlzr.SmellLargeClass, "Shape", 0},
+	}
+	if !reflect.DeepEqual(found, expected) {
+		t.Errorf("Smells: found %v, expected %v", found, expected)
+	}
+	if s := res.Smells.Smells[6]; s.Severity != anlzr.SeverityMajor {
+		t.Errorf("Smells: found severity %s for a data class, expected %s", s.Severity, anlzr.SeverityMajor)
+	}
+	if len(res.Smells.Counts) != len(anlzr.SmellKinds)-1 || res.Smells.Total != 8 {
+		t.Errorf("Smells: found %d counts and %d smells, expected %d and 8", len(res.Smells.Counts), res.Smells.Total, len(anlzr.SmellKinds)-1)
+	}
+
+	res, err = anlzr.RunAnalyzers(p, anlzr.Smells{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	chains := 0
+	for _, s := range res.Smells.Smells {
+		if s.Kind == anlzr.SmellMessageChain {
+			chains++
+		}
+	}
+	if chains != 2 {
+		t.Errorf("Smells: found %d message chains, expected 2", chains)
+	}
+
+	for _, s := range []anlzr.Smells{{Disabled: []string{"foo"}}, {Severities: map[string]string{anlzr.SmellEmptyCatch: "fatal"}}} {
+		if _, err := anlzr.RunAnalyzers(p, s); err == nil {
+			t.Errorf("Smells %+v: found no error, expected an error", s)
+		}
+	}
+}
@@ -0,0 +1,205 @@
+// Copyright 2014-2015 The DevMine Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package anlzr
+
+import (
+	"math"
+	"sort"
+)
+
+// Describe summarizes a set of values. The weights, if not nil, must have the
+// same length as the values and are used for the weighted mean and median.
+// The bounds, in increasing order, define the buckets of the histogram (see
+// HistogramBucket).
+func Describe(values, weights, bounds []float64) Distribution {
+	d := Distribution{Count: int64(len(values)), Histogram: []HistogramBucket{}}
+	if len(values) == 0 {
+		return d
+	}
+
+	sorted := make([]float64, len(values))
+	copy(sorted, values)
+	sort.Float64s(sorted)
+
+	d.Min = float32(sorted[0])
+	d.Max = float32(sorted[len(sorted)-1])
+	d.Mean = float32(Mean(sorted))
+	d.Median = float32(Median(sorted))
+	d.StdDev = float32(StdDev(sorted))
+	d.Gini = float32(Gini(sorted))
+	d.Percentiles = Percentiles{
+		P50: float32(Percentile(sorted, 50)),
+		P75: float32(Percentile(sorted, 75)),
+		P90: float32(Percentile(sorted, 90)),
+		P95: float32(Percentile(sorted, 95)),
+		P99: float32(Percentile(sorted, 99)),
+	}
+	if weights != nil {
+		d.WeightedMean = float32(WeightedMean(values, weights))
+		d.WeightedMedian = float32(WeightedMedian(values, weights))
+	}
+	d.Histogram = Histogram(sorted, bounds)
+
+	return d
+}
+
+// Mean returns the arithmetic mean of the values, or 0 if there is none.
+func Mean(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	var sum float64
+	for _, v := range values {
+		sum += v
+	}
+	return sum / float64(len(values))
+}
+
+// Median returns the median of the values: the middle value, or the mean of
+// the two middle values when there is an even number of values. It returns 0
+// if there is no value.
+func Median(values []float64) float64 {
+	return Percentile(values, 50)
+}
+
+// Percentile returns the p-th percentile (0 <= p <= 100) of the values,
+// linearly interpolated between the closest ranks. It returns 0 if there is no
+// value.
+func Percentile(values []float64, p float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	sorted := values
+	if !sort.Float64sAreSorted(values) {
+		sorted = make([]float64, len(values))
+		copy(sorted, values)
+		sort.Float64s(sorted)
+	}
+
+	rank := p / 100 * float64(len(sorted)-1)
+	lo := int(math.Floor(rank))
+	hi := int(math.Ceil(rank))
+	if lo < 0 {
+		return sorted[0]
+	}
+	if hi >= len(sorted) {
+		return sorted[len(sorted)-1]
+	}
+	return sorted[lo] + (rank-float64(lo))*(sorted[hi]-sorted[lo])
+}
+
+// StdDev returns the population standard deviation of the values.
+func StdDev(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	mean := Mean(values)
+	var sum float64
+	for _, v := range values {
+		sum += (v - mean) * (v - mean)
+	}
+	return math.Sqrt(sum / float64(len(values)))
+}
+
+// Gini returns the Gini coefficient of the values, which must not be negative:
+// 0 when all the values are equal, close to 1 when a single value holds almost
+// the whole total.
+func Gini(values []float64) float64 {
+	sorted := values
+	if !sort.Float64sAreSorted(values) {
+		sorted = make([]float64, len(values))
+		copy(sorted, values)
+		sort.Float64s(sorted)
+	}
+
+	var sum, weighted float64
+	for i, v := range sorted {
+		sum += v
+		weighted += float64(i+1) * v
+	}
+	if sum == 0 {
+		return 0
+	}
+	n := float64(len(sorted))
+	return 2*weighted/(n*sum) - (n+1)/n
+}
+
+// WeightedMean returns the mean of the values weighted by the given weights,
+// or 0 if the sum of the weights is 0.
+func WeightedMean(values, weights []float64) float64 {
+	var sum, total float64
+	for i, v := range values {
+		sum += v * weights[i]
+		total += weights[i]
+	}
+	if total == 0 {
+		return 0
+	}
+	return sum / total
+}
+
+// WeightedMedian returns the smallest value such that the values lower or
+// equal to it hold at least half of the total weight, or 0 if the sum of the
+// weights is 0.
+func WeightedMedian(values, weights []float64) float64 {
+	idx := make([]int, len(values))
+	var total float64
+	for i := range idx {
+		idx[i] = i
+		total += weights[i]
+	}
+	if total == 0 {
+		return 0
+	}
+	sort.Sort(byValue{idx, values})
+
+	var cum float64
+	for _, i := range idx {
+		cum += weights[i]
+		if cum >= total/2 {
+			return values[i]
+		}
+	}
+	return values[idx[len(idx)-1]]
+}
+
+// byValue sorts indices by the values they refer to.
+type byValue struct {
+	idx    []int
+	values []float64
+}
+
+func (s byValue) Len() int           { return len(s.idx) }
+func (s byValue) Swap(i, j int)      { s.idx[i], s.idx[j] = s.idx[j], s.idx[i] }
+func (s byValue) Less(i, j int) bool { return s.values[s.idx[i]] < s.values[s.idx[j]] }
+
+// Histogram counts the values falling into the buckets defined by the bounds,
+// in increasing order. The i-th bucket holds the values lower or equal to
+// bounds[i] and greater than the previous bound. The values greater than the
+// last bound are counted in a last bucket, whose upper bound is the greatest
+// value. Empty buckets are included.
+func Histogram(values, bounds []float64) []HistogramBucket {
+	hist := make([]HistogramBucket, len(bounds))
+	for i, b := range bounds {
+		hist[i].Max = float32(b)
+	}
+
+	var overflow HistogramBucket
+	for _, v := range values {
+		i := sort.SearchFloat64s(bounds, v)
+		if i < len(bounds) {
+			hist[i].Count++
+			continue
+		}
+		overflow.Count++
+		if float32(v) > overflow.Max {
+			overflow.Max = float32(v)
+		}
+	}
+	if overflow.Count > 0 {
+		hist = append(hist, overflow)
+	}
+	return hist
+}
@@ -0,0 +1,113 @@
+// Copyright 2014-2015 The DevMine Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package anlzr_test
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/DevMine/srcanlzr/anlzr"
+	"github.com/DevMine/srcanlzr/src"
+	"github.com/DevMine/srcanlzr/src/ast"
+	"github.com/DevMine/srcanlzr/src/token"
+)
+
+func TestStats(t *testing.T) {
+	values := []float64{4, 1, 3, 2}
+
+	input := map[string]struct{ found, expected float64 }{
+		"Mean":           {anlzr.Mean(values), 2.5},
+		"Median":         {anlzr.Median(values), 2.5},
+		"Median odd":     {anlzr.Median([]float64{5, 1, 1}), 1},
+		"Percentile 75":  {anlzr.Percentile(values, 75), 3.25},
+		"Percentile 90":  {anlzr.Percentile(values, 90), 3.7},
+		"Percentile 100": {anlzr.Percentile(values, 100), 4},
+		"StdDev":         {anlzr.StdDev(values), math.Sqrt(1.25)},
+		"Gini":           {anlzr.Gini(values), 0.25},
+		"Gini equal":     {anlzr.Gini([]float64{3, 3, 3}), 0},
+		"WeightedMean":   {anlzr.WeightedMean(values, values), 3},
+		"WeightedMedian": {anlzr.WeightedMedian(values, values), 3},
+		"empty":          {anlzr.Median(nil) + anlzr.StdDev(nil) + anlzr.Gini(nil) + anlzr.WeightedMean(nil, nil), 0},
+	}
+	for name, in := range input {
+		if math.Abs(in.found-in.expected) > 1e-9 {
+			t.Errorf("%s: found %v, expected %v", name, in.found, in.expected)
+		}
+	}
+}
+
+func TestHistogram(t *testing.T) {
+	found := anlzr.Histogram([]float64{1, 2, 3, 4, 12}, []float64{2, 10, 11})
+	expected := []anlzr.HistogramBucket{
+		{Max: 2, Count: 2},
+		{Max: 10, Count: 2},
+		{Max: 11, Count: 0},
+		{Max: 12, Count: 1},
+	}
+	if !reflect.DeepEqual(found, expected) {
+		t.Errorf("Histogram: found %+v, expected %+v", found, expected)
+	}
+}
+
+func TestDistributions(t *testing.T) {
+	p := &src.Project{
+		Packages: []*src.Package{
+			&src.Package{
+				SrcFiles: []*src.SrcFile{
+					&src.SrcFile{
+						LoC: 30,
+						Funcs: []*ast.FuncDecl{
+							&ast.FuncDecl{Name: "f", LoC: 4},
+							&ast.FuncDecl{Name: "g", LoC: 12, Body: []ast.Stmt{&ast.IfStmt{StmtName: token.IfStmtName}}},
+						},
+					},
+					&src.SrcFile{LoC: 300},
+				},
+			},
+		},
+	}
+
+	res, err := anlzr.RunAnalyzers(p, anlzr.Distributions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	d := res.Distributions
+
+	input := map[string]struct{ found, expected float32 }{
+		"function length median":        {d.FuncLength.Median, 8},
+		"function length weighted mean": {d.FuncLength.WeightedMean, 10},
+		"complexity max":                {d.Complexity.Max, 2},
+		"complexity weighted mean":      {d.Complexity.WeightedMean, 1.75},
+		"file size p50":                 {d.FileSize.Percentiles.P50, 165},
+		"file size weighted median":     {d.FileSize.WeightedMedian, 300},
+	}
+	for name, in := range input {
+		if in.found != in.expected {
+			t.Errorf("%s: found %v, expected %v", name, in.found, in.expected)
+		}
+	}
+
+	expected := []anlzr.HistogramBucket{{Max: 10, Count: 1}, {Max: 25, Count: 1}, {Max: 50}, {Max: 100}, {Max: 200}}
+	if !reflect.DeepEqual(d.FuncLength.Histogram, expected) {
+		t.Errorf("function length histogram: found %+v, expected %+v", d.FuncLength.Histogram, expected)
+	}
+}
+
+func TestLoCMedian(t *testing.T) {
+	var funcs []*ast.FuncDecl
+	for _, loc := range []int64{1, 1, 5, 9} {
+		funcs = append(funcs, &ast.FuncDecl{LoC: loc})
+	}
+	p := &src.Project{Packages: []*src.Package{&src.Package{SrcFiles: []*src.SrcFile{&src.SrcFile{Funcs: funcs}}}}}
+
+	res, err := anlzr.RunAnalyzers(p, anlzr.LoC{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.MedianFuncLen != 3 {
+		t.Errorf("median_function_length: found %d, expected 3", res.MedianFuncLen)
+	}
+}
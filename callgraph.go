@@ -0,0 +1,70 @@
+// Copyright 2014-2015 The DevMine Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/DevMine/srcanlzr/callgraph"
+)
+
+var cmdCallgraph = &command{
+	name:  "callgraph",
+	usage: "[-format FORMAT] [-pkg PATH] [-root NAME [-depth N]] [-nocollapsed] [JSON PATH]",
+	short: "print the call graph of the project",
+	run:   runCallgraph,
+}
+
+func runCallgraph(cmd *command, args []string) {
+	fs := flag.NewFlagSet(cmd.name, flag.ExitOnError)
+	format := fs.String("format", "dot", "Output format. Possible values are: dot, graphml, json")
+	pkg := fs.String("pkg", "", "Only keep the calls made by the functions of the package with this path.")
+	root := fs.String("root", "", "Only keep the functions reachable from this function (e.g. Foo.bar).")
+	depth := fs.Int("depth", 0, "Maximum call depth from the root function (0 for no limit).")
+	noCollapsed := fs.Bool("nocollapsed", false, "Omit the external and unresolved calls.")
+	fs.Usage = func() {
+		fmt.Printf("usage: %s %s %s\n\n", os.Args[0], cmd.name, cmd.usage)
+		fs.PrintDefaults()
+		os.Exit(0)
+	}
+	fs.Parse(args)
+
+	if len(fs.Args()) > 1 {
+		fmt.Fprint(os.Stderr, "too many arguments\n\n")
+		fs.Usage()
+	}
+
+	p, err := decodeProject(fs.Arg(0))
+	if err != nil {
+		fatal(err)
+	}
+
+	g, err := callgraph.New(p).Filter(callgraph.Options{
+		Package:     *pkg,
+		Root:        *root,
+		Depth:       *depth,
+		NoCollapsed: *noCollapsed,
+	})
+	if err != nil {
+		fatal(err)
+	}
+
+	switch *format {
+	case "dot":
+		err = g.WriteDOT(os.Stdout)
+	case "graphml":
+		err = g.WriteGraphML(os.Stdout)
+	case "json":
+		err = g.WriteJSON(os.Stdout)
+	default:
+		err = errors.New("unsupported output format")
+	}
+	if err != nil {
+		fatal(err)
+	}
+}
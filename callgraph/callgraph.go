@@ -0,0 +1,309 @@
+// Copyright 2014-2015 The DevMine Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package callgraph builds the call graph of a project.
+//
+// The nodes of the graph are the functions, methods, constructors and
+// destructors of the project. Calls made in function literals are attributed
+// to the enclosing function. Calls to declarations that are not part of the
+// project are collapsed into one node per namespace (e.g. one node for all the
+// calls to the functions of "fmt"), and so are the calls that cannot be
+// resolved, such as method calls on variables (see the resolver package).
+//
+// Graphs can be restricted to a package or to the functions reachable from a
+// root function (see Graph.Filter), and written as DOT, GraphML or JSON.
+package callgraph
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/DevMine/srcanlzr/resolver"
+	"github.com/DevMine/srcanlzr/src"
+	"github.com/DevMine/srcanlzr/src/ast"
+)
+
+// Kinds of collapsed nodes. The kind of the other nodes is the kind of their
+// symbol (e.g. "function" or "method").
+const (
+	External   = "external"   // calls to an external namespace
+	Unresolved = "unresolved" // calls that could not be resolved
+)
+
+// Names of the collapsed nodes collecting the calls without namespace: the
+// external ones are most likely calls to builtin functions.
+const (
+	builtin = "<builtin>"
+	unknown = "<unknown>"
+)
+
+// A Node is a node of the call graph.
+type Node struct {
+	ID   int    // index of the node in Graph.Nodes
+	Name string // qualified name of the symbol, or namespace of collapsed nodes
+	Kind string
+
+	Symbol *resolver.Symbol // symbol of the function; nil for collapsed nodes
+
+	In  []*Edge // calls made to the node
+	Out []*Edge // calls made by the node
+}
+
+// Collapsed reports whether the node stands for a whole namespace.
+func (n *Node) Collapsed() bool {
+	return n.Symbol == nil
+}
+
+// An Edge represents all the calls made by a caller to a callee.
+type Edge struct {
+	Caller *Node
+	Callee *Node
+	Calls  []*resolver.Reference
+}
+
+// A Graph is a call graph.
+type Graph struct {
+	Nodes []*Node
+	Edges []*Edge
+
+	bySymbol    map[*resolver.Symbol]*Node
+	byNamespace map[string]*Node // collapsed nodes, by kind and namespace
+	edges       map[[2]*Node]*Edge
+}
+
+func newGraph() *Graph {
+	return &Graph{
+		bySymbol:    make(map[*resolver.Symbol]*Node),
+		byNamespace: make(map[string]*Node),
+		edges:       make(map[[2]*Node]*Edge),
+	}
+}
+
+// New builds the call graph of a project.
+func New(p *src.Project) *Graph {
+	return FromTable(resolver.Resolve(p))
+}
+
+// FromTable builds the call graph from a resolved symbol table.
+func FromTable(t *resolver.Table) *Graph {
+	g := newGraph()
+	for _, sym := range t.Symbols {
+		if isFunc(sym) {
+			g.symbolNode(sym)
+		}
+	}
+
+	for _, ref := range t.Refs {
+		if !isCall(ref) || ref.Scope == nil || !isFunc(ref.Scope) {
+			continue
+		}
+		caller := g.symbolNode(ref.Scope)
+
+		var callee *Node
+		switch {
+		case ref.Target != nil:
+			callee = g.symbolNode(ref.Target)
+		case ref.External && !isDump(ref.Namespace):
+			ns := ref.Namespace
+			if ns == "" {
+				ns = builtin
+			}
+			callee = g.collapsedNode(External, ns)
+		default:
+			ns := ref.Namespace
+			if ns == "" || isDump(ns) {
+				ns = unknown
+			}
+			callee = g.collapsedNode(Unresolved, ns)
+		}
+		g.addCalls(caller, callee, ref)
+	}
+	return g
+}
+
+// isDump reports whether a namespace is not a name but the dump of an
+// expression, as written by some parsers for the receivers they do not
+// support (e.g. "&{0xc20828fbc0 9641 [0xc20828fbe0] 0 9649}").
+func isDump(ns string) bool {
+	return strings.ContainsAny(ns, "{}[]() ")
+}
+
+// Node returns the node of a function declaration, or nil.
+func (g *Graph) Node(sym *resolver.Symbol) *Node {
+	return g.bySymbol[sym]
+}
+
+// Lookup returns the node named name. The name is either the qualified name of
+// a function (e.g. "foo/bar.Baz.qux") or a suffix of it starting after a dot
+// or a slash (e.g. "Baz.qux"). An error is returned if no node or more than
+// one node match.
+func (g *Graph) Lookup(name string) (*Node, error) {
+	var found []*Node
+	for _, n := range g.Nodes {
+		if n.Collapsed() {
+			continue
+		}
+		if n.Name == name {
+			return n, nil
+		}
+		if strings.HasSuffix(n.Name, "."+name) || strings.HasSuffix(n.Name, "/"+name) {
+			found = append(found, n)
+		}
+	}
+	switch len(found) {
+	case 0:
+		return nil, fmt.Errorf("callgraph: no function named %s", name)
+	case 1:
+		return found[0], nil
+	}
+	names := make([]string, len(found))
+	for i, n := range found {
+		names[i] = n.Name
+	}
+	return nil, fmt.Errorf("callgraph: ambiguous function name %s: %s", name, strings.Join(names, ", "))
+}
+
+func (g *Graph) addNode(n *Node) *Node {
+	n.ID = len(g.Nodes)
+	g.Nodes = append(g.Nodes, n)
+	if n.Symbol != nil {
+		g.bySymbol[n.Symbol] = n
+	} else {
+		g.byNamespace[n.Kind+" "+n.Name] = n
+	}
+	return n
+}
+
+func (g *Graph) symbolNode(sym *resolver.Symbol) *Node {
+	if n := g.bySymbol[sym]; n != nil {
+		return n
+	}
+	return g.addNode(&Node{Name: sym.QualifiedName(), Kind: string(sym.Kind), Symbol: sym})
+}
+
+func (g *Graph) collapsedNode(kind, ns string) *Node {
+	if n := g.byNamespace[kind+" "+ns]; n != nil {
+		return n
+	}
+	return g.addNode(&Node{Name: ns, Kind: kind})
+}
+
+func (g *Graph) addCalls(caller, callee *Node, calls ...*resolver.Reference) {
+	key := [2]*Node{caller, callee}
+	e := g.edges[key]
+	if e == nil {
+		e = &Edge{Caller: caller, Callee: callee}
+		g.edges[key] = e
+		g.Edges = append(g.Edges, e)
+		caller.Out = append(caller.Out, e)
+		callee.In = append(callee.In, e)
+	}
+	e.Calls = append(e.Calls, calls...)
+}
+
+// Options restrict the nodes of a graph. See Graph.Filter.
+type Options struct {
+	// Path of a package; only the calls made by the functions of this
+	// package are kept.
+	Package string
+
+	// Name of a root function (see Graph.Lookup); only the functions
+	// reachable from the root are kept.
+	Root string
+
+	// Maximum distance from the root; 0 means no limit.
+	Depth int
+
+	// Drop the collapsed (external and unresolved) nodes.
+	NoCollapsed bool
+}
+
+// Filter returns a new graph restricted according to opts.
+func (g *Graph) Filter(opts Options) (*Graph, error) {
+	if opts.Depth < 0 {
+		return nil, errors.New("callgraph: negative depth")
+	}
+	if opts.Depth > 0 && opts.Root == "" {
+		return nil, errors.New("callgraph: depth requires a root function")
+	}
+
+	keep := func(e *Edge) bool {
+		if opts.NoCollapsed && e.Callee.Collapsed() {
+			return false
+		}
+		return opts.Package == "" || e.Caller.Symbol.Pkg.Path == opts.Package
+	}
+
+	nodes := make(map[*Node]bool)
+	edges := make(map[*Edge]bool)
+	if opts.Root != "" {
+		root, err := g.Lookup(opts.Root)
+		if err != nil {
+			return nil, err
+		}
+		nodes[root] = true
+		for depth, cur := 0, []*Node{root}; len(cur) > 0 && (opts.Depth == 0 || depth < opts.Depth); depth++ {
+			var next []*Node
+			for _, n := range cur {
+				for _, e := range n.Out {
+					if !keep(e) {
+						continue
+					}
+					edges[e] = true
+					if !nodes[e.Callee] {
+						nodes[e.Callee] = true
+						next = append(next, e.Callee)
+					}
+				}
+			}
+			cur = next
+		}
+	} else {
+		for _, n := range g.Nodes {
+			if !n.Collapsed() && (opts.Package == "" || n.Symbol.Pkg.Path == opts.Package) {
+				nodes[n] = true
+			}
+		}
+		for _, e := range g.Edges {
+			if keep(e) {
+				edges[e] = true
+				nodes[e.Callee] = true
+			}
+		}
+	}
+
+	// rebuild the graph, preserving the order of the nodes and edges
+	f := newGraph()
+	copies := make(map[*Node]*Node)
+	for _, n := range g.Nodes {
+		if nodes[n] {
+			copies[n] = f.addNode(&Node{Name: n.Name, Kind: n.Kind, Symbol: n.Symbol})
+		}
+	}
+	for _, e := range g.Edges {
+		if edges[e] {
+			f.addCalls(copies[e.Caller], copies[e.Callee], e.Calls...)
+		}
+	}
+	return f, nil
+}
+
+// isFunc reports whether the symbol is a function-like declaration.
+func isFunc(sym *resolver.Symbol) bool {
+	switch sym.Kind {
+	case resolver.Func, resolver.Method, resolver.Constructor, resolver.Destructor:
+		return true
+	}
+	return false
+}
+
+// isCall reports whether the reference is a function or constructor call.
+func isCall(ref *resolver.Reference) bool {
+	switch ref.Node.(type) {
+	case *ast.CallExpr, *ast.ConstructorCallExpr:
+		return true
+	}
+	return false
+}
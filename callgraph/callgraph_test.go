@@ -0,0 +1,163 @@
+// Copyright 2014-2015 The DevMine Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package callgraph
+
+import (
+	"bytes"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/DevMine/srcanlzr/src"
+	"github.com/DevMine/srcanlzr/src/ast"
+	"github.com/DevMine/srcanlzr/src/token"
+)
+
+func call(namespace, name string) ast.Stmt {
+	return &ast.ExprStmt{
+		StmtName: token.ExprStmtName,
+		X: &ast.CallExpr{
+			ExprName: token.CallExprName,
+			Fun:      &ast.FuncRef{Namespace: namespace, FuncName: name},
+		},
+	}
+}
+
+func newProject() *src.Project {
+	lit := &ast.FuncLit{ExprName: token.FuncLitName, Body: []ast.Stmt{call("", "c")}}
+	return &src.Project{
+		Name: "foo",
+		Packages: []*src.Package{
+			&src.Package{
+				Path: "x",
+				SrcFiles: []*src.SrcFile{
+					&src.SrcFile{
+						Path:    "x/x.go",
+						Imports: []*ast.Import{&ast.Import{Path: "fmt"}, &ast.Import{Path: "foo/y"}},
+						Funcs: []*ast.FuncDecl{
+							&ast.FuncDecl{Name: "a", Body: []ast.Stmt{call("", "b"), call("", "b"), call("fmt", "Println")}},
+							&ast.FuncDecl{Name: "b", Body: []ast.Stmt{&ast.ExprStmt{StmtName: token.ExprStmtName, X: lit}}},
+							&ast.FuncDecl{Name: "c", Body: []ast.Stmt{call("y", "d"), call("v", "m")}},
+						},
+					},
+				},
+			},
+			&src.Package{
+				Path: "y",
+				SrcFiles: []*src.SrcFile{
+					&src.SrcFile{
+						Path:  "y/y.go",
+						Funcs: []*ast.FuncDecl{&ast.FuncDecl{Name: "d", Body: []ast.Stmt{call("", "len")}}},
+					},
+				},
+			},
+		},
+	}
+}
+
+// edges returns the edges of the graph as "caller -> callee (calls)" strings.
+func edges(g *Graph) string {
+	var strs []string
+	for _, e := range g.Edges {
+		s := e.Caller.Name + " -> " + e.Callee.Name
+		if len(e.Calls) > 1 {
+			s += " (" + strconv.Itoa(len(e.Calls)) + ")"
+		}
+		strs = append(strs, s)
+	}
+	return strings.Join(strs, ", ")
+}
+
+func TestNew(t *testing.T) {
+	g := New(newProject())
+
+	expected := "x.a -> x.b (2), x.a -> fmt, x.b -> x.c, x.c -> y.d, x.c -> v, y.d -> <builtin>"
+	if found := edges(g); found != expected {
+		t.Errorf("New: found edges %s, expected %s", found, expected)
+	}
+
+	n, err := g.Lookup("c")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(n.In) != 1 || len(n.Out) != 2 || n.Collapsed() {
+		t.Errorf("Lookup c: found %d in and %d out edges, expected 1 and 2", len(n.In), len(n.Out))
+	}
+	for _, kind := range []string{External, Unresolved} {
+		found := false
+		for _, n := range g.Nodes {
+			found = found || n.Kind == kind && n.Collapsed()
+		}
+		if !found {
+			t.Errorf("New: found no %s node", kind)
+		}
+	}
+}
+
+func TestNewDumpedNamespaces(t *testing.T) {
+	p := newProject()
+	p.Packages[1].SrcFiles[0].Funcs[0].Body = []ast.Stmt{
+		call("&{0xc20828fbc0 9641 [0xc20828fbe0] 0 9649}", "Close"),
+		call("&{0xc20828fc40 9702 [0xc20828fc60] 0 9710}", "Close"),
+		call("&{dec scan}", "next"),
+	}
+	g := New(p)
+
+	expected := "x.a -> x.b (2), x.a -> fmt, x.b -> x.c, x.c -> y.d, x.c -> v, y.d -> <unknown> (3)"
+	if found := edges(g); found != expected {
+		t.Errorf("New: found edges %s, expected %s", found, expected)
+	}
+}
+
+func TestFilter(t *testing.T) {
+	g := New(newProject())
+
+	input := map[Options]string{
+		Options{Package: "y"}:                             "y.d -> <builtin>",
+		Options{Root: "b", Depth: 2}:                      "x.b -> x.c, x.c -> y.d, x.c -> v",
+		Options{Root: "x.a", Depth: 1, NoCollapsed: true}: "x.a -> x.b (2)",
+		Options{Package: "x", NoCollapsed: true}:          "x.a -> x.b (2), x.b -> x.c, x.c -> y.d",
+	}
+	for opts, expected := range input {
+		f, err := g.Filter(opts)
+		if err != nil {
+			t.Errorf("Filter %+v: %v", opts, err)
+			continue
+		}
+		if found := edges(f); found != expected {
+			t.Errorf("Filter %+v: found edges %s, expected %s", opts, found, expected)
+		}
+	}
+
+	for _, opts := range []Options{{Root: "z"}, {Depth: 1}, {Root: "a", Depth: -1}} {
+		if _, err := g.Filter(opts); err == nil {
+			t.Errorf("Filter %+v: found no error, expected an error", opts)
+		}
+	}
+}
+
+func TestWrite(t *testing.T) {
+	g := New(newProject())
+
+	input := []struct {
+		write    func(*bytes.Buffer) error
+		expected []string
+	}{
+		{func(b *bytes.Buffer) error { return g.WriteDOT(b) }, []string{"digraph callgraph {", `n0 [label="x.a"];`, `n0 -> n1 [label="2"];`}},
+		{func(b *bytes.Buffer) error { return g.WriteGraphML(b) }, []string{"<graphml", `<edge source="n0" target="n1">`, `<data key="calls">2</data>`}},
+		{func(b *bytes.Buffer) error { return g.WriteJSON(b) }, []string{`{"id":0,"name":"x.a","kind":"function","package":"x","file":"x/x.go"}`, `{"caller":0,"callee":1,"calls":2}`}},
+	}
+	for i, in := range input {
+		buf := new(bytes.Buffer)
+		if err := in.write(buf); err != nil {
+			t.Fatal(err)
+		}
+		for _, s := range in.expected {
+			if !strings.Contains(buf.String(), s) {
+				t.Errorf("Write %d: %q not found in\n%s", i, s, buf.String())
+			}
+		}
+	}
+}
@@ -0,0 +1,169 @@
+// Copyright 2014-2015 The DevMine Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package callgraph
+
+import (
+	"bufio"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// WriteDOT writes the graph in the DOT language of Graphviz. Collapsed nodes
+// are drawn as boxes, and edges are labelled with the number of calls when
+// there is more than one.
+func (g *Graph) WriteDOT(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+	fmt.Fprintln(bw, "digraph callgraph {")
+	for _, n := range g.Nodes {
+		attrs := "label=" + strconv.Quote(n.Name)
+		if n.Collapsed() {
+			attrs += ", shape=box"
+			if n.Kind == Unresolved {
+				attrs += ", style=dashed"
+			}
+		}
+		fmt.Fprintf(bw, "\tn%d [%s];\n", n.ID, attrs)
+	}
+	for _, e := range g.Edges {
+		fmt.Fprintf(bw, "\tn%d -> n%d", e.Caller.ID, e.Callee.ID)
+		if len(e.Calls) > 1 {
+			fmt.Fprintf(bw, " [label=\"%d\"]", len(e.Calls))
+		}
+		fmt.Fprintln(bw, ";")
+	}
+	fmt.Fprintln(bw, "}")
+	return bw.Flush()
+}
+
+type graphML struct {
+	XMLName xml.Name     `xml:"graphml"`
+	XMLNS   string       `xml:"xmlns,attr"`
+	Keys    []graphMLKey `xml:"key"`
+	Graph   struct {
+		ID          string        `xml:"id,attr"`
+		EdgeDefault string        `xml:"edgedefault,attr"`
+		Nodes       []graphMLNode `xml:"node"`
+		Edges       []graphMLEdge `xml:"edge"`
+	} `xml:"graph"`
+}
+
+type graphMLKey struct {
+	ID       string `xml:"id,attr"`
+	For      string `xml:"for,attr"`
+	AttrName string `xml:"attr.name,attr"`
+	AttrType string `xml:"attr.type,attr"`
+}
+
+type graphMLData struct {
+	Key   string `xml:"key,attr"`
+	Value string `xml:",chardata"`
+}
+
+type graphMLNode struct {
+	ID   string        `xml:"id,attr"`
+	Data []graphMLData `xml:"data"`
+}
+
+type graphMLEdge struct {
+	Source string        `xml:"source,attr"`
+	Target string        `xml:"target,attr"`
+	Data   []graphMLData `xml:"data"`
+}
+
+// WriteGraphML writes the graph in the GraphML format. Nodes have a name, a
+// kind, a package and a file; edges have a number of calls.
+func (g *Graph) WriteGraphML(w io.Writer) error {
+	doc := graphML{
+		XMLNS: "http://graphml.graphdrawing.org/xmlns",
+		Keys: []graphMLKey{
+			{ID: "name", For: "node", AttrName: "name", AttrType: "string"},
+			{ID: "kind", For: "node", AttrName: "kind", AttrType: "string"},
+			{ID: "package", For: "node", AttrName: "package", AttrType: "string"},
+			{ID: "file", For: "node", AttrName: "file", AttrType: "string"},
+			{ID: "calls", For: "edge", AttrName: "calls", AttrType: "int"},
+		},
+	}
+	doc.Graph.ID = "callgraph"
+	doc.Graph.EdgeDefault = "directed"
+
+	for _, n := range g.Nodes {
+		node := graphMLNode{
+			ID:   "n" + strconv.Itoa(n.ID),
+			Data: []graphMLData{{Key: "name", Value: n.Name}, {Key: "kind", Value: n.Kind}},
+		}
+		if !n.Collapsed() {
+			node.Data = append(node.Data,
+				graphMLData{Key: "package", Value: n.Symbol.Pkg.Path},
+				graphMLData{Key: "file", Value: n.Symbol.File.Path})
+		}
+		doc.Graph.Nodes = append(doc.Graph.Nodes, node)
+	}
+	for _, e := range g.Edges {
+		doc.Graph.Edges = append(doc.Graph.Edges, graphMLEdge{
+			Source: "n" + strconv.Itoa(e.Caller.ID),
+			Target: "n" + strconv.Itoa(e.Callee.ID),
+			Data:   []graphMLData{{Key: "calls", Value: strconv.Itoa(len(e.Calls))}},
+		})
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(doc); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+type jsonGraph struct {
+	Nodes []jsonNode `json:"nodes"`
+	Edges []jsonEdge `json:"edges"`
+}
+
+type jsonNode struct {
+	ID      int    `json:"id"`
+	Name    string `json:"name"`
+	Kind    string `json:"kind"`
+	Package string `json:"package,omitempty"`
+	File    string `json:"file,omitempty"`
+}
+
+type jsonEdge struct {
+	Caller int     `json:"caller"`
+	Callee int     `json:"callee"`
+	Calls  int     `json:"calls"`
+	Lines  []int64 `json:"lines,omitempty"` // lines of the calls, when known
+}
+
+// WriteJSON writes the graph in JSON, as a list of nodes and a list of edges
+// referring to the nodes by their ID.
+func (g *Graph) WriteJSON(w io.Writer) error {
+	jg := jsonGraph{Nodes: []jsonNode{}, Edges: []jsonEdge{}}
+	for _, n := range g.Nodes {
+		jn := jsonNode{ID: n.ID, Name: n.Name, Kind: n.Kind}
+		if !n.Collapsed() {
+			jn.Package, jn.File = n.Symbol.Pkg.Path, n.Symbol.File.Path
+		}
+		jg.Nodes = append(jg.Nodes, jn)
+	}
+	for _, e := range g.Edges {
+		je := jsonEdge{Caller: e.Caller.ID, Callee: e.Callee.ID, Calls: len(e.Calls)}
+		for _, ref := range e.Calls {
+			if ref.Line != 0 {
+				je.Lines = append(je.Lines, ref.Line)
+			}
+		}
+		jg.Edges = append(jg.Edges, je)
+	}
+	enc := json.NewEncoder(w)
+	enc.SetEscapeHTML(false)
+	return enc.Encode(jg)
+}
@@ -0,0 +1,184 @@
+// Copyright 2014-2015 The DevMine Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package gate checks analysis results against quality rules, such as a
+// maximum function complexity or the absence of import cycles, to fail a
+// build when the code gets worse.
+//
+// The rules are read from a JSON configuration:
+//
+//	{
+//		"rules": [
+//			{"rule": "max-function-complexity", "threshold": 15},
+//			{"rule": "max-function-loc", "threshold": 80},
+//			{"rule": "min-public-doc-ratio", "threshold": 0.8, "severity": "warning"},
+//			{"rule": "no-import-cycles"},
+//			{"rule": "max-metric", "metric": "acme.unsafe_calls", "threshold": 0}
+//		]
+//	}
+package gate
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/DevMine/srcanlzr/anlzr"
+)
+
+// Severities of the rules
+const (
+	Error   = "error" // default severity
+	Warning = "warning"
+)
+
+// A Rule is a constraint on the analysis results.
+type Rule struct {
+	Name      string  `json:"rule"`                // one of Rules()
+	Threshold float64 `json:"threshold,omitempty"` // unused by some rules
+	Severity  string  `json:"severity,omitempty"`  // Error or Warning
+	Metric    string  `json:"metric,omitempty"`    // key of the metric, for min-metric and max-metric
+}
+
+// A Config is a set of rules.
+type Config struct {
+	Rules []Rule `json:"rules"`
+}
+
+// A Location tells where a rule is broken. All its fields are empty when the
+// rule applies to the whole project.
+type Location struct {
+	Package string `json:"package,omitempty"`
+	File    string `json:"file,omitempty"`
+	Func    string `json:"function,omitempty"`
+	Scope   string `json:"scope,omitempty"` // scope of a custom metric (see anlzr.Metric)
+}
+
+func (l Location) String() string {
+	var parts []string
+	for _, s := range []string{l.Package, l.File, l.Func, l.Scope} {
+		if s != "" {
+			parts = append(parts, s)
+		}
+	}
+	if len(parts) == 0 {
+		return "project"
+	}
+	return strings.Join(parts, ":")
+}
+
+// A Violation is a broken rule.
+type Violation struct {
+	Rule     string   `json:"rule"`
+	Severity string   `json:"severity"`
+	Location Location `json:"location"`
+	Value    float64  `json:"value"`
+	Message  string   `json:"message"`
+}
+
+func (v Violation) String() string {
+	return fmt.Sprintf("%s: %s: %s (%s)", v.Severity, v.Location, v.Message, v.Rule)
+}
+
+// A check returns the locations where a rule is broken, with the offending
+// values.
+type check func(r *anlzr.Result, rule Rule) []Violation
+
+type ruleInfo struct {
+	doc      string
+	analyzer string // analyzer computing the results checked by the rule
+	check    check
+}
+
+// rules holds the available rules, by name.
+var rules = map[string]ruleInfo{
+	"max-function-complexity":  {"maximum cyclomatic complexity of a function", "complexity", maxFuncComplexity},
+	"max-cognitive-complexity": {"maximum cognitive complexity of a function", "cognitive-complexity", maxCognitiveComplexity},
+	"max-function-loc":         {"maximum lines of code of a function", "loc", maxFuncLoC},
+	"max-nesting-depth":        {"maximum nesting depth of a function", "nesting", maxNestingDepth},
+	"min-public-doc-ratio":     {"minimum ratio of documented public functions", "comment-ratios", minPublicDocRatio},
+	"no-import-cycles":         {"no import cycle between the packages of the project", "dependencies", noImportCycles},
+	"min-metric":               {"minimum value of a custom metric, in every scope", "", minMetric},
+	"max-metric":               {"maximum value of a custom metric, in every scope", "", maxMetric},
+}
+
+// Rules returns the names of the available rules, sorted.
+func Rules() []string {
+	names := make([]string, 0, len(rules))
+	for name := range rules {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Doc returns the description of a rule.
+func Doc(name string) string {
+	return rules[name].doc
+}
+
+// Load reads and validates a configuration in JSON.
+func Load(r io.Reader) (*Config, error) {
+	var c Config
+	dec := json.NewDecoder(r)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&c); err != nil {
+		return nil, err
+	}
+	for i, rule := range c.Rules {
+		if _, ok := rules[rule.Name]; !ok {
+			return nil, fmt.Errorf("unknown rule %q", rule.Name)
+		}
+		switch rule.Severity {
+		case "":
+			c.Rules[i].Severity = Error
+		case Error, Warning:
+		default:
+			return nil, fmt.Errorf("rule %s: unknown severity %q", rule.Name, rule.Severity)
+		}
+		if (rule.Name == "min-metric" || rule.Name == "max-metric") != (rule.Metric != "") {
+			return nil, fmt.Errorf("rule %s: a metric is required by min-metric and max-metric only", rule.Name)
+		}
+	}
+	return &c, nil
+}
+
+// Analyzers returns the names of the registered analyzers (see anlzr.Select)
+// whose results are checked by the rules.
+func (c *Config) Analyzers() []string {
+	var names []string
+	seen := make(map[string]bool)
+	for _, rule := range c.Rules {
+		if name := rules[rule.Name].analyzer; name != "" && !seen[name] {
+			seen[name] = true
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// Check evaluates the rules against analysis results and returns the
+// violations, in the order of the rules.
+func (c *Config) Check(r *anlzr.Result) []Violation {
+	var vs []Violation
+	for _, rule := range c.Rules {
+		for _, v := range rules[rule.Name].check(r, rule) {
+			v.Rule, v.Severity = rule.Name, rule.Severity
+			vs = append(vs, v)
+		}
+	}
+	return vs
+}
+
+// Failed tells whether any of the violations has the Error severity.
+func Failed(vs []Violation) bool {
+	for _, v := range vs {
+		if v.Severity == Error {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,95 @@
+// Copyright 2014-2015 The DevMine Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gate
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/DevMine/srcanlzr/anlzr"
+)
+
+const config = `{
+	"rules": [
+		{"rule": "max-function-complexity", "threshold": 15},
+		{"rule": "max-function-loc", "threshold": 80, "severity": "warning"},
+		{"rule": "min-public-doc-ratio", "threshold": 0.8},
+		{"rule": "no-import-cycles"},
+		{"rule": "max-metric", "metric": "acme.unsafe_calls", "threshold": 0}
+	]
+}`
+
+func newResult() *anlzr.Result {
+	r := &anlzr.Result{
+		Complexity: anlzr.ComplexityMetrics{Files: []anlzr.FileComplexity{
+			{Path: "x/x.go", Funcs: []anlzr.FuncComplexity{{Name: "f", Complexity: 16}, {Name: "g", Complexity: 15}}},
+		}},
+		FuncLengths: []anlzr.FileFuncLengths{
+			{Path: "x/x.go", Funcs: []anlzr.FuncLength{{Name: "f", LoC: 81}, {Name: "g", LoC: 80}}},
+		},
+		DocCoverage:  anlzr.CommentRatios{PublicFuncs: 10, DocumentedPublicFuncs: 9},
+		Dependencies: anlzr.DependencyMetrics{Cycles: []anlzr.ImportCycle{{Packages: []string{"x", "y"}}}},
+	}
+	r.AddMetric(anlzr.Metric{Key: "acme.unsafe_calls", Scope: "y", Value: 0})
+	return r
+}
+
+func TestCheck(t *testing.T) {
+	c, err := Load(strings.NewReader(config))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	vs := c.Check(newResult())
+	var found []string
+	for _, v := range vs {
+		found = append(found, v.String())
+	}
+	expected := []string{
+		"error: x/x.go:f: cyclomatic complexity 16 exceeds 15 (max-function-complexity)",
+		"warning: x/x.go:f: 81 lines of code exceed 80 (max-function-loc)",
+		"error: x: import cycle: x -> y (no-import-cycles)",
+	}
+	if !reflect.DeepEqual(found, expected) {
+		t.Errorf("Check: found %q, expected %q", found, expected)
+	}
+	if !Failed(vs) || Failed(vs[1:2]) {
+		t.Errorf("Failed: found %v and %v, expected true and false", Failed(vs), Failed(vs[1:2]))
+	}
+
+	analyzers := []string{"complexity", "loc", "comment-ratios", "dependencies"}
+	if found := c.Analyzers(); !reflect.DeepEqual(found, analyzers) {
+		t.Errorf("Analyzers: found %v, expected %v", found, analyzers)
+	}
+}
+
+func TestMinPublicDocRatio(t *testing.T) {
+	rule := Rule{Name: "min-public-doc-ratio", Threshold: 0.8}
+	input := map[anlzr.CommentRatios]int{
+		{}: 0,
+		{MethComRatio: 1, PublicFuncs: 4, DocumentedPublicFuncs: 4}:  0,
+		{FuncComRatio: 1, PublicFuncs: 10, DocumentedPublicFuncs: 5}: 1,
+	}
+	for dc, expected := range input {
+		if found := len(minPublicDocRatio(&anlzr.Result{DocCoverage: dc}, rule)); found != expected {
+			t.Errorf("%+v: found %d violations, expected %d", dc, found, expected)
+		}
+	}
+}
+
+func TestLoad(t *testing.T) {
+	for _, in := range []string{
+		`{"rules": [{"rule": "foo"}]}`,
+		`{"rules": [{"rule": "no-import-cycles", "severity": "fatal"}]}`,
+		`{"rules": [{"rule": "max-metric"}]}`,
+		`{"rules": [{"rule": "no-import-cycles", "metric": "acme.foo"}]}`,
+		`{"rules": [{"rule": "no-import-cycles", "foo": 1}]}`,
+	} {
+		if _, err := Load(strings.NewReader(in)); err == nil {
+			t.Errorf("Load %s: found no error, expected an error", in)
+		}
+	}
+}
@@ -0,0 +1,141 @@
+// Copyright 2014-2015 The DevMine Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gate
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/DevMine/srcanlzr/anlzr"
+)
+
+func maxFuncComplexity(r *anlzr.Result, rule Rule) []Violation {
+	var vs []Violation
+	for _, f := range r.Complexity.Files {
+		for _, fn := range f.Funcs {
+			if v := float64(fn.Complexity); v > rule.Threshold {
+				vs = append(vs, Violation{
+					Location: Location{File: f.Path, Func: fn.Name},
+					Value:    v,
+					Message:  fmt.Sprintf("cyclomatic complexity %g exceeds %g", v, rule.Threshold),
+				})
+			}
+		}
+	}
+	return vs
+}
+
+func maxCognitiveComplexity(r *anlzr.Result, rule Rule) []Violation {
+	var vs []Violation
+	for _, f := range r.Cognitive.Files {
+		for _, fn := range f.Funcs {
+			if v := float64(fn.Complexity); v > rule.Threshold {
+				vs = append(vs, Violation{
+					Location: Location{File: f.Path, Func: fn.Name},
+					Value:    v,
+					Message:  fmt.Sprintf("cognitive complexity %g exceeds %g", v, rule.Threshold),
+				})
+			}
+		}
+	}
+	return vs
+}
+
+func maxFuncLoC(r *anlzr.Result, rule Rule) []Violation {
+	var vs []Violation
+	for _, f := range r.FuncLengths {
+		for _, fn := range f.Funcs {
+			if v := float64(fn.LoC); v > rule.Threshold {
+				vs = append(vs, Violation{
+					Location: Location{File: f.Path, Func: fn.Name},
+					Value:    v,
+					Message:  fmt.Sprintf("%g lines of code exceed %g", v, rule.Threshold),
+				})
+			}
+		}
+	}
+	return vs
+}
+
+func maxNestingDepth(r *anlzr.Result, rule Rule) []Violation {
+	var vs []Violation
+	for _, f := range r.Nesting.Files {
+		for _, fn := range f.Funcs {
+			if v := float64(fn.MaxDepth); v > rule.Threshold {
+				vs = append(vs, Violation{
+					Location: Location{File: f.Path, Func: fn.Name},
+					Value:    v,
+					Message:  fmt.Sprintf("nesting depth %g exceeds %g", v, rule.Threshold),
+				})
+			}
+		}
+	}
+	return vs
+}
+
+// minPublicDocRatio checks the public functions and methods together, so that
+// the rule also applies to languages without top-level functions. It does not
+// apply to projects without any public function.
+func minPublicDocRatio(r *anlzr.Result, rule Rule) []Violation {
+	dc := r.DocCoverage
+	if dc.PublicFuncs == 0 {
+		return nil
+	}
+	if v := float64(dc.DocumentedPublicFuncs) / float64(dc.PublicFuncs); v < rule.Threshold {
+		return []Violation{{
+			Value:   v,
+			Message: fmt.Sprintf("%.2f of the public functions and methods are documented, expected at least %g", v, rule.Threshold),
+		}}
+	}
+	return nil
+}
+
+func noImportCycles(r *anlzr.Result, rule Rule) []Violation {
+	var vs []Violation
+	for _, c := range r.Dependencies.Cycles {
+		vs = append(vs, Violation{
+			Location: Location{Package: c.Packages[0]},
+			Value:    float64(len(c.Packages)),
+			Message:  "import cycle: " + strings.Join(c.Packages, " -> "),
+		})
+	}
+	return vs
+}
+
+func minMetric(r *anlzr.Result, rule Rule) []Violation {
+	return metricRule(r, rule, func(v float64) bool { return v < rule.Threshold }, "below")
+}
+
+func maxMetric(r *anlzr.Result, rule Rule) []Violation {
+	return metricRule(r, rule, func(v float64) bool { return v > rule.Threshold }, "exceeds")
+}
+
+// metricRule reports the numeric values of the metric of the rule for which
+// broken returns true.
+func metricRule(r *anlzr.Result, rule Rule, broken func(float64) bool, verb string) []Violation {
+	var vs []Violation
+	for _, m := range r.Metrics {
+		if m.Key != rule.Metric {
+			continue
+		}
+		var v float64
+		switch x := m.Value.(type) {
+		case int64:
+			v = float64(x)
+		case float64:
+			v = x
+		default:
+			continue
+		}
+		if broken(v) {
+			vs = append(vs, Violation{
+				Location: Location{Scope: m.Scope},
+				Value:    v,
+				Message:  fmt.Sprintf("%s %g %s %g", m.Key, v, verb, rule.Threshold),
+			})
+		}
+	}
+	return vs
+}
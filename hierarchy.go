@@ -0,0 +1,79 @@
+// Copyright 2014-2015 The DevMine Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/DevMine/srcanlzr/hierarchy"
+)
+
+var cmdHierarchy = &command{
+	name:  "hierarchy",
+	usage: "[-format FORMAT | -ancestors NAME | -descendants NAME | -implementors NAME] [JSON PATH]",
+	short: "print the type hierarchy of the project",
+	run:   runHierarchy,
+}
+
+func runHierarchy(cmd *command, args []string) {
+	fs := flag.NewFlagSet(cmd.name, flag.ExitOnError)
+	format := fs.String("format", "dot", "Output format. Possible values are: dot, json")
+	ancestors := fs.String("ancestors", "", "Print the supertypes of the type with this name.")
+	descendants := fs.String("descendants", "", "Print the subtypes of the type with this name.")
+	implementors := fs.String("implementors", "", "Print the classes and enums implementing the interface with this name.")
+	fs.Usage = func() {
+		fmt.Printf("usage: %s %s %s\n\n", os.Args[0], cmd.name, cmd.usage)
+		fs.PrintDefaults()
+		os.Exit(0)
+	}
+	fs.Parse(args)
+
+	if len(fs.Args()) > 1 {
+		fmt.Fprint(os.Stderr, "too many arguments\n\n")
+		fs.Usage()
+	}
+
+	p, err := decodeProject(fs.Arg(0))
+	if err != nil {
+		fatal(err)
+	}
+	h := hierarchy.New(p)
+
+	var name string
+	var query func(*hierarchy.Type) []*hierarchy.Type
+	switch {
+	case *ancestors != "":
+		name, query = *ancestors, h.Ancestors
+	case *descendants != "":
+		name, query = *descendants, h.Descendants
+	case *implementors != "":
+		name, query = *implementors, h.Implementors
+	}
+	if query != nil {
+		typ, err := h.Lookup(name)
+		if err != nil {
+			fatal(err)
+		}
+		for _, t := range query(typ) {
+			fmt.Println(t.Name)
+		}
+		return
+	}
+
+	switch *format {
+	case "dot":
+		err = h.WriteDOT(os.Stdout)
+	case "json":
+		err = h.WriteJSON(os.Stdout)
+	default:
+		err = errors.New("unsupported output format")
+	}
+	if err != nil {
+		fatal(err)
+	}
+}
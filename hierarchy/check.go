@@ -0,0 +1,93 @@
+// Copyright 2014-2015 The DevMine Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package hierarchy
+
+import "github.com/DevMine/srcanlzr/internal/graph"
+
+// Cycles returns the inheritance cycles of the hierarchy. Each cycle is the
+// list of the types that are (indirectly) their own supertypes, in the order
+// of Hierarchy.Types.
+func (h *Hierarchy) Cycles() [][]*Type {
+	g := make([][]int, len(h.Types))
+	for _, t := range h.Types {
+		for _, l := range t.Supers {
+			g[t.ID] = append(g[t.ID], l.Super.ID)
+		}
+	}
+
+	var cycles [][]*Type
+	for _, ids := range graph.Cycles(g) {
+		cycle := make([]*Type, len(ids))
+		for i, id := range ids {
+			cycle[i] = h.Types[id]
+		}
+		cycles = append(cycles, cycle)
+	}
+	return cycles
+}
+
+// A Diamond represents a type inheriting from the same ancestor through
+// several of its direct supertypes.
+type Diamond struct {
+	Type     *Type
+	Ancestor *Type
+	Via      []*Type // direct supertypes of Type through which Ancestor is inherited
+}
+
+// Diamonds returns the diamond inheritances of the hierarchy. For each type,
+// only the closest common ancestors are reported: when an ancestor is
+// inherited several times, so are all of its own ancestors.
+func (h *Hierarchy) Diamonds() []Diamond {
+	var diamonds []Diamond
+	for _, t := range h.Types {
+		if len(t.Supers) < 2 {
+			continue
+		}
+
+		// direct supertypes through which each ancestor is reached
+		via := make(map[*Type][]*Type)
+		var order []*Type
+		for _, l := range t.Supers {
+			for _, a := range append([]*Type{l.Super}, h.Ancestors(l.Super)...) {
+				if a == t || contains(via[a], l.Super) {
+					continue
+				}
+				if via[a] == nil {
+					order = append(order, a)
+				}
+				via[a] = append(via[a], l.Super)
+			}
+		}
+
+		var common []*Type
+		for _, a := range order {
+			if len(via[a]) > 1 {
+				common = append(common, a)
+			}
+		}
+		for _, a := range common {
+			closest := true
+			for _, b := range common {
+				if b != a && contains(h.Ancestors(b), a) && !contains(h.Ancestors(a), b) {
+					closest = false
+					break
+				}
+			}
+			if closest {
+				diamonds = append(diamonds, Diamond{Type: t, Ancestor: a, Via: via[a]})
+			}
+		}
+	}
+	return diamonds
+}
+
+func contains(types []*Type, t *Type) bool {
+	for _, x := range types {
+		if x == t {
+			return true
+		}
+	}
+	return false
+}
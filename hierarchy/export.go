@@ -0,0 +1,113 @@
+// Copyright 2014-2015 The DevMine Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package hierarchy
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/DevMine/srcanlzr/resolver"
+)
+
+// WriteDOT writes the hierarchy in the DOT language of Graphviz, with edges
+// going from subtypes to supertypes. Interfaces and traits are drawn in italic,
+// external types are dashed and links are styled after their relation.
+func (h *Hierarchy) WriteDOT(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+	fmt.Fprintln(bw, "digraph hierarchy {")
+	fmt.Fprintln(bw, "\trankdir=BT;")
+	fmt.Fprintln(bw, "\tnode [shape=box];")
+	for _, t := range h.Types {
+		attrs := "label=" + strconv.Quote(t.Name)
+		switch t.Kind {
+		case External:
+			attrs += ", style=dashed"
+		case string(resolver.Interface), string(resolver.Trait):
+			attrs += ", fontname=\"Helvetica-Oblique\""
+		}
+		fmt.Fprintf(bw, "\tn%d [%s];\n", t.ID, attrs)
+	}
+	for _, l := range h.Links {
+		fmt.Fprintf(bw, "\tn%d -> n%d", l.Sub.ID, l.Super.ID)
+		switch l.Relation {
+		case Implements:
+			fmt.Fprint(bw, " [style=dashed]")
+		case Mixes:
+			fmt.Fprint(bw, " [style=dotted]")
+		}
+		fmt.Fprintln(bw, ";")
+	}
+	fmt.Fprintln(bw, "}")
+	return bw.Flush()
+}
+
+type jsonHierarchy struct {
+	Types    []jsonType    `json:"types"`
+	Links    []jsonLink    `json:"links"`
+	Cycles   [][]int       `json:"cycles"`
+	Diamonds []jsonDiamond `json:"diamonds"`
+}
+
+type jsonType struct {
+	ID      int    `json:"id"`
+	Name    string `json:"name"`
+	Kind    string `json:"kind"`
+	Package string `json:"package,omitempty"`
+	File    string `json:"file,omitempty"`
+}
+
+type jsonLink struct {
+	Sub      int      `json:"sub"`
+	Super    int      `json:"super"`
+	Relation Relation `json:"relation"`
+}
+
+type jsonDiamond struct {
+	Type     int   `json:"type"`
+	Ancestor int   `json:"ancestor"`
+	Via      []int `json:"via"`
+}
+
+// WriteJSON writes the hierarchy in JSON, as a list of types and a list of
+// links referring to the types by their ID, along with the cycles and the
+// diamonds of the hierarchy.
+func (h *Hierarchy) WriteJSON(w io.Writer) error {
+	jh := jsonHierarchy{
+		Types:    []jsonType{},
+		Links:    []jsonLink{},
+		Cycles:   [][]int{},
+		Diamonds: []jsonDiamond{},
+	}
+	for _, t := range h.Types {
+		jt := jsonType{ID: t.ID, Name: t.Name, Kind: t.Kind}
+		if !t.IsExternal() {
+			jt.Package, jt.File = t.Symbol.Pkg.Path, t.Symbol.File.Path
+		}
+		jh.Types = append(jh.Types, jt)
+	}
+	for _, l := range h.Links {
+		jh.Links = append(jh.Links, jsonLink{Sub: l.Sub.ID, Super: l.Super.ID, Relation: l.Relation})
+	}
+	for _, c := range h.Cycles() {
+		jh.Cycles = append(jh.Cycles, ids(c))
+	}
+	for _, d := range h.Diamonds() {
+		jh.Diamonds = append(jh.Diamonds, jsonDiamond{Type: d.Type.ID, Ancestor: d.Ancestor.ID, Via: ids(d.Via)})
+	}
+	enc := json.NewEncoder(w)
+	enc.SetEscapeHTML(false)
+	return enc.Encode(jh)
+}
+
+func ids(types []*Type) []int {
+	ids := make([]int, len(types))
+	for i, t := range types {
+		ids[i] = t.ID
+	}
+	return ids
+}
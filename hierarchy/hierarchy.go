@@ -0,0 +1,245 @@
+// Copyright 2014-2015 The DevMine Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package hierarchy builds the type hierarchy of a project: the inheritance,
+// interface implementation and mixin relationships between its classes,
+// interfaces, enums and traits.
+//
+// Supertypes that are not part of the project (e.g. a class of the standard
+// library) are represented by external types, named after the reference.
+// References that cannot be resolved are ignored (see the resolver package).
+package hierarchy
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/DevMine/srcanlzr/resolver"
+	"github.com/DevMine/srcanlzr/src"
+	"github.com/DevMine/srcanlzr/src/ast"
+)
+
+// A Relation describes how a type relates to one of its supertypes.
+type Relation string
+
+// Relations between types
+const (
+	Extends    Relation = "extends"    // class or interface inheritance
+	Implements Relation = "implements" // interface implementation
+	Mixes      Relation = "mixes"      // trait mixin
+)
+
+// External is the kind of the types that are not part of the project.
+const External = "external"
+
+// A Type is a node of the hierarchy.
+type Type struct {
+	ID     int    // index of the type in Hierarchy.Types
+	Name   string // qualified name of the symbol, or name of the reference of external types
+	Kind   string // kind of the symbol (e.g. "class"), or External
+	Symbol *resolver.Symbol
+
+	Supers []*Link // links to the direct supertypes
+	Subs   []*Link // links to the direct subtypes
+}
+
+// IsExternal reports whether the type is not part of the project.
+func (t *Type) IsExternal() bool {
+	return t.Symbol == nil
+}
+
+// A Link relates a type to one of its direct supertypes.
+type Link struct {
+	Sub      *Type
+	Super    *Type
+	Relation Relation
+}
+
+// A Hierarchy is the type hierarchy of a project.
+type Hierarchy struct {
+	Types []*Type
+	Links []*Link
+
+	bySymbol   map[*resolver.Symbol]*Type
+	byExternal map[string]*Type
+}
+
+// New builds the type hierarchy of a project.
+func New(p *src.Project) *Hierarchy {
+	return FromTable(resolver.Resolve(p))
+}
+
+// FromTable builds the type hierarchy from a resolved symbol table.
+func FromTable(t *resolver.Table) *Hierarchy {
+	h := &Hierarchy{
+		bySymbol:   make(map[*resolver.Symbol]*Type),
+		byExternal: make(map[string]*Type),
+	}
+
+	for _, sym := range t.Symbols {
+		switch sym.Kind {
+		case resolver.Class, resolver.Interface, resolver.Enum, resolver.Trait:
+			h.symbolType(sym)
+		}
+	}
+
+	for _, sym := range t.Symbols {
+		typ := h.bySymbol[sym]
+		if typ == nil {
+			continue
+		}
+		switch d := sym.Decl.(type) {
+		case *ast.ClassDecl:
+			for _, ref := range d.ExtendedClasses {
+				h.link(t, typ, ref, Extends)
+			}
+			for _, ref := range d.ImplementedInterfaces {
+				h.link(t, typ, ref, Implements)
+			}
+			for _, ref := range d.Mixins {
+				h.link(t, typ, ref, Mixes)
+			}
+		case *ast.Interface:
+			for _, ref := range d.ImplementedInterfaces {
+				h.link(t, typ, ref, Extends)
+			}
+		case *ast.EnumDecl:
+			for _, ref := range d.ImplementedInterfaces {
+				h.link(t, typ, ref, Implements)
+			}
+		}
+	}
+	return h
+}
+
+func (h *Hierarchy) addType(typ *Type) *Type {
+	typ.ID = len(h.Types)
+	h.Types = append(h.Types, typ)
+	return typ
+}
+
+func (h *Hierarchy) symbolType(sym *resolver.Symbol) *Type {
+	if typ := h.bySymbol[sym]; typ != nil {
+		return typ
+	}
+	typ := h.addType(&Type{Name: sym.QualifiedName(), Kind: string(sym.Kind), Symbol: sym})
+	h.bySymbol[sym] = typ
+	return typ
+}
+
+// link adds a link from sub to the supertype referenced by node.
+func (h *Hierarchy) link(t *resolver.Table, sub *Type, node interface{}, rel Relation) {
+	ref := t.Reference(node)
+	if ref == nil || ref.Name == "" {
+		return
+	}
+
+	var super *Type
+	switch {
+	case ref.Target != nil:
+		super = h.symbolType(ref.Target)
+	case ref.External:
+		name := ref.Name
+		if ref.Namespace != "" {
+			name = ref.Namespace + "." + ref.Name
+		}
+		if super = h.byExternal[name]; super == nil {
+			super = h.addType(&Type{Name: name, Kind: External})
+			h.byExternal[name] = super
+		}
+	default:
+		return
+	}
+
+	l := &Link{Sub: sub, Super: super, Relation: rel}
+	h.Links = append(h.Links, l)
+	sub.Supers = append(sub.Supers, l)
+	super.Subs = append(super.Subs, l)
+}
+
+// Type returns the type declared by a symbol, or nil.
+func (h *Hierarchy) Type(sym *resolver.Symbol) *Type {
+	return h.bySymbol[sym]
+}
+
+// Lookup returns the type named name: either its qualified name or a suffix of
+// it starting after a dot or a slash. An error is returned if no type or more
+// than one type match.
+func (h *Hierarchy) Lookup(name string) (*Type, error) {
+	var found []*Type
+	for _, typ := range h.Types {
+		if typ.Name == name {
+			return typ, nil
+		}
+		if strings.HasSuffix(typ.Name, "."+name) || strings.HasSuffix(typ.Name, "/"+name) {
+			found = append(found, typ)
+		}
+	}
+	switch len(found) {
+	case 0:
+		return nil, fmt.Errorf("hierarchy: no type named %s", name)
+	case 1:
+		return found[0], nil
+	}
+	names := make([]string, len(found))
+	for i, typ := range found {
+		names[i] = typ.Name
+	}
+	return nil, fmt.Errorf("hierarchy: ambiguous type name %s: %s", name, strings.Join(names, ", "))
+}
+
+// Ancestors returns all the supertypes of typ, direct or not, closest first.
+func (h *Hierarchy) Ancestors(typ *Type) []*Type {
+	return walk(typ, func(t *Type) []*Type {
+		supers := make([]*Type, len(t.Supers))
+		for i, l := range t.Supers {
+			supers[i] = l.Super
+		}
+		return supers
+	})
+}
+
+// Descendants returns all the subtypes of typ, direct or not, closest first.
+func (h *Hierarchy) Descendants(typ *Type) []*Type {
+	return walk(typ, func(t *Type) []*Type {
+		subs := make([]*Type, len(t.Subs))
+		for i, l := range t.Subs {
+			subs[i] = l.Sub
+		}
+		return subs
+	})
+}
+
+// Implementors returns the classes and enums implementing the interface
+// iface, either directly, through a subinterface or through a superclass.
+func (h *Hierarchy) Implementors(iface *Type) []*Type {
+	var impls []*Type
+	for _, typ := range h.Descendants(iface) {
+		if typ.Kind == string(resolver.Class) || typ.Kind == string(resolver.Enum) {
+			impls = append(impls, typ)
+		}
+	}
+	return impls
+}
+
+// walk returns the types reachable from typ, in breadth-first order,
+// excluding typ itself.
+func walk(typ *Type, next func(*Type) []*Type) []*Type {
+	var types []*Type
+	seen := map[*Type]bool{typ: true}
+	for cur := []*Type{typ}; len(cur) > 0; {
+		var nodes []*Type
+		for _, t := range cur {
+			for _, n := range next(t) {
+				if !seen[n] {
+					seen[n] = true
+					types = append(types, n)
+					nodes = append(nodes, n)
+				}
+			}
+		}
+		cur = nodes
+	}
+	return types
+}
@@ -0,0 +1,167 @@
+// Copyright 2014-2015 The DevMine Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package hierarchy
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/DevMine/srcanlzr/src"
+	"github.com/DevMine/srcanlzr/src/ast"
+)
+
+func class(name string, extends []string, implements []string, mixins ...string) *ast.ClassDecl {
+	c := &ast.ClassDecl{Name: name}
+	for _, n := range extends {
+		c.ExtendedClasses = append(c.ExtendedClasses, &ast.ClassRef{ClassName: n})
+	}
+	for _, n := range implements {
+		c.ImplementedInterfaces = append(c.ImplementedInterfaces, &ast.InterfaceRef{InterfaceName: n})
+	}
+	for _, n := range mixins {
+		c.Mixins = append(c.Mixins, &ast.TraitRef{TraitName: n})
+	}
+	return c
+}
+
+func newProject() *src.Project {
+	return &src.Project{
+		Name: "foo",
+		Packages: []*src.Package{
+			&src.Package{
+				Path: "p",
+				SrcFiles: []*src.SrcFile{
+					&src.SrcFile{
+						Path: "p/Shapes.java",
+						Interfaces: []*ast.Interface{
+							&ast.Interface{Name: "Shape"},
+							&ast.Interface{Name: "Named"},
+							&ast.Interface{Name: "Solid", ImplementedInterfaces: []*ast.InterfaceRef{&ast.InterfaceRef{InterfaceName: "Shape"}}},
+						},
+						Classes: []*ast.ClassDecl{
+							class("Base", nil, []string{"Shape", "Named"}),
+							class("Square", []string{"Base"}, []string{"Solid"}),
+							class("Failure", []string{"Exception"}, nil),
+							class("A", []string{"B"}, nil),
+							class("B", []string{"A"}, nil),
+							class("Mixed", nil, nil, "Printable"),
+						},
+						Enums: []*ast.EnumDecl{
+							&ast.EnumDecl{Name: "Color", ImplementedInterfaces: []*ast.InterfaceRef{&ast.InterfaceRef{InterfaceName: "Named"}}},
+						},
+						Traits: []*ast.Trait{&ast.Trait{Name: "Printable"}},
+					},
+				},
+			},
+		},
+	}
+}
+
+func names(types []*Type) string {
+	strs := make([]string, len(types))
+	for i, t := range types {
+		strs[i] = t.Name
+	}
+	return strings.Join(strs, ", ")
+}
+
+func lookup(t *testing.T, h *Hierarchy, name string) *Type {
+	typ, err := h.Lookup(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return typ
+}
+
+func TestNew(t *testing.T) {
+	h := New(newProject())
+
+	var links []string
+	for _, l := range h.Links {
+		links = append(links, l.Sub.Name+" "+string(l.Relation)+" "+l.Super.Name)
+	}
+	expected := "p.Solid extends p.Shape, p.Base implements p.Shape, p.Base implements p.Named, " +
+		"p.Square extends p.Base, p.Square implements p.Solid, p.Failure extends Exception, " +
+		"p.A extends p.B, p.B extends p.A, p.Mixed mixes p.Printable, p.Color implements p.Named"
+	if found := strings.Join(links, ", "); found != expected {
+		t.Errorf("New: found links %s, expected %s", found, expected)
+	}
+
+	if typ := lookup(t, h, "Exception"); !typ.IsExternal() || typ.Kind != External {
+		t.Errorf("Lookup Exception: found kind %s, expected %s", typ.Kind, External)
+	}
+	if _, err := h.Lookup("Circle"); err == nil {
+		t.Error("Lookup Circle: found no error, expected an error")
+	}
+}
+
+func TestQueries(t *testing.T) {
+	h := New(newProject())
+
+	input := []struct {
+		query    func(*Type) []*Type
+		name     string
+		expected string
+	}{
+		{h.Ancestors, "Square", "p.Base, p.Solid, p.Shape, p.Named"},
+		{h.Ancestors, "Failure", "Exception"},
+		{h.Ancestors, "A", "p.B"},
+		{h.Descendants, "Shape", "p.Solid, p.Base, p.Square"},
+		{h.Descendants, "Printable", "p.Mixed"},
+		{h.Implementors, "Shape", "p.Base, p.Square"},
+		{h.Implementors, "Named", "p.Base, p.Color, p.Square"},
+	}
+	for _, in := range input {
+		if found := names(in.query(lookup(t, h, in.name))); found != in.expected {
+			t.Errorf("%s: found %s, expected %s", in.name, found, in.expected)
+		}
+	}
+}
+
+func TestCycles(t *testing.T) {
+	cycles := New(newProject()).Cycles()
+	if len(cycles) != 1 {
+		t.Fatalf("Cycles: found %d cycles, expected 1", len(cycles))
+	}
+	if found := names(cycles[0]); found != "p.A, p.B" {
+		t.Errorf("Cycles: found %s, expected p.A, p.B", found)
+	}
+}
+
+func TestDiamonds(t *testing.T) {
+	diamonds := New(newProject()).Diamonds()
+	if len(diamonds) != 1 {
+		t.Fatalf("Diamonds: found %d diamonds, expected 1", len(diamonds))
+	}
+	d := diamonds[0]
+	if d.Type.Name != "p.Square" || d.Ancestor.Name != "p.Shape" || names(d.Via) != "p.Base, p.Solid" {
+		t.Errorf("Diamonds: found %s inheriting %s via %s, expected p.Square inheriting p.Shape via p.Base, p.Solid",
+			d.Type.Name, d.Ancestor.Name, names(d.Via))
+	}
+}
+
+func TestWrite(t *testing.T) {
+	h := New(newProject())
+
+	input := []struct {
+		write    func(*bytes.Buffer) error
+		expected []string
+	}{
+		{func(b *bytes.Buffer) error { return h.WriteDOT(b) }, []string{"digraph hierarchy {", `n0 [label="p.Shape", fontname="Helvetica-Oblique"];`, `n3 -> n0 [style=dashed];`}},
+		{func(b *bytes.Buffer) error { return h.WriteJSON(b) }, []string{`{"id":0,"name":"p.Shape","kind":"interface","package":"p","file":"p/Shapes.java"}`, `{"sub":2,"super":0,"relation":"extends"}`, `"cycles":[[6,7]]`, `"diamonds":[{"type":4,"ancestor":0,"via":[3,2]}]`}},
+	}
+	for i, in := range input {
+		buf := new(bytes.Buffer)
+		if err := in.write(buf); err != nil {
+			t.Fatal(err)
+		}
+		for _, s := range in.expected {
+			if !strings.Contains(buf.String(), s) {
+				t.Errorf("Write %d: %q not found in\n%s", i, s, buf.String())
+			}
+		}
+	}
+}
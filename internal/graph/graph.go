@@ -0,0 +1,75 @@
+// Copyright 2014-2015 The DevMine Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package graph provides algorithms on directed graphs whose nodes are the
+// integers from 0 to n-1, represented by their adjacency lists: g[v] lists the
+// successors of the node v.
+package graph
+
+import "sort"
+
+// Cycles returns the strongly connected components of g which contain a cycle,
+// i.e. the ones having at least two nodes or a single node which is its own
+// successor, using Tarjan's algorithm. The nodes of each component are sorted.
+func Cycles(g [][]int) [][]int {
+	index := make([]int, len(g))
+	lowlink := make([]int, len(g))
+	onStack := make([]bool, len(g))
+	for v := range index {
+		index[v] = -1
+	}
+
+	var (
+		stack  []int
+		cycles [][]int
+		count  int
+	)
+	var connect func(v int)
+	connect = func(v int) {
+		index[v], lowlink[v] = count, count
+		count++
+		stack = append(stack, v)
+		onStack[v] = true
+
+		selfLoop := false
+		for _, w := range g[v] {
+			if w == v {
+				selfLoop = true
+			}
+			if index[w] < 0 {
+				connect(w)
+				if lowlink[w] < lowlink[v] {
+					lowlink[v] = lowlink[w]
+				}
+			} else if onStack[w] && index[w] < lowlink[v] {
+				lowlink[v] = index[w]
+			}
+		}
+
+		if lowlink[v] != index[v] {
+			return
+		}
+		var scc []int
+		for {
+			w := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			onStack[w] = false
+			scc = append(scc, w)
+			if w == v {
+				break
+			}
+		}
+		if len(scc) > 1 || selfLoop {
+			sort.Ints(scc)
+			cycles = append(cycles, scc)
+		}
+	}
+
+	for v := range g {
+		if index[v] < 0 {
+			connect(v)
+		}
+	}
+	return cycles
+}
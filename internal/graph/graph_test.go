@@ -0,0 +1,23 @@
+// Copyright 2014-2015 The DevMine Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package graph
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCycles(t *testing.T) {
+	// 0 -> 1 -> 2 -> 0, 3 -> 3, 4 -> 0, 5 -> 6 -> 5
+	g := [][]int{{1}, {2}, {0}, {3}, {0}, {6}, {5}}
+	expected := [][]int{{0, 1, 2}, {3}, {5, 6}}
+	if found := Cycles(g); !reflect.DeepEqual(found, expected) {
+		t.Errorf("Cycles: found %v, expected %v", found, expected)
+	}
+
+	if found := Cycles([][]int{{1}, {}}); found != nil {
+		t.Errorf("Cycles: found %v, expected none", found)
+	}
+}
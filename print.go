@@ -0,0 +1,156 @@
+// Copyright 2014-2015 The DevMine Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/DevMine/srcanlzr/src"
+	"github.com/DevMine/srcanlzr/src/ast"
+	"github.com/DevMine/srcanlzr/src/printer"
+)
+
+var cmdPrint = &command{
+	name:  "print",
+	usage: "[-go] [-nodoc] [-tabwidth N] FILE[:NAME] [JSON PATH]",
+	short: "print a source file, or one of its declarations, as pseudo-code",
+	run:   runPrint,
+}
+
+func runPrint(cmd *command, args []string) {
+	fs := flag.NewFlagSet(cmd.name, flag.ExitOnError)
+	goSyntax := fs.Bool("go", false, "Use a Go-like syntax instead of pseudo-code.")
+	noDoc := fs.Bool("nodoc", false, "Do not print the documentation.")
+	tabwidth := fs.Int("tabwidth", 2, "Number of spaces per indentation level (0 for tabs).")
+	fs.Usage = func() {
+		fmt.Printf("usage: %s %s %s\n\n", os.Args[0], cmd.name, cmd.usage)
+		fmt.Println("FILE is matched against the end of the source files paths. NAME is the")
+		fmt.Println("name of a function, class, interface, enum or trait declared in FILE.")
+		fmt.Print("Methods are designated as CLASS.METHOD.\n\n")
+		fs.PrintDefaults()
+		os.Exit(0)
+	}
+	fs.Parse(args)
+
+	if l := len(fs.Args()); l == 0 || l > 2 {
+		fmt.Fprint(os.Stderr, "wrong number of arguments\n\n")
+		fs.Usage()
+	}
+
+	p, err := decodeProject(fs.Arg(1))
+	if err != nil {
+		fatal(err)
+	}
+
+	path, name := fs.Arg(0), ""
+	if i := strings.LastIndex(path, ":"); i >= 0 {
+		path, name = path[:i], path[i+1:]
+	}
+
+	sf := findSrcFile(p, path)
+	if sf == nil {
+		fatal(fmt.Errorf("%s: no such source file", path))
+	}
+
+	var node interface{} = sf
+	if name != "" {
+		if node = findDecl(sf, name); node == nil {
+			fatal(fmt.Errorf("%s: %s not found", path, name))
+		}
+	}
+
+	cfg := printer.Config{Tabwidth: *tabwidth}
+	if *goSyntax {
+		cfg.Mode |= printer.GoSyntax
+	}
+	if *noDoc {
+		cfg.Mode |= printer.SkipDocs
+	}
+	if err := cfg.Fprint(os.Stdout, node); err != nil {
+		fatal(err)
+	}
+}
+
+// findSrcFile returns the source file of the project whose path is path or
+// ends with "/" + path.
+func findSrcFile(p *src.Project, path string) *src.SrcFile {
+	for _, pkg := range p.Packages {
+		for _, sf := range pkg.SrcFiles {
+			if sf.Path == path || strings.HasSuffix(sf.Path, "/"+path) {
+				return sf
+			}
+		}
+	}
+	return nil
+}
+
+// findDecl returns the declaration of the source file named name, or nil if
+// there is no such declaration. Methods are looked up as "Type.method".
+func findDecl(sf *src.SrcFile, name string) interface{} {
+	if i := strings.Index(name, "."); i >= 0 {
+		typ, meth := name[:i], name[i+1:]
+		var methods []*ast.MethodDecl
+		if c := findClass(sf.Classes, typ); c != nil {
+			methods = c.Methods
+		}
+		for _, e := range sf.Enums {
+			if e.Name == typ {
+				methods = e.Methods
+			}
+		}
+		for _, t := range sf.Traits {
+			if t.Name == typ {
+				methods = t.Methods
+			}
+		}
+		for _, m := range methods {
+			if m.Name == meth {
+				return m
+			}
+		}
+		return nil
+	}
+
+	for _, f := range sf.Funcs {
+		if f.Name == name {
+			return f
+		}
+	}
+	if c := findClass(sf.Classes, name); c != nil {
+		return c
+	}
+	for _, i := range sf.Interfaces {
+		if i.Name == name {
+			return i
+		}
+	}
+	for _, e := range sf.Enums {
+		if e.Name == name {
+			return e
+		}
+	}
+	for _, t := range sf.Traits {
+		if t.Name == name {
+			return t
+		}
+	}
+	return nil
+}
+
+// findClass looks for a class named name, including nested classes.
+func findClass(classes []*ast.ClassDecl, name string) *ast.ClassDecl {
+	for _, c := range classes {
+		if c.Name == name {
+			return c
+		}
+		if nested := findClass(c.NestedClasses, name); nested != nil {
+			return nested
+		}
+	}
+	return nil
+}
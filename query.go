@@ -0,0 +1,70 @@
+// Copyright 2014-2015 The DevMine Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/DevMine/srcanlzr/query"
+)
+
+var cmdQuery = &command{
+	name:  "query",
+	usage: "[-nonode] [-indent] QUERY [JSON PATH]",
+	short: "print the nodes matching a query, and their locations, in JSON",
+	run:   runQuery,
+}
+
+func runQuery(cmd *command, args []string) {
+	fs := flag.NewFlagSet(cmd.name, flag.ExitOnError)
+	noNode := fs.Bool("nonode", false, "Only print the locations of the matching nodes.")
+	indent := fs.Bool("indent", false, "Indent the JSON output.")
+	fs.Usage = func() {
+		fmt.Printf("usage: %s %s %s\n\n", os.Args[0], cmd.name, cmd.usage)
+		fmt.Println("QUERY is a list of selectors, such as:")
+		fmt.Print("    package[path$=x] method[visibility=public][loc>80]\n\n")
+		fmt.Print("See the documentation of the query package for the syntax.\n\n")
+		fs.PrintDefaults()
+		os.Exit(0)
+	}
+	fs.Parse(args)
+
+	if l := len(fs.Args()); l == 0 || l > 2 {
+		fmt.Fprint(os.Stderr, "wrong number of arguments\n\n")
+		fs.Usage()
+	}
+
+	q, err := query.Compile(fs.Arg(0))
+	if err != nil {
+		fatal(err)
+	}
+
+	p, err := decodeProject(fs.Arg(1))
+	if err != nil {
+		fatal(err)
+	}
+
+	matches := q.Find(p)
+	if *noNode {
+		for _, m := range matches {
+			m.Node = nil
+		}
+	}
+
+	var bs []byte
+	if *indent {
+		bs, err = json.MarshalIndent(matches, "", "  ")
+	} else {
+		bs, err = json.Marshal(matches)
+	}
+	if err != nil {
+		fatal(err)
+	}
+
+	fmt.Println(string(bs))
+}
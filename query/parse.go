@@ -0,0 +1,218 @@
+// Copyright 2014-2015 The DevMine Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package query
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// operators of the attribute predicates, longest first
+var operators = []string{"!=", "^=", "$=", "*=", "~=", "<=", ">=", "=", "<", ">"}
+
+// A parser compiles the textual representation of a query.
+type parser struct {
+	src string
+	pos int
+}
+
+func (p *parser) errorf(format string, a ...interface{}) error {
+	return fmt.Errorf("query: %s at offset %d in %q", fmt.Sprintf(format, a...), p.pos, p.src)
+}
+
+func (p *parser) eof() bool {
+	return p.pos >= len(p.src)
+}
+
+func (p *parser) peek() byte {
+	if p.eof() {
+		return 0
+	}
+	return p.src[p.pos]
+}
+
+// skipSpaces skips white spaces and reports whether at least one was found.
+func (p *parser) skipSpaces() bool {
+	start := p.pos
+	for !p.eof() && isSpace(p.src[p.pos]) {
+		p.pos++
+	}
+	return p.pos > start
+}
+
+// word scans a sequence of characters for which accept returns true.
+func (p *parser) word(accept func(c byte) bool) string {
+	start := p.pos
+	for !p.eof() && accept(p.src[p.pos]) {
+		p.pos++
+	}
+	return p.src[start:p.pos]
+}
+
+func (p *parser) query() ([]*selector, error) {
+	var sels []*selector
+	for {
+		p.skipSpaces()
+		sel, err := p.selector()
+		if err != nil {
+			return nil, err
+		}
+		sels = append(sels, sel)
+
+		p.skipSpaces()
+		if p.eof() {
+			return sels, nil
+		}
+		if p.peek() != ',' {
+			return nil, p.errorf("unexpected %q", p.peek())
+		}
+		p.pos++
+	}
+}
+
+func (p *parser) selector() (*selector, error) {
+	sel := &selector{}
+	comb := descendant
+	for {
+		st, err := p.step()
+		if err != nil {
+			return nil, err
+		}
+		st.comb = comb
+		sel.steps = append(sel.steps, st)
+
+		spaces := p.skipSpaces()
+		switch c := p.peek(); {
+		case c == 0 || c == ',':
+			return sel, nil
+		case c == '>':
+			p.pos++
+			p.skipSpaces()
+			comb = child
+		case spaces:
+			comb = descendant
+		default:
+			return nil, p.errorf("unexpected %q", c)
+		}
+	}
+}
+
+func (p *parser) step() (*step, error) {
+	st := &step{}
+	if p.peek() == '*' {
+		p.pos++
+	} else {
+		if st.kind = strings.ToLower(p.word(isIdentChar)); st.kind == "" {
+			return nil, p.errorf("expected node kind")
+		}
+	}
+
+	for p.peek() == '[' {
+		p.pos++
+		pred, err := p.predicate()
+		if err != nil {
+			return nil, err
+		}
+		st.preds = append(st.preds, pred)
+	}
+	return st, nil
+}
+
+func (p *parser) predicate() (*predicate, error) {
+	p.skipSpaces()
+	pred := &predicate{}
+	for {
+		name := p.word(isIdentChar)
+		if name == "" {
+			return nil, p.errorf("expected attribute name")
+		}
+		pred.attr = append(pred.attr, name)
+		if p.peek() != '.' {
+			break
+		}
+		p.pos++
+	}
+	p.skipSpaces()
+
+	if p.peek() == ']' {
+		p.pos++
+		return pred, nil
+	}
+
+	for _, op := range operators {
+		if strings.HasPrefix(p.src[p.pos:], op) {
+			pred.op = op
+			p.pos += len(op)
+			break
+		}
+	}
+	if pred.op == "" {
+		return nil, p.errorf("expected operator or ']'")
+	}
+	p.skipSpaces()
+
+	val, err := p.value()
+	if err != nil {
+		return nil, err
+	}
+	pred.value = val
+
+	switch pred.op {
+	case "<", "<=", ">", ">=":
+		if pred.num, err = strconv.ParseFloat(val, 64); err != nil {
+			return nil, p.errorf("operator %s expects a number, found %q", pred.op, val)
+		}
+	case "~=":
+		if pred.re, err = regexp.Compile(val); err != nil {
+			return nil, p.errorf("invalid regular expression: %v", err)
+		}
+	}
+
+	p.skipSpaces()
+	if p.peek() != ']' {
+		return nil, p.errorf("expected ']'")
+	}
+	p.pos++
+	return pred, nil
+}
+
+// value scans either a double quoted string or a bare word.
+func (p *parser) value() (string, error) {
+	if p.peek() != '"' {
+		val := p.word(func(c byte) bool {
+			return c != ']' && !isSpace(c)
+		})
+		if val == "" {
+			return "", p.errorf("expected value")
+		}
+		return val, nil
+	}
+
+	start := p.pos
+	for p.pos++; !p.eof() && p.src[p.pos] != '"'; p.pos++ {
+		if p.src[p.pos] == '\\' {
+			p.pos++
+		}
+	}
+	if p.eof() {
+		return "", p.errorf("unterminated string")
+	}
+	p.pos++
+	val, err := strconv.Unquote(p.src[start:p.pos])
+	if err != nil {
+		return "", p.errorf("invalid string: %v", err)
+	}
+	return val, nil
+}
+
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r'
+}
+
+func isIdentChar(c byte) bool {
+	return c == '_' || 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z' || '0' <= c && c <= '9'
+}
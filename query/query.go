@@ -0,0 +1,453 @@
+// Copyright 2014-2015 The DevMine Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package query implements a selector language, similar to CSS selectors, for
+// searching the nodes of a project.
+//
+// A query is a list of selectors separated by commas. A node matches the query
+// if it matches any of the selectors. A selector is a sequence of steps
+// separated either by white spaces, meaning that the node matched by the right
+// step must be a descendant of the one matched by the left step, or by '>',
+// meaning that it must be a direct child:
+//    query     = selector { "," selector }
+//    selector  = step { [ ">" ] step }
+//    step      = ( kind | "*" ) { "[" predicate "]" }
+//    predicate = attribute [ operator value ]
+//    attribute = name { "." name }
+//
+// The kind of a node is the name of its type in the src or ast packages (e.g.
+// Package, SrcFile, FuncDecl, CallExpr), matched case-insensitively. The Decl,
+// Expr and Stmt suffixes may be omitted (e.g. method, call, if) and SrcFile
+// may be written file. '*' matches any node.
+//
+// Attributes are designated by the JSON names of the fields of the node (e.g.
+// name, visibility, loc, line) and may follow the nested nodes (e.g.
+// function.namespace for a CallExpr). Identifiers are compared using their
+// name and languages using their name too. A predicate without an operator
+// holds when the attribute is set to a non-zero value. Otherwise, the
+// following operators are available:
+//    =  !=       equal, not equal
+//    ^= $= *=    has prefix, has suffix, contains
+//    ~=          matches the regular expression (see the regexp package)
+//    < <= > >=   numeric comparisons; lists are compared using their length
+//
+// Values are either double quoted Go strings or bare words. For instance:
+//    package[path$=x] method[visibility=public][loc>80]
+//    call[function.namespace="crypto/md5"]
+//    func[type.parameters>5], method[type.parameters>5]
+package query
+
+import (
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/DevMine/srcanlzr/src"
+	"github.com/DevMine/srcanlzr/src/ast"
+)
+
+// A combinator specifies the relationship between the nodes matched by two
+// consecutive steps of a selector.
+type combinator int
+
+const (
+	descendant combinator = iota
+	child
+)
+
+type selector struct {
+	steps []*step
+}
+
+type step struct {
+	comb  combinator // relationship with the previous step
+	kind  string     // lower case kind; or empty for any kind
+	preds []*predicate
+}
+
+type predicate struct {
+	attr  []string
+	op    string // empty for an existence test
+	value string
+	num   float64        // value of numeric comparisons
+	re    *regexp.Regexp // compiled value of "~="
+}
+
+// A Query is the compiled representation of a query. It can be used safely
+// by multiple goroutines.
+type Query struct {
+	src  string
+	sels []*selector
+}
+
+// Compile parses a query and returns, if successful, a Query that can be used
+// to search projects.
+func Compile(s string) (*Query, error) {
+	p := &parser{src: s}
+	sels, err := p.query()
+	if err != nil {
+		return nil, err
+	}
+	return &Query{src: s, sels: sels}, nil
+}
+
+// MustCompile is like Compile but panics if the query cannot be parsed.
+func MustCompile(s string) *Query {
+	q, err := Compile(s)
+	if err != nil {
+		panic(err)
+	}
+	return q
+}
+
+// String returns the source text used to compile the query.
+func (q *Query) String() string {
+	return q.src
+}
+
+// A Match represents a node matching a query.
+type Match struct {
+	Kind    string `json:"kind"`              // type name of the node (e.g. "FuncDecl")
+	Name    string `json:"name,omitempty"`    // name of the node; or empty
+	Package string `json:"package,omitempty"` // path of the enclosing package
+	File    string `json:"file,omitempty"`    // path of the enclosing source file
+
+	// Names of the enclosing declarations, separated by dots (e.g.
+	// "Foo.bar" for a statement of the method bar of the class Foo).
+	Scope string `json:"scope,omitempty"`
+
+	// Line number of the node or, when the node does not have one, of the
+	// closest enclosing node that does.
+	Line int64 `json:"line,omitempty"`
+
+	Node interface{} `json:"node,omitempty"`
+}
+
+// Find returns the nodes of the project matching the query, in depth-first
+// order.
+func (q *Query) Find(p *src.Project) []*Match {
+	f := &finder{q: q, matches: []*Match{}}
+	if !f.visit(p) {
+		return f.matches
+	}
+	for _, pkg := range p.Packages {
+		if !f.visit(pkg) {
+			continue
+		}
+		for _, sf := range pkg.SrcFiles {
+			if !f.visit(sf) {
+				continue
+			}
+			for _, x := range sf.Imports {
+				ast.Inspect(x, f.visit)
+			}
+			for _, x := range sf.TypeSpecs {
+				ast.Inspect(x, f.visit)
+			}
+			for _, x := range sf.Structs {
+				ast.Inspect(x, f.visit)
+			}
+			for _, x := range sf.Constants {
+				ast.Inspect(x, f.visit)
+			}
+			for _, x := range sf.Vars {
+				ast.Inspect(x, f.visit)
+			}
+			for _, x := range sf.Funcs {
+				ast.Inspect(x, f.visit)
+			}
+			for _, x := range sf.Interfaces {
+				ast.Inspect(x, f.visit)
+			}
+			for _, x := range sf.Classes {
+				ast.Inspect(x, f.visit)
+			}
+			for _, x := range sf.Enums {
+				ast.Inspect(x, f.visit)
+			}
+			for _, x := range sf.Traits {
+				ast.Inspect(x, f.visit)
+			}
+			for _, x := range sf.Comments {
+				ast.Inspect(x, f.visit)
+			}
+			f.visit(nil)
+		}
+		f.visit(nil)
+	}
+	f.visit(nil)
+	return f.matches
+}
+
+// A finder collects the nodes matching a query while a project is traversed.
+type finder struct {
+	q       *Query
+	stack   []interface{} // path from the project to the current node
+	matches []*Match
+}
+
+// visit is called with each node in depth-first order, and with nil once all
+// the children of a node have been visited. It always returns true, unless
+// node is nil or a typed nil pointer.
+func (f *finder) visit(node interface{}) bool {
+	if node == nil {
+		f.stack = f.stack[:len(f.stack)-1]
+		return false
+	}
+	if v := reflect.ValueOf(node); v.Kind() == reflect.Ptr && v.IsNil() {
+		return false
+	}
+
+	f.stack = append(f.stack, node)
+	for _, sel := range f.q.sels {
+		if sel.match(f.stack, len(f.stack)-1, len(sel.steps)-1) {
+			f.matches = append(f.matches, f.newMatch())
+			break
+		}
+	}
+	return true
+}
+
+// newMatch returns a Match for the node at the top of the stack.
+func (f *finder) newMatch() *Match {
+	node := f.stack[len(f.stack)-1]
+	m := &Match{Kind: kindOf(node), Node: node}
+	if v, ok := lookup(node, []string{"name"}); ok {
+		m.Name, _ = stringOf(v)
+	}
+
+	var scope []string
+	for i, x := range f.stack {
+		switch x := x.(type) {
+		case *src.Package:
+			m.Package = x.Path
+			continue
+		case *src.SrcFile:
+			m.File = x.Path
+			continue
+		case *src.Project:
+			continue
+		}
+		if v, ok := lookup(x, []string{"line"}); ok && v.Int() != 0 {
+			m.Line = v.Int()
+		}
+		if i == len(f.stack)-1 {
+			break
+		}
+		if v, ok := lookup(x, []string{"name"}); ok {
+			if name, _ := stringOf(v); name != "" {
+				scope = append(scope, name)
+			}
+		}
+	}
+	m.Scope = strings.Join(scope, ".")
+	return m
+}
+
+// match reports whether the node at index i of the stack matches the step k of
+// the selector, together with the previous steps.
+func (sel *selector) match(stack []interface{}, i, k int) bool {
+	st := sel.steps[k]
+	if !st.match(stack[i]) {
+		return false
+	}
+	if k == 0 {
+		return true
+	}
+	if st.comb == child {
+		return i > 0 && sel.match(stack, i-1, k-1)
+	}
+	for j := i - 1; j >= 0; j-- {
+		if sel.match(stack, j, k-1) {
+			return true
+		}
+	}
+	return false
+}
+
+func (st *step) match(node interface{}) bool {
+	if st.kind != "" {
+		kind := strings.ToLower(kindOf(node))
+		if st.kind != kind && st.kind != shortKind(kind) && !(st.kind == "file" && kind == "srcfile") {
+			return false
+		}
+	}
+	for _, pred := range st.preds {
+		if !pred.match(node) {
+			return false
+		}
+	}
+	return true
+}
+
+func (pred *predicate) match(node interface{}) bool {
+	v, ok := lookup(node, pred.attr)
+	if !ok {
+		return false
+	}
+
+	switch pred.op {
+	case "":
+		switch v.Kind() {
+		case reflect.Slice:
+			return v.Len() > 0
+		case reflect.Ptr, reflect.Interface:
+			return !v.IsNil()
+		}
+		return !v.IsZero()
+	case "<", "<=", ">", ">=":
+		n, ok := numberOf(v)
+		if !ok {
+			return false
+		}
+		switch pred.op {
+		case "<":
+			return n < pred.num
+		case "<=":
+			return n <= pred.num
+		case ">":
+			return n > pred.num
+		}
+		return n >= pred.num
+	}
+
+	if n, ok := numberOf(v); ok && v.Kind() != reflect.Slice {
+		if x, err := strconv.ParseFloat(pred.value, 64); err == nil {
+			switch pred.op {
+			case "=":
+				return n == x
+			case "!=":
+				return n != x
+			}
+		}
+	}
+
+	s, ok := stringOf(v)
+	if !ok {
+		return false
+	}
+	switch pred.op {
+	case "=":
+		return s == pred.value
+	case "!=":
+		return s != pred.value
+	case "^=":
+		return strings.HasPrefix(s, pred.value)
+	case "$=":
+		return strings.HasSuffix(s, pred.value)
+	case "*=":
+		return strings.Contains(s, pred.value)
+	case "~=":
+		return pred.re.MatchString(s)
+	}
+	return false
+}
+
+// kindOf returns the name of the type of a node.
+func kindOf(node interface{}) string {
+	return reflect.Indirect(reflect.ValueOf(node)).Type().Name()
+}
+
+// shortKind strips the decl, expr or stmt suffix of a lower case kind.
+func shortKind(kind string) string {
+	for _, suffix := range []string{"decl", "expr", "stmt"} {
+		if strings.HasSuffix(kind, suffix) && len(kind) > len(suffix) {
+			return strings.TrimSuffix(kind, suffix)
+		}
+	}
+	return kind
+}
+
+// lookup returns the value of the attribute of node designated by path.
+func lookup(node interface{}, path []string) (reflect.Value, bool) {
+	v := reflect.ValueOf(node)
+	for _, name := range path {
+		if v = indirect(v); v.Kind() != reflect.Struct {
+			return reflect.Value{}, false
+		}
+		var ok bool
+		if v, ok = field(v, name); !ok {
+			return reflect.Value{}, false
+		}
+	}
+
+	// identifiers and languages are designated by their names
+	switch x := indirect(v); x.Interface().(type) {
+	case ast.Ident:
+		return x.FieldByName("Name"), true
+	case src.Language:
+		return x.FieldByName("Lang"), true
+	}
+	return v, true
+}
+
+// field returns the field of the structure v whose JSON name is name, looking
+// into the embedded structures.
+func field(v reflect.Value, name string) (reflect.Value, bool) {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if sf.Anonymous && sf.Type.Kind() == reflect.Struct {
+			if f, ok := field(v.Field(i), name); ok {
+				return f, true
+			}
+			continue
+		}
+		if tag := strings.Split(sf.Tag.Get("json"), ",")[0]; tag == name {
+			return v.Field(i), true
+		}
+	}
+	return reflect.Value{}, false
+}
+
+// indirect follows pointers and interfaces until a nil or non pointer value is
+// reached.
+func indirect(v reflect.Value) reflect.Value {
+	for (v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface) && !v.IsNil() {
+		v = v.Elem()
+	}
+	return v
+}
+
+// numberOf returns the numeric value of v. Lists are valued by their length.
+func numberOf(v reflect.Value) (float64, bool) {
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(v.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(v.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return v.Float(), true
+	case reflect.Slice:
+		return float64(v.Len()), true
+	}
+	return 0, false
+}
+
+// stringOf returns the textual value of v. Lists of strings, such as the
+// documentation, are joined with new lines.
+func stringOf(v reflect.Value) (string, bool) {
+	v = indirect(v)
+	switch v.Kind() {
+	case reflect.String:
+		return v.String(), true
+	case reflect.Bool:
+		return strconv.FormatBool(v.Bool()), true
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10), true
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(v.Float(), 'g', -1, 64), true
+	case reflect.Slice:
+		if v.Type().Elem().Kind() != reflect.String {
+			return "", false
+		}
+		strs := make([]string, v.Len())
+		for i := range strs {
+			strs[i] = v.Index(i).String()
+		}
+		return strings.Join(strs, "\n"), true
+	}
+	return "", false
+}
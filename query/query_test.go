@@ -0,0 +1,145 @@
+// Copyright 2014-2015 The DevMine Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package query
+
+import (
+	"testing"
+
+	"github.com/DevMine/srcanlzr/src"
+	"github.com/DevMine/srcanlzr/src/ast"
+	"github.com/DevMine/srcanlzr/src/token"
+)
+
+func newCall(namespace, name string, line int64) *ast.ExprStmt {
+	return &ast.ExprStmt{
+		StmtName: token.ExprStmtName,
+		X: &ast.CallExpr{
+			ExprName: token.CallExprName,
+			Fun:      &ast.FuncRef{Namespace: namespace, FuncName: name},
+			Line:     line,
+		},
+	}
+}
+
+var prj = &src.Project{
+	Name: "foo",
+	Packages: []*src.Package{
+		&src.Package{
+			Name: "x",
+			Path: "foo/x",
+			SrcFiles: []*src.SrcFile{
+				&src.SrcFile{
+					Path: "foo/x/x.java",
+					Lang: &src.Language{Lang: "java"},
+					Classes: []*ast.ClassDecl{
+						&ast.ClassDecl{
+							Name:       "Hasher",
+							Visibility: token.PublicVisibility,
+							Methods: []*ast.MethodDecl{
+								&ast.MethodDecl{FuncDecl: ast.FuncDecl{
+									Name:       "hash",
+									Visibility: token.PublicVisibility,
+									LoC:        120,
+									Body: []ast.Stmt{
+										&ast.IfStmt{
+											StmtName: token.IfStmtName,
+											Body:     []ast.Stmt{newCall("crypto/md5", "New", 12)},
+											Line:     11,
+										},
+									},
+								}},
+								&ast.MethodDecl{FuncDecl: ast.FuncDecl{
+									Name:       "check",
+									Visibility: token.PrivateVisibility,
+									LoC:        90,
+									Body:       []ast.Stmt{newCall("crypto/sha1", "New", 42)},
+								}},
+							},
+						},
+					},
+				},
+			},
+		},
+		&src.Package{
+			Name: "y",
+			Path: "foo/y",
+			SrcFiles: []*src.SrcFile{
+				&src.SrcFile{
+					Path: "foo/y/y.go",
+					Lang: &src.Language{Lang: "go"},
+					Funcs: []*ast.FuncDecl{
+						&ast.FuncDecl{
+							Name:       "Sum",
+							Visibility: token.PublicVisibility,
+							LoC:        3,
+							Body:       []ast.Stmt{newCall("crypto/md5", "Sum", 7)},
+						},
+					},
+				},
+			},
+		},
+	},
+}
+
+func TestFind(t *testing.T) {
+	input := map[string][]Match{
+		`package[path=foo/x] method[visibility=public][loc>80]`: {
+			{Kind: "MethodDecl", Name: "hash", Package: "foo/x", File: "foo/x/x.java", Scope: "Hasher"},
+		},
+		`call[function.namespace="crypto/md5"]`: {
+			{Kind: "CallExpr", Package: "foo/x", File: "foo/x/x.java", Scope: "Hasher.hash", Line: 12},
+			{Kind: "CallExpr", Package: "foo/y", File: "foo/y/y.go", Scope: "Sum", Line: 7},
+		},
+		`method > if > * > call`: {
+			{Kind: "CallExpr", Package: "foo/x", File: "foo/x/x.java", Scope: "Hasher.hash", Line: 12},
+		},
+		`file[language=go] FuncRef[function_name^=S], methoddecl[name~="^c"][doc]`: {
+			{Kind: "FuncRef", Package: "foo/y", File: "foo/y/y.go", Scope: "Sum", Line: 7},
+		},
+		`class > method[loc<=90], func[loc=3]`: {
+			{Kind: "MethodDecl", Name: "check", Package: "foo/x", File: "foo/x/x.java", Scope: "Hasher"},
+			{Kind: "FuncDecl", Name: "Sum", Package: "foo/y", File: "foo/y/y.go"},
+		},
+		`if[body<1]`:    {},
+		`Project[name]`: {{Kind: "Project", Name: "foo"}},
+	}
+
+	for s, expected := range input {
+		found := MustCompile(s).Find(prj)
+		if len(found) != len(expected) {
+			t.Errorf("%s: found %d matches, expected %d", s, len(found), len(expected))
+			continue
+		}
+		for i, m := range found {
+			if m.Node == nil {
+				t.Errorf("%s: match %d: found no node", s, i)
+			}
+			m.Node = nil
+			if *m != expected[i] {
+				t.Errorf("%s: match %d: found %+v, expected %+v", s, i, *m, expected[i])
+			}
+		}
+	}
+}
+
+func TestCompileErrors(t *testing.T) {
+	input := []string{
+		"",
+		"func[",
+		"func[loc>x]",
+		"func[name~=\"(\"]",
+		"func[name=\"foo]",
+		"func[name=foo",
+		"func >",
+		"func, ",
+		"func[name?foo]",
+	}
+
+	for _, s := range input {
+		if _, err := Compile(s); err == nil {
+			t.Errorf("%q: found no error, expected an error", s)
+		}
+	}
+}
@@ -0,0 +1,197 @@
+// Copyright 2014-2015 The DevMine Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package resolver
+
+import (
+	"github.com/DevMine/srcanlzr/src"
+	"github.com/DevMine/srcanlzr/src/ast"
+)
+
+// A collector declares the symbols of a source file and collects its
+// references.
+type collector struct {
+	t   *Table
+	pkg *src.Package
+	sf  *src.SrcFile
+}
+
+func (c *collector) srcFile() {
+	for _, x := range c.sf.Constants {
+		c.refs(nil, x)
+	}
+	for _, x := range c.sf.Vars {
+		c.refs(nil, x)
+	}
+	for _, f := range c.sf.Funcs {
+		sym := c.declare(Func, f.Name, f, nil)
+		c.body(sym, f.Body)
+	}
+	for _, i := range c.sf.Interfaces {
+		sym := c.declare(Interface, i.Name, i, nil)
+		for _, ref := range i.ImplementedInterfaces {
+			c.refs(sym, ref)
+		}
+	}
+	for _, cd := range c.sf.Classes {
+		c.class(cd, nil)
+	}
+	for _, e := range c.sf.Enums {
+		c.enum(e, nil)
+	}
+	for _, t := range c.sf.Traits {
+		c.trait(t, nil)
+	}
+}
+
+// declare adds a new symbol to the table. Top level symbols are added to the
+// package scope; the other ones are members of their parent.
+func (c *collector) declare(kind Kind, name string, decl interface{}, parent *Symbol) *Symbol {
+	sym := &Symbol{Kind: kind, Name: name, Decl: decl, Parent: parent, Pkg: c.pkg, File: c.sf}
+	c.t.Symbols = append(c.t.Symbols, sym)
+	c.t.decls[decl] = sym
+
+	if parent == nil {
+		c.t.scopes[c.pkg][name] = append(c.t.scopes[c.pkg][name], sym)
+	} else {
+		if c.t.members[parent] == nil {
+			c.t.members[parent] = make(map[string][]*Symbol)
+		}
+		c.t.members[parent][name] = append(c.t.members[parent][name], sym)
+	}
+	return sym
+}
+
+func (c *collector) class(cd *ast.ClassDecl, parent *Symbol) {
+	sym := c.declare(Class, cd.Name, cd, parent)
+	for _, ref := range cd.ExtendedClasses {
+		c.refs(sym, ref)
+	}
+	for _, ref := range cd.ImplementedInterfaces {
+		c.refs(sym, ref)
+	}
+	for _, ref := range cd.Mixins {
+		c.refs(sym, ref)
+	}
+	for _, attr := range cd.Attrs {
+		c.refs(sym, attr)
+	}
+	c.methods(sym, cd.Constructors, cd.Destructors, cd.Methods)
+	for _, nested := range cd.NestedClasses {
+		c.class(nested, sym)
+	}
+}
+
+func (c *collector) enum(e *ast.EnumDecl, parent *Symbol) {
+	sym := c.declare(Enum, e.Name, e, parent)
+	for _, ref := range e.ImplementedInterfaces {
+		c.refs(sym, ref)
+	}
+	for _, attr := range e.Attrs {
+		c.refs(sym, attr)
+	}
+	c.methods(sym, e.Constructors, e.Destructors, e.Methods)
+}
+
+func (c *collector) trait(t *ast.Trait, parent *Symbol) {
+	sym := c.declare(Trait, t.Name, t, parent)
+	for _, attr := range t.Attrs {
+		c.refs(sym, attr)
+	}
+	c.methods(sym, nil, nil, t.Methods)
+	for _, cd := range t.Classes {
+		c.class(cd, sym)
+	}
+	for _, nested := range t.Traits {
+		c.trait(nested, sym)
+	}
+}
+
+func (c *collector) methods(parent *Symbol, cstrs []*ast.ConstructorDecl, dstrs []*ast.DestructorDecl, mthds []*ast.MethodDecl) {
+	for _, cstr := range cstrs {
+		sym := c.declare(Constructor, cstr.Name, cstr, parent)
+		c.body(sym, cstr.Body)
+	}
+	for _, dstr := range dstrs {
+		sym := c.declare(Destructor, dstr.Name, dstr, parent)
+		c.body(sym, dstr.Body)
+	}
+	for _, m := range mthds {
+		sym := c.declare(Method, m.Name, m, parent)
+		c.body(sym, m.Body)
+	}
+}
+
+func (c *collector) body(scope *Symbol, body []ast.Stmt) {
+	for _, stmt := range body {
+		c.refs(scope, stmt)
+	}
+}
+
+// refs collects the references found in the tree rooted at node.
+func (c *collector) refs(scope *Symbol, node interface{}) {
+	var lines []int64 // line numbers of the enclosing nodes
+	ast.Inspect(node, func(n interface{}) bool {
+		if n == nil {
+			lines = lines[:len(lines)-1]
+			return false
+		}
+
+		line := lineOf(n)
+		if line == 0 && len(lines) > 0 {
+			line = lines[len(lines)-1]
+		}
+		lines = append(lines, line)
+
+		ref := &Reference{Node: n, Scope: scope, Pkg: c.pkg, File: c.sf, Line: line, nargs: -1}
+		switch n := n.(type) {
+		case *ast.CallExpr:
+			ref.nargs = len(n.Args)
+			if n.Fun != nil {
+				ref.Namespace, ref.Name = n.Fun.Namespace, n.Fun.FuncName
+			}
+		case *ast.ConstructorCallExpr:
+			ref.nargs = len(n.Args)
+			if n.Fun != nil {
+				ref.Namespace, ref.Name = n.Fun.Namespace, n.Fun.FuncName
+			}
+		case *ast.ClassRef:
+			ref.Namespace, ref.Name = n.Namespace, n.ClassName
+		case *ast.InterfaceRef:
+			ref.Namespace, ref.Name = n.Namespace, n.InterfaceName
+		case *ast.TraitRef:
+			ref.Namespace, ref.Name = n.Namespace, n.TraitName
+		default:
+			return true
+		}
+		c.t.Refs = append(c.t.Refs, ref)
+		c.t.nodes[n] = ref
+		return true
+	})
+}
+
+// lineOf returns the line number of a node, or 0 if unknown.
+func lineOf(node interface{}) int64 {
+	switch n := node.(type) {
+	case *ast.AssignStmt:
+		return n.Line
+	case *ast.DeclStmt:
+		return n.Line
+	case *ast.CallExpr:
+		return n.Line
+	case *ast.ConstructorCallExpr:
+		return n.Line
+	case *ast.IfStmt:
+		return n.Line
+	case *ast.LoopStmt:
+		return n.Line
+	case *ast.RangeLoopStmt:
+		return n.Line
+	case *ast.ReturnStmt:
+		return n.Line
+	case *ast.OtherStmt:
+		return n.Line
+	}
+	return 0
+}
@@ -0,0 +1,248 @@
+// Copyright 2014-2015 The DevMine Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package resolver
+
+import (
+	"strings"
+
+	"github.com/DevMine/srcanlzr/src/ast"
+)
+
+// resolve sets the target of a reference. References to superclasses may be
+// resolved on demand while resolving other references, hence the resolved
+// flag.
+func (t *Table) resolve(ref *Reference) {
+	if ref.resolved {
+		return
+	}
+	ref.resolved = true
+	if ref.Name == "" {
+		return
+	}
+	if ref.Namespace == "" {
+		ref.Target, ref.External = t.lookupUnqualified(ref)
+	} else {
+		ref.Target, ref.External = t.lookupQualified(ref)
+	}
+}
+
+// lookupUnqualified resolves a reference without namespace. The members of the
+// enclosing types are looked up first, then the declarations of the package
+// and finally the imported names. Names that cannot be found are considered as
+// external (e.g. builtin functions).
+func (t *Table) lookupUnqualified(ref *Reference) (*Symbol, bool) {
+	for s := ref.Scope; s != nil; s = s.Parent {
+		if !s.isType() {
+			continue
+		}
+		if sym := t.lookupMember(s, ref, map[*Symbol]bool{}); sym != nil {
+			return sym, false
+		}
+	}
+
+	if sym := t.best(ref, t.scopes[ref.Pkg][ref.Name]); sym != nil {
+		return sym, false
+	}
+
+	if imp := ref.File.ImportFor(ref.Name); imp != nil {
+		pkg := t.imports[imp]
+		if pkg == nil {
+			return nil, true
+		}
+		if sym := t.best(ref, t.scopes[pkg][importedName(imp, ref.Name)]); sym != nil {
+			return sym, false
+		}
+		return nil, false
+	}
+
+	for _, imp := range ref.File.Imports {
+		pkg := t.imports[imp]
+		if !imp.Wildcard || pkg == nil {
+			continue
+		}
+		if sym := t.best(ref, t.scopes[pkg][ref.Name]); sym != nil {
+			return sym, false
+		}
+	}
+
+	return nil, true
+}
+
+// lookupQualified resolves a reference with a namespace, which may designate
+// the current object (this, self), the superclasses (super), an import or a
+// type visible from the reference.
+func (t *Table) lookupQualified(ref *Reference) (*Symbol, bool) {
+	switch ref.Namespace {
+	case "this", "self":
+		if typ := enclosingType(ref.Scope); typ != nil {
+			return t.lookupMember(typ, ref, map[*Symbol]bool{}), false
+		}
+		return nil, false
+	case "super":
+		if typ := enclosingType(ref.Scope); typ != nil {
+			seen := map[*Symbol]bool{typ: true}
+			for _, super := range t.supertypes(typ) {
+				if sym := t.lookupMember(super, ref, seen); sym != nil {
+					return sym, false
+				}
+			}
+		}
+		return nil, false
+	}
+
+	if imp := ref.File.ImportFor(ref.Namespace); imp != nil {
+		pkg := t.imports[imp]
+		if pkg == nil {
+			return nil, true
+		}
+		for _, typ := range t.scopes[pkg][importedName(imp, ref.Namespace)] {
+			if !typ.isType() {
+				continue
+			}
+			if sym := t.lookupMember(typ, ref, map[*Symbol]bool{}); sym != nil {
+				return sym, false
+			}
+		}
+		return t.best(ref, t.scopes[pkg][ref.Name]), false
+	}
+
+	if typ := t.visibleType(ref, ref.Namespace); typ != nil {
+		return t.lookupMember(typ, ref, map[*Symbol]bool{}), false
+	}
+
+	// most likely a variable, whose type is unknown
+	return nil, false
+}
+
+// lookupMember looks for a member of typ, or of one of its supertypes,
+// matching the reference.
+func (t *Table) lookupMember(typ *Symbol, ref *Reference, seen map[*Symbol]bool) *Symbol {
+	if seen[typ] {
+		return nil
+	}
+	seen[typ] = true
+
+	if sym := t.best(ref, t.members[typ][ref.Name]); sym != nil {
+		return sym
+	}
+	for _, super := range t.supertypes(typ) {
+		if sym := t.lookupMember(super, ref, seen); sym != nil {
+			return sym
+		}
+	}
+	return nil
+}
+
+// supertypes returns the resolved superclasses and mixins of a type.
+func (t *Table) supertypes(typ *Symbol) []*Symbol {
+	var nodes []interface{}
+	switch d := typ.Decl.(type) {
+	case *ast.ClassDecl:
+		for _, ref := range d.ExtendedClasses {
+			nodes = append(nodes, ref)
+		}
+		for _, ref := range d.Mixins {
+			nodes = append(nodes, ref)
+		}
+	}
+
+	var supers []*Symbol
+	for _, node := range nodes {
+		ref := t.nodes[node]
+		if ref == nil {
+			continue
+		}
+		t.resolve(ref)
+		if ref.Target != nil {
+			supers = append(supers, ref.Target)
+		}
+	}
+	return supers
+}
+
+// visibleType returns the type named name that is visible from the
+// reference: a type nested in one of the enclosing types or a top level type
+// of the package.
+func (t *Table) visibleType(ref *Reference, name string) *Symbol {
+	for s := ref.Scope; s != nil; s = s.Parent {
+		if !s.isType() {
+			continue
+		}
+		if s.Name == name {
+			return s
+		}
+		for _, m := range t.members[s][name] {
+			if m.isType() {
+				return m
+			}
+		}
+	}
+	for _, sym := range t.scopes[ref.Pkg][name] {
+		if sym.isType() {
+			return sym
+		}
+	}
+	return nil
+}
+
+// best returns the best candidate for the reference: the first one of a kind
+// accepted by the reference, preferably with as many parameters as the
+// reference has arguments.
+func (t *Table) best(ref *Reference, cands []*Symbol) *Symbol {
+	var first *Symbol
+	for _, sym := range cands {
+		if !accepts(ref, sym.Kind) {
+			continue
+		}
+		if ref.nargs < 0 || sym.params() == ref.nargs {
+			return sym
+		}
+		if first == nil {
+			first = sym
+		}
+	}
+	return first
+}
+
+// accepts reports whether ref may refer to a symbol of the given kind.
+func accepts(ref *Reference, kind Kind) bool {
+	switch ref.Node.(type) {
+	case *ast.CallExpr, *ast.ConstructorCallExpr:
+		return kind == Func || kind == Method || kind == Constructor || kind == Class
+	case *ast.ClassRef:
+		return kind == Class
+	case *ast.InterfaceRef:
+		return kind == Interface
+	case *ast.TraitRef:
+		return kind == Trait
+	}
+	return false
+}
+
+// enclosingType returns the innermost type enclosing the scope, if any.
+func enclosingType(scope *Symbol) *Symbol {
+	for s := scope; s != nil; s = s.Parent {
+		if s.isType() {
+			return s
+		}
+	}
+	return nil
+}
+
+// importedName returns the name, in the imported package, denoted by the
+// local name of an import: either one of the names imported out of the
+// package (e.g. Python's "from foo import bar as baz") or the last element of
+// the local name (e.g. "List" for Java's "import java.util.List").
+func importedName(imp *ast.Import, local string) string {
+	for _, n := range imp.Names {
+		if n.Alias == local || n.Alias == "" && n.Name == local {
+			return n.Name
+		}
+	}
+	if i := strings.LastIndexAny(local, "./"); i >= 0 {
+		return local[i+1:]
+	}
+	return local
+}
@@ -0,0 +1,212 @@
+// Copyright 2014-2015 The DevMine Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package resolver links the references of a project (function calls, class,
+// interface and trait references) to the declarations they denote.
+//
+// Resolve builds a symbol table of all the declarations of a project
+// (functions, classes, nested classes, methods, interfaces, enums and traits)
+// and resolves every reference against it, using the imports of the source
+// files, the enclosing classes and their superclasses, and the declarations of
+// the package. The resulting Table answers "go to definition" (Definition) and
+// "find references" (References) queries.
+//
+// The resolution is purely syntactic: the types of the variables are unknown,
+// so method calls on variables (e.g. foo.bar()) cannot be resolved unless
+// foo names a class or an import.
+package resolver
+
+import (
+	"strings"
+
+	"github.com/DevMine/srcanlzr/src"
+	"github.com/DevMine/srcanlzr/src/ast"
+)
+
+// A Kind describes the kind of declaration of a symbol.
+type Kind string
+
+// Kinds of symbols
+const (
+	Func        Kind = "function"
+	Method      Kind = "method"
+	Constructor Kind = "constructor"
+	Destructor  Kind = "destructor"
+	Class       Kind = "class"
+	Interface   Kind = "interface"
+	Enum        Kind = "enum"
+	Trait       Kind = "trait"
+)
+
+// A Symbol represents a declaration of the project.
+type Symbol struct {
+	Kind Kind
+	Name string
+
+	// The declaration: *ast.FuncDecl, *ast.MethodDecl, *ast.ConstructorDecl,
+	// *ast.DestructorDecl, *ast.ClassDecl, *ast.Interface, *ast.EnumDecl or
+	// *ast.Trait.
+	Decl interface{}
+
+	Parent *Symbol // enclosing class, enum or trait; or nil
+	Pkg    *src.Package
+	File   *src.SrcFile
+}
+
+// QualifiedName returns the name of the symbol prefixed by the path of its
+// package and the names of the enclosing declarations (e.g.
+// "foo/bar.Outer.Inner.method").
+func (s *Symbol) QualifiedName() string {
+	names := []string{s.Name}
+	for p := s.Parent; p != nil; p = p.Parent {
+		names = append([]string{p.Name}, names...)
+	}
+	if s.Pkg != nil && s.Pkg.Path != "" {
+		names = append([]string{s.Pkg.Path}, names...)
+	}
+	return strings.Join(names, ".")
+}
+
+// isType reports whether the symbol declares a type, which may have members.
+func (s *Symbol) isType() bool {
+	switch s.Kind {
+	case Class, Interface, Enum, Trait:
+		return true
+	}
+	return false
+}
+
+// params returns the number of parameters of a function-like symbol, or -1.
+func (s *Symbol) params() int {
+	switch d := s.Decl.(type) {
+	case *ast.FuncDecl:
+		if d.Type != nil {
+			return len(d.Type.Params)
+		}
+		return 0
+	case *ast.MethodDecl:
+		if d.Type != nil {
+			return len(d.Type.Params)
+		}
+		return 0
+	case *ast.ConstructorDecl:
+		return len(d.Params)
+	}
+	return -1
+}
+
+// A Reference represents a reference to a declaration.
+type Reference struct {
+	// The referencing node: *ast.CallExpr, *ast.ConstructorCallExpr,
+	// *ast.ClassRef, *ast.InterfaceRef or *ast.TraitRef.
+	Node interface{}
+
+	Namespace string // namespace of the reference; or empty
+	Name      string // referenced name
+
+	Scope *Symbol // declaration in which the reference appears; or nil
+	Pkg   *src.Package
+	File  *src.SrcFile
+
+	// Line number of the reference or, when the node does not have one, of
+	// the closest enclosing statement that does.
+	Line int64
+
+	// The referenced declaration; or nil when the reference could not be
+	// resolved.
+	Target *Symbol
+
+	// External is true when the reference denotes a declaration that is not
+	// part of the project, such as a function of an external library or a
+	// builtin function.
+	External bool
+
+	nargs    int // number of arguments of a call, or -1
+	resolved bool
+}
+
+// A Table is the symbol table of a project, with the resolved references.
+type Table struct {
+	Symbols []*Symbol    // all the symbols, in declaration order
+	Refs    []*Reference // all the references, in order of appearance
+
+	imports map[*ast.Import]*src.Package // project packages denoted by the imports
+	decls   map[interface{}]*Symbol
+	nodes   map[interface{}]*Reference
+	scopes  map[*src.Package]map[string][]*Symbol // top level symbols
+	members map[*Symbol]map[string][]*Symbol
+	refsTo  map[*Symbol][]*Reference
+}
+
+// Resolve builds the symbol table of the project and resolves its references,
+// the imports included (see src.ImportedPackages). The project is only read.
+func Resolve(p *src.Project) *Table {
+	t := &Table{
+		imports: src.ImportedPackages(p),
+		decls:   make(map[interface{}]*Symbol),
+		nodes:   make(map[interface{}]*Reference),
+		scopes:  make(map[*src.Package]map[string][]*Symbol),
+		members: make(map[*Symbol]map[string][]*Symbol),
+		refsTo:  make(map[*Symbol][]*Reference),
+	}
+
+	for _, pkg := range p.Packages {
+		t.scopes[pkg] = make(map[string][]*Symbol)
+		for _, sf := range pkg.SrcFiles {
+			c := &collector{t: t, pkg: pkg, sf: sf}
+			c.srcFile()
+		}
+	}
+
+	for _, ref := range t.Refs {
+		t.resolve(ref)
+	}
+	for _, ref := range t.Refs {
+		if ref.Target != nil {
+			t.refsTo[ref.Target] = append(t.refsTo[ref.Target], ref)
+		}
+	}
+	return t
+}
+
+// Symbol returns the symbol of a declaration, or nil if decl is not a
+// declaration of the project.
+func (t *Table) Symbol(decl interface{}) *Symbol {
+	return t.decls[decl]
+}
+
+// Reference returns the reference made by node, or nil if node is not a
+// reference of the project.
+func (t *Table) Reference(node interface{}) *Reference {
+	return t.nodes[node]
+}
+
+// Definition returns the symbol referenced by node ("go to definition"), or
+// nil if node is not a reference or if it could not be resolved.
+func (t *Table) Definition(node interface{}) *Symbol {
+	if ref := t.nodes[node]; ref != nil {
+		return ref.Target
+	}
+	return nil
+}
+
+// References returns all the references to the declaration decl ("find
+// references"), in order of appearance.
+func (t *Table) References(decl interface{}) []*Reference {
+	if sym := t.decls[decl]; sym != nil {
+		return t.refsTo[sym]
+	}
+	return nil
+}
+
+// Lookup returns the top level symbols of a package named name.
+func (t *Table) Lookup(pkg *src.Package, name string) []*Symbol {
+	return t.scopes[pkg][name]
+}
+
+// Members returns the members (methods, constructors, destructors and nested
+// types) of a class, enum or trait named name.
+func (t *Table) Members(sym *Symbol, name string) []*Symbol {
+	return t.members[sym][name]
+}
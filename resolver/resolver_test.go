@@ -0,0 +1,152 @@
+// Copyright 2014-2015 The DevMine Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package resolver
+
+import (
+	"testing"
+
+	"github.com/DevMine/srcanlzr/src"
+	"github.com/DevMine/srcanlzr/src/ast"
+	"github.com/DevMine/srcanlzr/src/token"
+)
+
+func newCall(namespace, name string, nargs int) *ast.CallExpr {
+	return &ast.CallExpr{
+		ExprName: token.CallExprName,
+		Fun:      &ast.FuncRef{Namespace: namespace, FuncName: name},
+		Args:     make([]ast.Expr, nargs),
+	}
+}
+
+func newMethod(name string, nparams int, body ...ast.Stmt) *ast.MethodDecl {
+	return &ast.MethodDecl{FuncDecl: ast.FuncDecl{
+		Name: name,
+		Type: &ast.FuncType{Params: make([]*ast.Field, nparams)},
+		Body: body,
+	}}
+}
+
+func stmt(x ast.Expr) ast.Stmt {
+	return &ast.ExprStmt{StmtName: token.ExprStmtName, X: x}
+}
+
+var (
+	callGreet  = newCall("", "greet", 0)
+	callThis   = newCall("this", "run", 0)
+	callJoin   = newCall("Strings", "join", 2)
+	callList   = newCall("List", "of", 0)
+	callPrint  = newCall("", "print", 1)
+	callHelper = newCall("", "helper", 0)
+	callVar    = newCall("foo", "bar", 0)
+	callInner  = newCall("Inner", "make", 0)
+	callNew    = &ast.ConstructorCallExpr{CallExpr: *newCall("", "Base", 0)}
+
+	refBase    = &ast.ClassRef{ClassName: "Base"}
+	refGreeter = &ast.InterfaceRef{InterfaceName: "Greeter"}
+
+	greet      = newMethod("greet", 0)
+	join1      = newMethod("join", 1)
+	join2      = newMethod("join", 2)
+	run        = newMethod("run", 0, stmt(callGreet), stmt(callThis), stmt(callJoin), stmt(callList), stmt(callPrint), stmt(callHelper), stmt(callVar), stmt(callInner), stmt(callNew))
+	innerMk    = newMethod("make", 0)
+	helper     = &ast.FuncDecl{Name: "helper"}
+	base       = &ast.ClassDecl{Name: "Base", Methods: []*ast.MethodDecl{greet}}
+	greeter    = &ast.Interface{Name: "Greeter"}
+	inner      = &ast.ClassDecl{Name: "Inner", Methods: []*ast.MethodDecl{innerMk}}
+	mainCls    = &ast.ClassDecl{Name: "Main", ExtendedClasses: []*ast.ClassRef{refBase}, ImplementedInterfaces: []*ast.InterfaceRef{refGreeter}, Methods: []*ast.MethodDecl{run}, NestedClasses: []*ast.ClassDecl{inner}}
+	stringsCls = &ast.ClassDecl{Name: "Strings", Methods: []*ast.MethodDecl{join1, join2}}
+	testPrj    = &src.Project{
+		Name: "foo",
+		Packages: []*src.Package{
+			&src.Package{
+				Path: "app",
+				SrcFiles: []*src.SrcFile{
+					&src.SrcFile{
+						Path:    "app/Main.java",
+						Imports: []*ast.Import{&ast.Import{Path: "util.Strings"}, &ast.Import{Path: "java.util.List"}},
+						Classes: []*ast.ClassDecl{mainCls},
+					},
+					&src.SrcFile{
+						Path:       "app/Base.java",
+						Funcs:      []*ast.FuncDecl{helper},
+						Classes:    []*ast.ClassDecl{base},
+						Interfaces: []*ast.Interface{greeter},
+					},
+				},
+			},
+			&src.Package{
+				Path:     "util",
+				SrcFiles: []*src.SrcFile{&src.SrcFile{Path: "util/Strings.java", Classes: []*ast.ClassDecl{stringsCls}}},
+			},
+		},
+	}
+)
+
+func TestDefinition(t *testing.T) {
+	tbl := Resolve(testPrj)
+
+	input := []struct {
+		node     interface{}
+		decl     interface{}
+		external bool
+	}{
+		{callGreet, greet, false},
+		{callThis, run, false},
+		{callJoin, join2, false},
+		{callList, nil, true},
+		{callPrint, nil, true},
+		{callHelper, helper, false},
+		{callVar, nil, false},
+		{callInner, innerMk, false},
+		{callNew, base, false},
+		{refBase, base, false},
+		{refGreeter, greeter, false},
+	}
+
+	for _, in := range input {
+		ref := tbl.Reference(in.node)
+		if ref == nil {
+			t.Errorf("Reference %v: found nil, expected a reference", in.node)
+			continue
+		}
+		var found interface{}
+		if sym := tbl.Definition(in.node); sym != nil {
+			found = sym.Decl
+		}
+		if found != in.decl {
+			t.Errorf("Definition %s.%s: found %v, expected %v", ref.Namespace, ref.Name, found, in.decl)
+		}
+		if ref.External != in.external {
+			t.Errorf("External %s.%s: found %v, expected %v", ref.Namespace, ref.Name, ref.External, in.external)
+		}
+	}
+
+	if ref := tbl.Reference(callGreet); ref.Scope == nil || ref.Scope.Decl != run || ref.File.Path != "app/Main.java" {
+		t.Errorf("Reference greet: found scope %v in %s, expected run in app/Main.java", ref.Scope, ref.File.Path)
+	}
+}
+
+func TestReferences(t *testing.T) {
+	tbl := Resolve(testPrj)
+
+	refs := tbl.References(base)
+	if len(refs) != 2 || refs[0].Node != refBase || refs[1].Node != callNew {
+		t.Errorf("References Base: found %v, expected the class reference and the constructor call", refs)
+	}
+	if refs := tbl.References(join1); len(refs) != 0 {
+		t.Errorf("References join/1: found %d references, expected 0", len(refs))
+	}
+
+	sym := tbl.Symbol(innerMk)
+	if sym == nil || sym.Kind != Method || sym.QualifiedName() != "app.Main.Inner.make" {
+		t.Errorf("Symbol make: found %v, expected method app.Main.Inner.make", sym)
+	}
+	if syms := tbl.Lookup(testPrj.Packages[1], "Strings"); len(syms) != 1 || syms[0].Decl != stringsCls {
+		t.Errorf("Lookup Strings: found %v, expected the Strings class", syms)
+	}
+	if syms := tbl.Members(tbl.Symbol(stringsCls), "join"); len(syms) != 2 {
+		t.Errorf("Members join: found %d symbols, expected 2", len(syms))
+	}
+}
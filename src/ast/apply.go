@@ -0,0 +1,228 @@
+// Copyright 2014-2015 The project AUTHORS. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package ast
+
+import "reflect"
+
+// An ApplyFunc is invoked by Apply for each node n, even if n is nil, before
+// and/or after the node's children, using a Cursor describing the current
+// node and providing operations on it.
+//
+// The return value of ApplyFunc controls the syntax tree traversal.
+// See Apply for details.
+type ApplyFunc func(*Cursor) bool
+
+// Apply traverses a syntax tree recursively, starting with root, and calling
+// pre and post for each node:
+//
+// If pre is not nil, it is called for each node before the node's children
+// are traversed (pre-order). If pre returns false, no children are traversed,
+// and post is not called for that node.
+//
+// If post is not nil, and a prior call of pre didn't return false, post is
+// called for each node after its children are traversed (post-order). If post
+// returns false, traversal is terminated and Apply returns immediately.
+//
+// Only fields that refer to nodes (i.e. Expr and Stmt fields, pointers to
+// node types and lists thereof) are traversed, in the order of their
+// declaration in the node type. When pre replaces the current node, the
+// children of the new node are traversed.
+//
+// Apply returns the syntax tree, possibly modified. If root was replaced, the
+// new root is returned.
+func Apply(root interface{}, pre, post ApplyFunc) (result interface{}) {
+	parent := &struct{ Node interface{} }{root}
+	defer func() {
+		if r := recover(); r != nil && r != abort {
+			panic(r)
+		}
+		result = parent.Node
+	}()
+	a := &application{pre: pre, post: post}
+	a.apply(parent, "Node", nil, root)
+	return
+}
+
+var abort = new(int) // singleton, to signal termination of Apply
+
+// A Cursor describes a node encountered during Apply. Information about the
+// node and its parent is available from the Node, Parent, Name, and Index
+// methods.
+//
+// If p is a variable of type and value of the current parent node c.Parent(),
+// and f is the field identifier with name c.Name(), the following invariants
+// hold:
+//
+//    p.f            == c.Node()  if c.Index() <  0
+//    p.f[c.Index()] == c.Node()  if c.Index() >= 0
+//
+// The methods Replace, Delete, InsertBefore, and InsertAfter can be used to
+// change the syntax tree rooted at the current node.
+type Cursor struct {
+	parent interface{}
+	name   string
+	iter   *iterator // valid if non-nil
+	node   interface{}
+}
+
+// Node returns the current node, or nil.
+func (c *Cursor) Node() interface{} { return c.node }
+
+// Parent returns the parent of the current node, or nil for the root node.
+func (c *Cursor) Parent() interface{} {
+	if _, ok := c.parent.(*struct{ Node interface{} }); ok {
+		return nil
+	}
+	return c.parent
+}
+
+// Name returns the name of the parent field that contains the current node.
+// The name of the root node is "Node".
+func (c *Cursor) Name() string { return c.name }
+
+// Index reports the index >= 0 of the current node in the list of nodes that
+// contains it, or a value < 0 if the current node is not part of a list.
+func (c *Cursor) Index() int {
+	if c.iter != nil {
+		return c.iter.index
+	}
+	return -1
+}
+
+// field returns the current node's parent field value.
+func (c *Cursor) field() reflect.Value {
+	return reflect.Indirect(reflect.ValueOf(c.parent)).FieldByName(c.name)
+}
+
+// value returns the reflect value of n, to be stored in a field or list of
+// type t. A nil n gives the zero value of t.
+func value(n interface{}, t reflect.Type) reflect.Value {
+	if n == nil {
+		return reflect.Zero(t)
+	}
+	return reflect.ValueOf(n)
+}
+
+// Replace replaces the current node with n. The replacement must be
+// assignable to the field or list holding the current node (e.g. any
+// expression for an Expr field, but only an *Ident for an *Ident field).
+func (c *Cursor) Replace(n interface{}) {
+	v := c.field()
+	if i := c.Index(); i >= 0 {
+		v = v.Index(i)
+	}
+	v.Set(value(n, v.Type()))
+	c.node = n
+}
+
+// Delete deletes the current node from its containing list. If the current
+// node is not part of a list, Delete panics.
+func (c *Cursor) Delete() {
+	i := c.Index()
+	if i < 0 {
+		panic("Delete node not contained in list")
+	}
+	v := c.field()
+	l := v.Len()
+	reflect.Copy(v.Slice(i, l), v.Slice(i+1, l))
+	v.Index(l - 1).Set(reflect.Zero(v.Type().Elem()))
+	v.SetLen(l - 1)
+	c.iter.step--
+	c.node = nil
+}
+
+// InsertAfter inserts n after the current node in its containing list. If
+// the current node is not part of a list, InsertAfter panics. Apply does not
+// walk n.
+func (c *Cursor) InsertAfter(n interface{}) {
+	i := c.Index()
+	if i < 0 {
+		panic("InsertAfter node not contained in list")
+	}
+	v := c.field()
+	v.Set(reflect.Append(v, reflect.Zero(v.Type().Elem())))
+	l := v.Len()
+	reflect.Copy(v.Slice(i+2, l), v.Slice(i+1, l))
+	v.Index(i + 1).Set(value(n, v.Type().Elem()))
+	c.iter.step++
+}
+
+// InsertBefore inserts n before the current node in its containing list. If
+// the current node is not part of a list, InsertBefore panics. Apply does not
+// walk n.
+func (c *Cursor) InsertBefore(n interface{}) {
+	i := c.Index()
+	if i < 0 {
+		panic("InsertBefore node not contained in list")
+	}
+	v := c.field()
+	v.Set(reflect.Append(v, reflect.Zero(v.Type().Elem())))
+	l := v.Len()
+	reflect.Copy(v.Slice(i+1, l), v.Slice(i, l))
+	v.Index(i).Set(value(n, v.Type().Elem()))
+	c.iter.index++
+}
+
+// An iterator controls the iteration over a list of nodes.
+type iterator struct {
+	index, step int
+}
+
+// An application holds the state of an Apply call.
+type application struct {
+	pre, post ApplyFunc
+	cursor    Cursor
+	iter      iterator
+}
+
+func (a *application) apply(parent interface{}, name string, iter *iterator, n interface{}) {
+	// convert typed nil into untyped nil
+	if v := reflect.ValueOf(n); v.Kind() == reflect.Ptr && v.IsNil() {
+		n = nil
+	}
+
+	// reuse a.cursor instead of allocating a new cursor for each node
+	saved := a.cursor
+	a.cursor.parent = parent
+	a.cursor.name = name
+	a.cursor.iter = iter
+	a.cursor.node = n
+
+	if a.pre != nil && !a.pre(&a.cursor) {
+		a.cursor = saved
+		return
+	}
+
+	a.applyChildren(a.cursor.node)
+
+	if a.post != nil && !a.post(&a.cursor) {
+		panic(abort)
+	}
+
+	a.cursor = saved
+}
+
+func (a *application) applyList(parent interface{}, name string) {
+	// reuse a.iter instead of allocating a new iterator for each list
+	saved := a.iter
+	a.iter.index = 0
+	for {
+		// reload the list each time, since cursor modifications might change it
+		v := reflect.Indirect(reflect.ValueOf(parent)).FieldByName(name)
+		if a.iter.index >= v.Len() {
+			break
+		}
+
+		var x interface{}
+		if e := v.Index(a.iter.index); e.IsValid() {
+			x = e.Interface()
+		}
+
+		a.iter.step = 1
+		a.apply(parent, name, &a.iter, x)
+		a.iter.index += a.iter.step
+	}
+	a.iter = saved
+}
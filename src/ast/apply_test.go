@@ -0,0 +1,94 @@
+// Copyright 2014-2015 The project AUTHORS. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package ast_test
+
+import (
+	"testing"
+
+	"github.com/DevMine/srcanlzr/src/ast"
+	"github.com/DevMine/srcanlzr/src/token"
+)
+
+func TestApplyReplace(t *testing.T) {
+	f := newFunc()
+	ast.Apply(f, func(c *ast.Cursor) bool {
+		if id, ok := c.Node().(*ast.Ident); ok {
+			c.Replace(&ast.Ident{ExprName: token.IdentName, Name: "_" + id.Name})
+		}
+		return true
+	}, nil)
+
+	cond := f.Body[0].(*ast.IfStmt).Cond.(*ast.BinaryExpr)
+	if l, r := cond.LeftExpr.(*ast.Ident).Name, cond.RightExpr.(*ast.Ident).Name; l != "_a" || r != "_b" {
+		t.Errorf("Apply: found identifiers %s and %s, expected _a and _b", l, r)
+	}
+}
+
+func TestApplyLists(t *testing.T) {
+	f := newFunc()
+	stmt := &ast.ExprStmt{StmtName: token.ExprStmtName}
+	ast.Apply(f, func(c *ast.Cursor) bool {
+		switch c.Node().(type) {
+		case *ast.IfStmt:
+			if c.Name() != "Body" || c.Index() != 0 || c.Parent() != f {
+				t.Errorf("Apply: found cursor %s[%d], expected Body[0]", c.Name(), c.Index())
+			}
+			c.InsertBefore(stmt)
+			c.InsertAfter(stmt)
+		case *ast.ReturnStmt:
+			c.Delete()
+		case *ast.ExprStmt:
+			t.Error("Apply: inserted nodes should not be traversed")
+		}
+		return true
+	}, nil)
+
+	if len(f.Body) != 3 || f.Body[0] != stmt || f.Body[2] != stmt {
+		t.Fatalf("Apply: found %d statements, expected the if statement surrounded by the inserted ones", len(f.Body))
+	}
+	if body := f.Body[1].(*ast.IfStmt).Body; len(body) != 0 {
+		t.Errorf("Apply: found %d statements in the if body, expected 0", len(body))
+	}
+}
+
+func TestApplyRoot(t *testing.T) {
+	f := newFunc()
+	g := &ast.FuncDecl{Name: "bar"}
+	visited := 0
+	root := ast.Apply(f, func(c *ast.Cursor) bool {
+		if c.Node() != nil {
+			visited++
+		}
+		if c.Node() == f {
+			if c.Parent() != nil || c.Index() >= 0 {
+				t.Error("Apply: the root node should have no parent nor index")
+			}
+			c.Replace(g)
+		}
+		return true
+	}, nil)
+
+	if root != g {
+		t.Errorf("Apply: found root %v, expected %v", root, g)
+	}
+	if visited != 1 {
+		t.Errorf("Apply: found %d visited nodes, expected 1", visited)
+	}
+}
+
+func TestApplyAbort(t *testing.T) {
+	visited := 0
+	ast.Apply(newFunc(), nil, func(c *ast.Cursor) bool {
+		if c.Node() == nil {
+			return true
+		}
+		visited++
+		_, ok := c.Node().(*ast.Ident)
+		return !ok
+	})
+	if visited != 1 {
+		t.Errorf("Apply: found %d visited nodes, expected 1", visited)
+	}
+}
@@ -35,8 +35,14 @@ type Attr struct {
 	Static   bool `json:"static"`
 }
 
+// AttrRef represents a reference to an attribute (e.g. a.b or this.b).
+//
+// The receiver was not part of the JSON schema at first: parsers that do not
+// write the "expression" key leave it nil, and so do the references to the
+// attributes of the current object.
 type AttrRef struct {
 	ExprName string `json:"expression_name"`
+	X        Expr   `json:"expression,omitempty"` // receiver; or nil
 	Name     *Ident `json:"name"`
 }
 
@@ -89,6 +95,19 @@ type ClassRef struct {
 	ClassName string `json:"class_name"`
 }
 
+// Comment represents a comment of a source file.
+type Comment struct {
+	Kind string `json:"kind"` // kind of comment (see the token constants for the list of kinds)
+
+	// Text of the comment, without the comment markers (e.g. //, /* and */)
+	// but including the new lines of multi-line comments. As every string of
+	// the JSON input, it is left escaped, hence new lines usually appear as
+	// "\n" sequences.
+	Text string `json:"text"`
+
+	Line int64 `json:"line"` // line number of the first line of the comment
+}
+
 type Constant struct {
 	Doc        []string `json:"doc"`
 	Name       string   `json:"name"`
@@ -189,6 +208,36 @@ type IfStmt struct {
 	Line     int64  `json:"line"` // Line number of the statement relatively to the function.
 }
 
+// Import represents an import declaration (import, require, include, use,
+// etc.).
+//
+// In its simplest form, only the path is set. For instance, in Go:
+//    import "fmt"
+// Languages that import names out of a module, such as Python, list them in
+// the Names field:
+//    from os.path import join as pjoin
+type Import struct {
+	Path     string        `json:"path"`               // imported path (e.g. "crypto/md5", "java.util.List", "os.path")
+	Alias    string        `json:"alias,omitempty"`    // local name given to the imported path; or empty
+	Names    []*ImportName `json:"names,omitempty"`    // names imported out of the path; or nil
+	Wildcard bool          `json:"wildcard,omitempty"` // all the names of the path are imported (import java.util.*)
+	Static   bool          `json:"static,omitempty"`   // static import (import static java.lang.Math.PI)
+
+	// Path of the project package denoted by the import, as found in
+	// src.Package.Path. It is empty when the import refers to an external
+	// package or when the import has not been resolved (see
+	// src.ResolveImports).
+	Resolved string `json:"resolved,omitempty"`
+
+	Line int64 `json:"line"` // line number of the import declaration
+}
+
+// ImportName represents a name imported out of a module, optionally renamed.
+type ImportName struct {
+	Name  string `json:"name"`            // imported name
+	Alias string `json:"alias,omitempty"` // local name of the imported name; or empty
+}
+
 type IncDecExpr struct {
 	ExprName string `json:"expression_name"`
 	X        Expr   `json:"operand"`
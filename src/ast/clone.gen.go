@@ -0,0 +1,1405 @@
+// Copyright 2014-2015 The project AUTHORS. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// DO NOT EDIT: This source file has been generated by gen/gen_ast_funcs.go
+
+package ast
+
+import "fmt"
+
+// Clone returns a deep copy of node, which must be nil or a pointer to one of
+// the node types defined by this package. Since Expr and Stmt are interfaces,
+// Clone can be used on any expression or statement.
+//
+// Clone panics if node has an unexpected type.
+func Clone(node interface{}) interface{} {
+	switch n := node.(type) {
+	case nil:
+		return nil
+	case *ArrayExpr:
+		return cloneArrayExpr(n)
+	case *ArrayLit:
+		return cloneArrayLit(n)
+	case *ArrayType:
+		return cloneArrayType(n)
+	case *AssignStmt:
+		return cloneAssignStmt(n)
+	case *Attr:
+		return cloneAttr(n)
+	case *AttrRef:
+		return cloneAttrRef(n)
+	case *BasicLit:
+		return cloneBasicLit(n)
+	case *BinaryExpr:
+		return cloneBinaryExpr(n)
+	case *CallExpr:
+		return cloneCallExpr(n)
+	case *ClassDecl:
+		return cloneClassDecl(n)
+	case *ClassLit:
+		return cloneClassLit(n)
+	case *ClassRef:
+		return cloneClassRef(n)
+	case *Comment:
+		return cloneComment(n)
+	case *Constant:
+		return cloneConstant(n)
+	case *ConstructorCallExpr:
+		return cloneConstructorCallExpr(n)
+	case *ConstructorDecl:
+		return cloneConstructorDecl(n)
+	case *DeclStmt:
+		return cloneDeclStmt(n)
+	case *DestructorDecl:
+		return cloneDestructorDecl(n)
+	case *EnumDecl:
+		return cloneEnumDecl(n)
+	case *ExprStmt:
+		return cloneExprStmt(n)
+	case *FuncDecl:
+		return cloneFuncDecl(n)
+	case *FuncLit:
+		return cloneFuncLit(n)
+	case *FuncRef:
+		return cloneFuncRef(n)
+	case *FuncType:
+		return cloneFuncType(n)
+	case *GlobalDecl:
+		return cloneGlobalDecl(n)
+	case *Ident:
+		return cloneIdent(n)
+	case *IfStmt:
+		return cloneIfStmt(n)
+	case *Import:
+		return cloneImport(n)
+	case *ImportName:
+		return cloneImportName(n)
+	case *IncDecExpr:
+		return cloneIncDecExpr(n)
+	case *IndexExpr:
+		return cloneIndexExpr(n)
+	case *Interface:
+		return cloneInterface(n)
+	case *InterfaceRef:
+		return cloneInterfaceRef(n)
+	case *ListLit:
+		return cloneListLit(n)
+	case *ListType:
+		return cloneListType(n)
+	case *LoopStmt:
+		return cloneLoopStmt(n)
+	case *MapLit:
+		return cloneMapLit(n)
+	case *KeyValuePair:
+		return cloneKeyValuePair(n)
+	case *MapType:
+		return cloneMapType(n)
+	case *MethodDecl:
+		return cloneMethodDecl(n)
+	case *OtherStmt:
+		return cloneOtherStmt(n)
+	case *ProtoDecl:
+		return cloneProtoDecl(n)
+	case *RangeLoopStmt:
+		return cloneRangeLoopStmt(n)
+	case *ReturnStmt:
+		return cloneReturnStmt(n)
+	case *StructType:
+		return cloneStructType(n)
+	case *Field:
+		return cloneField(n)
+	case *SwitchStmt:
+		return cloneSwitchStmt(n)
+	case *CaseClause:
+		return cloneCaseClause(n)
+	case *TernaryExpr:
+		return cloneTernaryExpr(n)
+	case *ThrowStmt:
+		return cloneThrowStmt(n)
+	case *Trait:
+		return cloneTrait(n)
+	case *TraitRef:
+		return cloneTraitRef(n)
+	case *TryStmt:
+		return cloneTryStmt(n)
+	case *CatchClause:
+		return cloneCatchClause(n)
+	case *TypeSpec:
+		return cloneTypeSpec(n)
+	case *UnaryExpr:
+		return cloneUnaryExpr(n)
+	case *ValueSpec:
+		return cloneValueSpec(n)
+	case *Var:
+		return cloneVar(n)
+	}
+	panic(fmt.Sprintf("ast.Clone: unexpected node type %T", node))
+}
+
+// cloneExprs returns a deep copy of a list of expressions.
+func cloneExprs(xs []Expr) []Expr {
+	if xs == nil {
+		return nil
+	}
+	c := make([]Expr, len(xs))
+	for i, x := range xs {
+		c[i] = Clone(x)
+	}
+	return c
+}
+
+// cloneStmts returns a deep copy of a list of statements.
+func cloneStmts(xs []Stmt) []Stmt {
+	if xs == nil {
+		return nil
+	}
+	c := make([]Stmt, len(xs))
+	for i, x := range xs {
+		c[i] = Clone(x)
+	}
+	return c
+}
+
+// cloneStrings returns a copy of a list of strings.
+func cloneStrings(xs []string) []string {
+	if xs == nil {
+		return nil
+	}
+	return append([]string{}, xs...)
+}
+
+// cloneInt64s returns a copy of a list of int 64.
+func cloneInt64s(xs []int64) []int64 {
+	if xs == nil {
+		return nil
+	}
+	return append([]int64{}, xs...)
+}
+
+func cloneArrayExpr(x *ArrayExpr) *ArrayExpr {
+	if x == nil {
+		return nil
+	}
+	c := *x
+	c.Type = cloneArrayType(x.Type)
+	return &c
+}
+
+func cloneArrayExprs(xs []*ArrayExpr) []*ArrayExpr {
+	if xs == nil {
+		return nil
+	}
+	c := make([]*ArrayExpr, len(xs))
+	for i, x := range xs {
+		c[i] = cloneArrayExpr(x)
+	}
+	return c
+}
+
+func cloneArrayLit(x *ArrayLit) *ArrayLit {
+	if x == nil {
+		return nil
+	}
+	c := *x
+	c.Type = cloneArrayType(x.Type)
+	c.Elts = cloneExprs(x.Elts)
+	return &c
+}
+
+func cloneArrayLits(xs []*ArrayLit) []*ArrayLit {
+	if xs == nil {
+		return nil
+	}
+	c := make([]*ArrayLit, len(xs))
+	for i, x := range xs {
+		c[i] = cloneArrayLit(x)
+	}
+	return c
+}
+
+func cloneArrayType(x *ArrayType) *ArrayType {
+	if x == nil {
+		return nil
+	}
+	c := *x
+	c.Dims = cloneInt64s(x.Dims)
+	c.Elt = Clone(x.Elt)
+	return &c
+}
+
+func cloneArrayTypes(xs []*ArrayType) []*ArrayType {
+	if xs == nil {
+		return nil
+	}
+	c := make([]*ArrayType, len(xs))
+	for i, x := range xs {
+		c[i] = cloneArrayType(x)
+	}
+	return c
+}
+
+func cloneAssignStmt(x *AssignStmt) *AssignStmt {
+	if x == nil {
+		return nil
+	}
+	c := *x
+	c.LHS = cloneExprs(x.LHS)
+	c.RHS = cloneExprs(x.RHS)
+	return &c
+}
+
+func cloneAssignStmts(xs []*AssignStmt) []*AssignStmt {
+	if xs == nil {
+		return nil
+	}
+	c := make([]*AssignStmt, len(xs))
+	for i, x := range xs {
+		c[i] = cloneAssignStmt(x)
+	}
+	return c
+}
+
+func cloneAttr(x *Attr) *Attr {
+	if x == nil {
+		return nil
+	}
+	c := *x
+	c.Doc = cloneStrings(x.Doc)
+	return &c
+}
+
+func cloneAttrs(xs []*Attr) []*Attr {
+	if xs == nil {
+		return nil
+	}
+	c := make([]*Attr, len(xs))
+	for i, x := range xs {
+		c[i] = cloneAttr(x)
+	}
+	return c
+}
+
+func cloneAttrRef(x *AttrRef) *AttrRef {
+	if x == nil {
+		return nil
+	}
+	c := *x
+	c.X = Clone(x.X)
+	c.Name = cloneIdent(x.Name)
+	return &c
+}
+
+func cloneAttrRefs(xs []*AttrRef) []*AttrRef {
+	if xs == nil {
+		return nil
+	}
+	c := make([]*AttrRef, len(xs))
+	for i, x := range xs {
+		c[i] = cloneAttrRef(x)
+	}
+	return c
+}
+
+func cloneBasicLit(x *BasicLit) *BasicLit {
+	if x == nil {
+		return nil
+	}
+	c := *x
+	return &c
+}
+
+func cloneBasicLits(xs []*BasicLit) []*BasicLit {
+	if xs == nil {
+		return nil
+	}
+	c := make([]*BasicLit, len(xs))
+	for i, x := range xs {
+		c[i] = cloneBasicLit(x)
+	}
+	return c
+}
+
+func cloneBinaryExpr(x *BinaryExpr) *BinaryExpr {
+	if x == nil {
+		return nil
+	}
+	c := *x
+	c.LeftExpr = Clone(x.LeftExpr)
+	c.RightExpr = Clone(x.RightExpr)
+	return &c
+}
+
+func cloneBinaryExprs(xs []*BinaryExpr) []*BinaryExpr {
+	if xs == nil {
+		return nil
+	}
+	c := make([]*BinaryExpr, len(xs))
+	for i, x := range xs {
+		c[i] = cloneBinaryExpr(x)
+	}
+	return c
+}
+
+func cloneCallExpr(x *CallExpr) *CallExpr {
+	if x == nil {
+		return nil
+	}
+	c := *x
+	c.Fun = cloneFuncRef(x.Fun)
+	c.Args = cloneExprs(x.Args)
+	return &c
+}
+
+func cloneCallExprs(xs []*CallExpr) []*CallExpr {
+	if xs == nil {
+		return nil
+	}
+	c := make([]*CallExpr, len(xs))
+	for i, x := range xs {
+		c[i] = cloneCallExpr(x)
+	}
+	return c
+}
+
+func cloneClassDecl(x *ClassDecl) *ClassDecl {
+	if x == nil {
+		return nil
+	}
+	c := *x
+	c.Doc = cloneStrings(x.Doc)
+	c.ExtendedClasses = cloneClassRefs(x.ExtendedClasses)
+	c.ImplementedInterfaces = cloneInterfaceRefs(x.ImplementedInterfaces)
+	c.Attrs = cloneAttrs(x.Attrs)
+	c.Constructors = cloneConstructorDecls(x.Constructors)
+	c.Destructors = cloneDestructorDecls(x.Destructors)
+	c.Methods = cloneMethodDecls(x.Methods)
+	c.NestedClasses = cloneClassDecls(x.NestedClasses)
+	c.Mixins = cloneTraitRefs(x.Mixins)
+	return &c
+}
+
+func cloneClassDecls(xs []*ClassDecl) []*ClassDecl {
+	if xs == nil {
+		return nil
+	}
+	c := make([]*ClassDecl, len(xs))
+	for i, x := range xs {
+		c[i] = cloneClassDecl(x)
+	}
+	return c
+}
+
+func cloneClassLit(x *ClassLit) *ClassLit {
+	if x == nil {
+		return nil
+	}
+	c := *x
+	c.ExtendedClasses = cloneClassRefs(x.ExtendedClasses)
+	c.ImplementedInterfaces = cloneInterfaceRefs(x.ImplementedInterfaces)
+	c.Attrs = cloneAttrs(x.Attrs)
+	c.Constructors = cloneConstructorDecls(x.Constructors)
+	c.Destructors = cloneDestructorDecls(x.Destructors)
+	c.Methods = cloneMethodDecls(x.Methods)
+	return &c
+}
+
+func cloneClassLits(xs []*ClassLit) []*ClassLit {
+	if xs == nil {
+		return nil
+	}
+	c := make([]*ClassLit, len(xs))
+	for i, x := range xs {
+		c[i] = cloneClassLit(x)
+	}
+	return c
+}
+
+func cloneClassRef(x *ClassRef) *ClassRef {
+	if x == nil {
+		return nil
+	}
+	c := *x
+	return &c
+}
+
+func cloneClassRefs(xs []*ClassRef) []*ClassRef {
+	if xs == nil {
+		return nil
+	}
+	c := make([]*ClassRef, len(xs))
+	for i, x := range xs {
+		c[i] = cloneClassRef(x)
+	}
+	return c
+}
+
+func cloneComment(x *Comment) *Comment {
+	if x == nil {
+		return nil
+	}
+	c := *x
+	return &c
+}
+
+func cloneComments(xs []*Comment) []*Comment {
+	if xs == nil {
+		return nil
+	}
+	c := make([]*Comment, len(xs))
+	for i, x := range xs {
+		c[i] = cloneComment(x)
+	}
+	return c
+}
+
+func cloneConstant(x *Constant) *Constant {
+	if x == nil {
+		return nil
+	}
+	c := *x
+	c.Doc = cloneStrings(x.Doc)
+	return &c
+}
+
+func cloneConstants(xs []*Constant) []*Constant {
+	if xs == nil {
+		return nil
+	}
+	c := make([]*Constant, len(xs))
+	for i, x := range xs {
+		c[i] = cloneConstant(x)
+	}
+	return c
+}
+
+func cloneConstructorCallExpr(x *ConstructorCallExpr) *ConstructorCallExpr {
+	if x == nil {
+		return nil
+	}
+	c := *x
+	c.Fun = cloneFuncRef(x.Fun)
+	c.Args = cloneExprs(x.Args)
+	return &c
+}
+
+func cloneConstructorCallExprs(xs []*ConstructorCallExpr) []*ConstructorCallExpr {
+	if xs == nil {
+		return nil
+	}
+	c := make([]*ConstructorCallExpr, len(xs))
+	for i, x := range xs {
+		c[i] = cloneConstructorCallExpr(x)
+	}
+	return c
+}
+
+func cloneConstructorDecl(x *ConstructorDecl) *ConstructorDecl {
+	if x == nil {
+		return nil
+	}
+	c := *x
+	c.Doc = cloneStrings(x.Doc)
+	c.Params = cloneFields(x.Params)
+	c.Body = cloneStmts(x.Body)
+	return &c
+}
+
+func cloneConstructorDecls(xs []*ConstructorDecl) []*ConstructorDecl {
+	if xs == nil {
+		return nil
+	}
+	c := make([]*ConstructorDecl, len(xs))
+	for i, x := range xs {
+		c[i] = cloneConstructorDecl(x)
+	}
+	return c
+}
+
+func cloneDeclStmt(x *DeclStmt) *DeclStmt {
+	if x == nil {
+		return nil
+	}
+	c := *x
+	c.LHS = cloneExprs(x.LHS)
+	c.RHS = cloneExprs(x.RHS)
+	return &c
+}
+
+func cloneDeclStmts(xs []*DeclStmt) []*DeclStmt {
+	if xs == nil {
+		return nil
+	}
+	c := make([]*DeclStmt, len(xs))
+	for i, x := range xs {
+		c[i] = cloneDeclStmt(x)
+	}
+	return c
+}
+
+func cloneDestructorDecl(x *DestructorDecl) *DestructorDecl {
+	if x == nil {
+		return nil
+	}
+	c := *x
+	c.Doc = cloneStrings(x.Doc)
+	c.Params = cloneFields(x.Params)
+	c.Body = cloneStmts(x.Body)
+	return &c
+}
+
+func cloneDestructorDecls(xs []*DestructorDecl) []*DestructorDecl {
+	if xs == nil {
+		return nil
+	}
+	c := make([]*DestructorDecl, len(xs))
+	for i, x := range xs {
+		c[i] = cloneDestructorDecl(x)
+	}
+	return c
+}
+
+func cloneEnumDecl(x *EnumDecl) *EnumDecl {
+	if x == nil {
+		return nil
+	}
+	c := *x
+	c.Doc = cloneStrings(x.Doc)
+	c.ImplementedInterfaces = cloneInterfaceRefs(x.ImplementedInterfaces)
+	c.EnumConstants = cloneIdents(x.EnumConstants)
+	c.Attrs = cloneAttrs(x.Attrs)
+	c.Constructors = cloneConstructorDecls(x.Constructors)
+	c.Destructors = cloneDestructorDecls(x.Destructors)
+	c.Methods = cloneMethodDecls(x.Methods)
+	return &c
+}
+
+func cloneEnumDecls(xs []*EnumDecl) []*EnumDecl {
+	if xs == nil {
+		return nil
+	}
+	c := make([]*EnumDecl, len(xs))
+	for i, x := range xs {
+		c[i] = cloneEnumDecl(x)
+	}
+	return c
+}
+
+func cloneExprStmt(x *ExprStmt) *ExprStmt {
+	if x == nil {
+		return nil
+	}
+	c := *x
+	c.X = Clone(x.X)
+	return &c
+}
+
+func cloneExprStmts(xs []*ExprStmt) []*ExprStmt {
+	if xs == nil {
+		return nil
+	}
+	c := make([]*ExprStmt, len(xs))
+	for i, x := range xs {
+		c[i] = cloneExprStmt(x)
+	}
+	return c
+}
+
+func cloneFuncDecl(x *FuncDecl) *FuncDecl {
+	if x == nil {
+		return nil
+	}
+	c := *x
+	c.Doc = cloneStrings(x.Doc)
+	c.Type = cloneFuncType(x.Type)
+	c.Body = cloneStmts(x.Body)
+	return &c
+}
+
+func cloneFuncDecls(xs []*FuncDecl) []*FuncDecl {
+	if xs == nil {
+		return nil
+	}
+	c := make([]*FuncDecl, len(xs))
+	for i, x := range xs {
+		c[i] = cloneFuncDecl(x)
+	}
+	return c
+}
+
+func cloneFuncLit(x *FuncLit) *FuncLit {
+	if x == nil {
+		return nil
+	}
+	c := *x
+	c.Type = cloneFuncType(x.Type)
+	c.Body = cloneStmts(x.Body)
+	return &c
+}
+
+func cloneFuncLits(xs []*FuncLit) []*FuncLit {
+	if xs == nil {
+		return nil
+	}
+	c := make([]*FuncLit, len(xs))
+	for i, x := range xs {
+		c[i] = cloneFuncLit(x)
+	}
+	return c
+}
+
+func cloneFuncRef(x *FuncRef) *FuncRef {
+	if x == nil {
+		return nil
+	}
+	c := *x
+	return &c
+}
+
+func cloneFuncRefs(xs []*FuncRef) []*FuncRef {
+	if xs == nil {
+		return nil
+	}
+	c := make([]*FuncRef, len(xs))
+	for i, x := range xs {
+		c[i] = cloneFuncRef(x)
+	}
+	return c
+}
+
+func cloneFuncType(x *FuncType) *FuncType {
+	if x == nil {
+		return nil
+	}
+	c := *x
+	c.Params = cloneFields(x.Params)
+	c.Results = cloneFields(x.Results)
+	return &c
+}
+
+func cloneFuncTypes(xs []*FuncType) []*FuncType {
+	if xs == nil {
+		return nil
+	}
+	c := make([]*FuncType, len(xs))
+	for i, x := range xs {
+		c[i] = cloneFuncType(x)
+	}
+	return c
+}
+
+func cloneGlobalDecl(x *GlobalDecl) *GlobalDecl {
+	if x == nil {
+		return nil
+	}
+	c := *x
+	c.Doc = cloneStrings(x.Doc)
+	c.Name = cloneIdent(x.Name)
+	c.Value = Clone(x.Value)
+	c.Type = cloneIdent(x.Type)
+	return &c
+}
+
+func cloneGlobalDecls(xs []*GlobalDecl) []*GlobalDecl {
+	if xs == nil {
+		return nil
+	}
+	c := make([]*GlobalDecl, len(xs))
+	for i, x := range xs {
+		c[i] = cloneGlobalDecl(x)
+	}
+	return c
+}
+
+func cloneIdent(x *Ident) *Ident {
+	if x == nil {
+		return nil
+	}
+	c := *x
+	return &c
+}
+
+func cloneIdents(xs []*Ident) []*Ident {
+	if xs == nil {
+		return nil
+	}
+	c := make([]*Ident, len(xs))
+	for i, x := range xs {
+		c[i] = cloneIdent(x)
+	}
+	return c
+}
+
+func cloneIfStmt(x *IfStmt) *IfStmt {
+	if x == nil {
+		return nil
+	}
+	c := *x
+	c.Init = Clone(x.Init)
+	c.Cond = Clone(x.Cond)
+	c.Body = cloneStmts(x.Body)
+	c.Else = cloneStmts(x.Else)
+	return &c
+}
+
+func cloneIfStmts(xs []*IfStmt) []*IfStmt {
+	if xs == nil {
+		return nil
+	}
+	c := make([]*IfStmt, len(xs))
+	for i, x := range xs {
+		c[i] = cloneIfStmt(x)
+	}
+	return c
+}
+
+func cloneImport(x *Import) *Import {
+	if x == nil {
+		return nil
+	}
+	c := *x
+	c.Names = cloneImportNames(x.Names)
+	return &c
+}
+
+func cloneImports(xs []*Import) []*Import {
+	if xs == nil {
+		return nil
+	}
+	c := make([]*Import, len(xs))
+	for i, x := range xs {
+		c[i] = cloneImport(x)
+	}
+	return c
+}
+
+func cloneImportName(x *ImportName) *ImportName {
+	if x == nil {
+		return nil
+	}
+	c := *x
+	return &c
+}
+
+func cloneImportNames(xs []*ImportName) []*ImportName {
+	if xs == nil {
+		return nil
+	}
+	c := make([]*ImportName, len(xs))
+	for i, x := range xs {
+		c[i] = cloneImportName(x)
+	}
+	return c
+}
+
+func cloneIncDecExpr(x *IncDecExpr) *IncDecExpr {
+	if x == nil {
+		return nil
+	}
+	c := *x
+	c.X = Clone(x.X)
+	return &c
+}
+
+func cloneIncDecExprs(xs []*IncDecExpr) []*IncDecExpr {
+	if xs == nil {
+		return nil
+	}
+	c := make([]*IncDecExpr, len(xs))
+	for i, x := range xs {
+		c[i] = cloneIncDecExpr(x)
+	}
+	return c
+}
+
+func cloneIndexExpr(x *IndexExpr) *IndexExpr {
+	if x == nil {
+		return nil
+	}
+	c := *x
+	c.X = Clone(x.X)
+	c.Index = Clone(x.Index)
+	return &c
+}
+
+func cloneIndexExprs(xs []*IndexExpr) []*IndexExpr {
+	if xs == nil {
+		return nil
+	}
+	c := make([]*IndexExpr, len(xs))
+	for i, x := range xs {
+		c[i] = cloneIndexExpr(x)
+	}
+	return c
+}
+
+func cloneInterface(x *Interface) *Interface {
+	if x == nil {
+		return nil
+	}
+	c := *x
+	c.Doc = cloneStrings(x.Doc)
+	c.ImplementedInterfaces = cloneInterfaceRefs(x.ImplementedInterfaces)
+	c.Protos = cloneProtoDecls(x.Protos)
+	return &c
+}
+
+func cloneInterfaces(xs []*Interface) []*Interface {
+	if xs == nil {
+		return nil
+	}
+	c := make([]*Interface, len(xs))
+	for i, x := range xs {
+		c[i] = cloneInterface(x)
+	}
+	return c
+}
+
+func cloneInterfaceRef(x *InterfaceRef) *InterfaceRef {
+	if x == nil {
+		return nil
+	}
+	c := *x
+	return &c
+}
+
+func cloneInterfaceRefs(xs []*InterfaceRef) []*InterfaceRef {
+	if xs == nil {
+		return nil
+	}
+	c := make([]*InterfaceRef, len(xs))
+	for i, x := range xs {
+		c[i] = cloneInterfaceRef(x)
+	}
+	return c
+}
+
+func cloneListLit(x *ListLit) *ListLit {
+	if x == nil {
+		return nil
+	}
+	c := *x
+	c.Type = cloneListType(x.Type)
+	c.Elts = cloneExprs(x.Elts)
+	return &c
+}
+
+func cloneListLits(xs []*ListLit) []*ListLit {
+	if xs == nil {
+		return nil
+	}
+	c := make([]*ListLit, len(xs))
+	for i, x := range xs {
+		c[i] = cloneListLit(x)
+	}
+	return c
+}
+
+func cloneListType(x *ListType) *ListType {
+	if x == nil {
+		return nil
+	}
+	c := *x
+	c.Elt = Clone(x.Elt)
+	return &c
+}
+
+func cloneListTypes(xs []*ListType) []*ListType {
+	if xs == nil {
+		return nil
+	}
+	c := make([]*ListType, len(xs))
+	for i, x := range xs {
+		c[i] = cloneListType(x)
+	}
+	return c
+}
+
+func cloneLoopStmt(x *LoopStmt) *LoopStmt {
+	if x == nil {
+		return nil
+	}
+	c := *x
+	c.Init = cloneStmts(x.Init)
+	c.Cond = Clone(x.Cond)
+	c.Post = cloneStmts(x.Post)
+	c.Body = cloneStmts(x.Body)
+	c.Else = cloneStmts(x.Else)
+	return &c
+}
+
+func cloneLoopStmts(xs []*LoopStmt) []*LoopStmt {
+	if xs == nil {
+		return nil
+	}
+	c := make([]*LoopStmt, len(xs))
+	for i, x := range xs {
+		c[i] = cloneLoopStmt(x)
+	}
+	return c
+}
+
+func cloneMapLit(x *MapLit) *MapLit {
+	if x == nil {
+		return nil
+	}
+	c := *x
+	c.Type = cloneMapType(x.Type)
+	c.Elts = cloneKeyValuePairs(x.Elts)
+	return &c
+}
+
+func cloneMapLits(xs []*MapLit) []*MapLit {
+	if xs == nil {
+		return nil
+	}
+	c := make([]*MapLit, len(xs))
+	for i, x := range xs {
+		c[i] = cloneMapLit(x)
+	}
+	return c
+}
+
+func cloneKeyValuePair(x *KeyValuePair) *KeyValuePair {
+	if x == nil {
+		return nil
+	}
+	c := *x
+	c.Key = Clone(x.Key)
+	c.Value = Clone(x.Value)
+	return &c
+}
+
+func cloneKeyValuePairs(xs []*KeyValuePair) []*KeyValuePair {
+	if xs == nil {
+		return nil
+	}
+	c := make([]*KeyValuePair, len(xs))
+	for i, x := range xs {
+		c[i] = cloneKeyValuePair(x)
+	}
+	return c
+}
+
+func cloneMapType(x *MapType) *MapType {
+	if x == nil {
+		return nil
+	}
+	c := *x
+	c.KeyType = Clone(x.KeyType)
+	c.ValueType = Clone(x.ValueType)
+	return &c
+}
+
+func cloneMapTypes(xs []*MapType) []*MapType {
+	if xs == nil {
+		return nil
+	}
+	c := make([]*MapType, len(xs))
+	for i, x := range xs {
+		c[i] = cloneMapType(x)
+	}
+	return c
+}
+
+func cloneMethodDecl(x *MethodDecl) *MethodDecl {
+	if x == nil {
+		return nil
+	}
+	c := *x
+	c.Doc = cloneStrings(x.Doc)
+	c.Type = cloneFuncType(x.Type)
+	c.Body = cloneStmts(x.Body)
+	return &c
+}
+
+func cloneMethodDecls(xs []*MethodDecl) []*MethodDecl {
+	if xs == nil {
+		return nil
+	}
+	c := make([]*MethodDecl, len(xs))
+	for i, x := range xs {
+		c[i] = cloneMethodDecl(x)
+	}
+	return c
+}
+
+func cloneOtherStmt(x *OtherStmt) *OtherStmt {
+	if x == nil {
+		return nil
+	}
+	c := *x
+	c.Body = cloneStmts(x.Body)
+	return &c
+}
+
+func cloneOtherStmts(xs []*OtherStmt) []*OtherStmt {
+	if xs == nil {
+		return nil
+	}
+	c := make([]*OtherStmt, len(xs))
+	for i, x := range xs {
+		c[i] = cloneOtherStmt(x)
+	}
+	return c
+}
+
+func cloneProtoDecl(x *ProtoDecl) *ProtoDecl {
+	if x == nil {
+		return nil
+	}
+	c := *x
+	c.Doc = cloneStrings(x.Doc)
+	c.Name = cloneIdent(x.Name)
+	c.Type = cloneFuncType(x.Type)
+	return &c
+}
+
+func cloneProtoDecls(xs []*ProtoDecl) []*ProtoDecl {
+	if xs == nil {
+		return nil
+	}
+	c := make([]*ProtoDecl, len(xs))
+	for i, x := range xs {
+		c[i] = cloneProtoDecl(x)
+	}
+	return c
+}
+
+func cloneRangeLoopStmt(x *RangeLoopStmt) *RangeLoopStmt {
+	if x == nil {
+		return nil
+	}
+	c := *x
+	c.Vars = cloneExprs(x.Vars)
+	c.Iterable = Clone(x.Iterable)
+	c.Body = cloneStmts(x.Body)
+	return &c
+}
+
+func cloneRangeLoopStmts(xs []*RangeLoopStmt) []*RangeLoopStmt {
+	if xs == nil {
+		return nil
+	}
+	c := make([]*RangeLoopStmt, len(xs))
+	for i, x := range xs {
+		c[i] = cloneRangeLoopStmt(x)
+	}
+	return c
+}
+
+func cloneReturnStmt(x *ReturnStmt) *ReturnStmt {
+	if x == nil {
+		return nil
+	}
+	c := *x
+	c.Results = cloneExprs(x.Results)
+	return &c
+}
+
+func cloneReturnStmts(xs []*ReturnStmt) []*ReturnStmt {
+	if xs == nil {
+		return nil
+	}
+	c := make([]*ReturnStmt, len(xs))
+	for i, x := range xs {
+		c[i] = cloneReturnStmt(x)
+	}
+	return c
+}
+
+func cloneStructType(x *StructType) *StructType {
+	if x == nil {
+		return nil
+	}
+	c := *x
+	c.Doc = cloneStrings(x.Doc)
+	c.Name = cloneIdent(x.Name)
+	c.Fields = cloneFields(x.Fields)
+	return &c
+}
+
+func cloneStructTypes(xs []*StructType) []*StructType {
+	if xs == nil {
+		return nil
+	}
+	c := make([]*StructType, len(xs))
+	for i, x := range xs {
+		c[i] = cloneStructType(x)
+	}
+	return c
+}
+
+func cloneField(x *Field) *Field {
+	if x == nil {
+		return nil
+	}
+	c := *x
+	c.Doc = cloneStrings(x.Doc)
+	return &c
+}
+
+func cloneFields(xs []*Field) []*Field {
+	if xs == nil {
+		return nil
+	}
+	c := make([]*Field, len(xs))
+	for i, x := range xs {
+		c[i] = cloneField(x)
+	}
+	return c
+}
+
+func cloneSwitchStmt(x *SwitchStmt) *SwitchStmt {
+	if x == nil {
+		return nil
+	}
+	c := *x
+	c.Init = Clone(x.Init)
+	c.Cond = Clone(x.Cond)
+	c.CaseClauses = cloneCaseClauses(x.CaseClauses)
+	c.Default = cloneStmts(x.Default)
+	return &c
+}
+
+func cloneSwitchStmts(xs []*SwitchStmt) []*SwitchStmt {
+	if xs == nil {
+		return nil
+	}
+	c := make([]*SwitchStmt, len(xs))
+	for i, x := range xs {
+		c[i] = cloneSwitchStmt(x)
+	}
+	return c
+}
+
+func cloneCaseClause(x *CaseClause) *CaseClause {
+	if x == nil {
+		return nil
+	}
+	c := *x
+	c.Conds = cloneExprs(x.Conds)
+	c.Body = cloneStmts(x.Body)
+	return &c
+}
+
+func cloneCaseClauses(xs []*CaseClause) []*CaseClause {
+	if xs == nil {
+		return nil
+	}
+	c := make([]*CaseClause, len(xs))
+	for i, x := range xs {
+		c[i] = cloneCaseClause(x)
+	}
+	return c
+}
+
+func cloneTernaryExpr(x *TernaryExpr) *TernaryExpr {
+	if x == nil {
+		return nil
+	}
+	c := *x
+	c.Cond = Clone(x.Cond)
+	c.Then = Clone(x.Then)
+	c.Else = Clone(x.Else)
+	return &c
+}
+
+func cloneTernaryExprs(xs []*TernaryExpr) []*TernaryExpr {
+	if xs == nil {
+		return nil
+	}
+	c := make([]*TernaryExpr, len(xs))
+	for i, x := range xs {
+		c[i] = cloneTernaryExpr(x)
+	}
+	return c
+}
+
+func cloneThrowStmt(x *ThrowStmt) *ThrowStmt {
+	if x == nil {
+		return nil
+	}
+	c := *x
+	c.X = Clone(x.X)
+	return &c
+}
+
+func cloneThrowStmts(xs []*ThrowStmt) []*ThrowStmt {
+	if xs == nil {
+		return nil
+	}
+	c := make([]*ThrowStmt, len(xs))
+	for i, x := range xs {
+		c[i] = cloneThrowStmt(x)
+	}
+	return c
+}
+
+func cloneTrait(x *Trait) *Trait {
+	if x == nil {
+		return nil
+	}
+	c := *x
+	c.Attrs = cloneAttrs(x.Attrs)
+	c.Methods = cloneMethodDecls(x.Methods)
+	c.Classes = cloneClassDecls(x.Classes)
+	c.Traits = cloneTraits(x.Traits)
+	return &c
+}
+
+func cloneTraits(xs []*Trait) []*Trait {
+	if xs == nil {
+		return nil
+	}
+	c := make([]*Trait, len(xs))
+	for i, x := range xs {
+		c[i] = cloneTrait(x)
+	}
+	return c
+}
+
+func cloneTraitRef(x *TraitRef) *TraitRef {
+	if x == nil {
+		return nil
+	}
+	c := *x
+	return &c
+}
+
+func cloneTraitRefs(xs []*TraitRef) []*TraitRef {
+	if xs == nil {
+		return nil
+	}
+	c := make([]*TraitRef, len(xs))
+	for i, x := range xs {
+		c[i] = cloneTraitRef(x)
+	}
+	return c
+}
+
+func cloneTryStmt(x *TryStmt) *TryStmt {
+	if x == nil {
+		return nil
+	}
+	c := *x
+	c.Body = cloneStmts(x.Body)
+	c.CatchClauses = cloneCatchClauses(x.CatchClauses)
+	c.Finally = cloneStmts(x.Finally)
+	return &c
+}
+
+func cloneTryStmts(xs []*TryStmt) []*TryStmt {
+	if xs == nil {
+		return nil
+	}
+	c := make([]*TryStmt, len(xs))
+	for i, x := range xs {
+		c[i] = cloneTryStmt(x)
+	}
+	return c
+}
+
+func cloneCatchClause(x *CatchClause) *CatchClause {
+	if x == nil {
+		return nil
+	}
+	c := *x
+	c.Params = cloneFields(x.Params)
+	c.Body = cloneStmts(x.Body)
+	return &c
+}
+
+func cloneCatchClauses(xs []*CatchClause) []*CatchClause {
+	if xs == nil {
+		return nil
+	}
+	c := make([]*CatchClause, len(xs))
+	for i, x := range xs {
+		c[i] = cloneCatchClause(x)
+	}
+	return c
+}
+
+func cloneTypeSpec(x *TypeSpec) *TypeSpec {
+	if x == nil {
+		return nil
+	}
+	c := *x
+	c.Doc = cloneStrings(x.Doc)
+	c.Name = cloneIdent(x.Name)
+	c.Type = Clone(x.Type)
+	return &c
+}
+
+func cloneTypeSpecs(xs []*TypeSpec) []*TypeSpec {
+	if xs == nil {
+		return nil
+	}
+	c := make([]*TypeSpec, len(xs))
+	for i, x := range xs {
+		c[i] = cloneTypeSpec(x)
+	}
+	return c
+}
+
+func cloneUnaryExpr(x *UnaryExpr) *UnaryExpr {
+	if x == nil {
+		return nil
+	}
+	c := *x
+	c.X = Clone(x.X)
+	return &c
+}
+
+func cloneUnaryExprs(xs []*UnaryExpr) []*UnaryExpr {
+	if xs == nil {
+		return nil
+	}
+	c := make([]*UnaryExpr, len(xs))
+	for i, x := range xs {
+		c[i] = cloneUnaryExpr(x)
+	}
+	return c
+}
+
+func cloneValueSpec(x *ValueSpec) *ValueSpec {
+	if x == nil {
+		return nil
+	}
+	c := *x
+	c.Name = cloneIdent(x.Name)
+	c.Type = cloneIdent(x.Type)
+	return &c
+}
+
+func cloneValueSpecs(xs []*ValueSpec) []*ValueSpec {
+	if xs == nil {
+		return nil
+	}
+	c := make([]*ValueSpec, len(xs))
+	for i, x := range xs {
+		c[i] = cloneValueSpec(x)
+	}
+	return c
+}
+
+func cloneVar(x *Var) *Var {
+	if x == nil {
+		return nil
+	}
+	c := *x
+	c.Doc = cloneStrings(x.Doc)
+	return &c
+}
+
+func cloneVars(xs []*Var) []*Var {
+	if xs == nil {
+		return nil
+	}
+	c := make([]*Var, len(xs))
+	for i, x := range xs {
+		c[i] = cloneVar(x)
+	}
+	return c
+}
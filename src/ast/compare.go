@@ -0,0 +1,16 @@
+// Copyright 2014-2015 The project AUTHORS. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:generate go run ./gen/gen_ast_funcs.go
+
+package ast
+
+// A CompareMode value is a set of flags (or 0). They control which parts of
+// the nodes are ignored when comparing them with Equal.
+type CompareMode uint
+
+const (
+	IgnorePositions CompareMode = 1 << iota // ignore line numbers
+	IgnoreDocs                              // ignore associated documentation
+)
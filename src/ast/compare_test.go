@@ -0,0 +1,75 @@
+// Copyright 2014-2015 The project AUTHORS. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package ast_test
+
+import (
+	"testing"
+
+	"github.com/DevMine/srcanlzr/src/ast"
+	"github.com/DevMine/srcanlzr/src/token"
+)
+
+func newFunc() *ast.FuncDecl {
+	return &ast.FuncDecl{
+		Doc:  []string{"foo does nothing"},
+		Name: "foo",
+		Body: []ast.Stmt{
+			&ast.IfStmt{
+				StmtName: token.IfStmtName,
+				Cond: &ast.BinaryExpr{
+					ExprName:  token.BinaryExprName,
+					LeftExpr:  &ast.Ident{ExprName: token.IdentName, Name: "a"},
+					Op:        token.LAND,
+					RightExpr: &ast.Ident{ExprName: token.IdentName, Name: "b"},
+				},
+				Body: []ast.Stmt{
+					&ast.ReturnStmt{StmtName: token.ReturnStmtName, Line: 2},
+				},
+				Line: 1,
+			},
+		},
+	}
+}
+
+func TestCloneEqual(t *testing.T) {
+	f := newFunc()
+	c := ast.Clone(f).(*ast.FuncDecl)
+	if c == f || c.Body[0] == f.Body[0] {
+		t.Fatal("Clone: the copy shares memory with the original node")
+	}
+	if !ast.Equal(f, c, 0) {
+		t.Error("Equal: a node and its copy should be equal")
+	}
+
+	c.Body[0].(*ast.IfStmt).Cond.(*ast.BinaryExpr).Op = token.LOR
+	if ast.Equal(f, c, 0) {
+		t.Error("Equal: nodes with different operators should not be equal")
+	}
+	if f.Body[0].(*ast.IfStmt).Cond.(*ast.BinaryExpr).Op != token.LAND {
+		t.Error("Clone: modifying the copy altered the original node")
+	}
+}
+
+func TestEqualMode(t *testing.T) {
+	f, g := newFunc(), newFunc()
+	g.Doc = nil
+	g.Body[0].(*ast.IfStmt).Line = 42
+
+	input := map[ast.CompareMode]bool{
+		0:                                    false,
+		ast.IgnoreDocs:                       false,
+		ast.IgnorePositions:                  false,
+		ast.IgnoreDocs | ast.IgnorePositions: true,
+	}
+	for mode, expected := range input {
+		if eq := ast.Equal(f, g, mode); eq != expected {
+			t.Errorf("Equal mode %d: found %v, expected %v", mode, eq, expected)
+		}
+	}
+
+	if ast.Equal(f, f.Body[0], 0) {
+		t.Error("Equal: nodes of different types should not be equal")
+	}
+}
@@ -0,0 +1,2052 @@
+// Copyright 2014-2015 The project AUTHORS. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// DO NOT EDIT: This source file has been generated by gen/gen_ast_funcs.go
+
+package ast
+
+import "fmt"
+
+// Equal reports whether the nodes x and y are structurally equal. The nodes
+// must be nil or pointers to one of the node types defined by this package.
+// The mode controls which parts of the nodes are ignored by the comparison.
+//
+// A nil list and an empty list are considered as equal.
+//
+// Equal panics if x has an unexpected type.
+func Equal(x, y interface{}, mode CompareMode) bool {
+	if x == nil || y == nil {
+		return x == y
+	}
+	switch x := x.(type) {
+	case *ArrayExpr:
+		y, ok := y.(*ArrayExpr)
+		return ok && equalArrayExpr(x, y, mode)
+	case *ArrayLit:
+		y, ok := y.(*ArrayLit)
+		return ok && equalArrayLit(x, y, mode)
+	case *ArrayType:
+		y, ok := y.(*ArrayType)
+		return ok && equalArrayType(x, y, mode)
+	case *AssignStmt:
+		y, ok := y.(*AssignStmt)
+		return ok && equalAssignStmt(x, y, mode)
+	case *Attr:
+		y, ok := y.(*Attr)
+		return ok && equalAttr(x, y, mode)
+	case *AttrRef:
+		y, ok := y.(*AttrRef)
+		return ok && equalAttrRef(x, y, mode)
+	case *BasicLit:
+		y, ok := y.(*BasicLit)
+		return ok && equalBasicLit(x, y, mode)
+	case *BinaryExpr:
+		y, ok := y.(*BinaryExpr)
+		return ok && equalBinaryExpr(x, y, mode)
+	case *CallExpr:
+		y, ok := y.(*CallExpr)
+		return ok && equalCallExpr(x, y, mode)
+	case *ClassDecl:
+		y, ok := y.(*ClassDecl)
+		return ok && equalClassDecl(x, y, mode)
+	case *ClassLit:
+		y, ok := y.(*ClassLit)
+		return ok && equalClassLit(x, y, mode)
+	case *ClassRef:
+		y, ok := y.(*ClassRef)
+		return ok && equalClassRef(x, y, mode)
+	case *Comment:
+		y, ok := y.(*Comment)
+		return ok && equalComment(x, y, mode)
+	case *Constant:
+		y, ok := y.(*Constant)
+		return ok && equalConstant(x, y, mode)
+	case *ConstructorCallExpr:
+		y, ok := y.(*ConstructorCallExpr)
+		return ok && equalConstructorCallExpr(x, y, mode)
+	case *ConstructorDecl:
+		y, ok := y.(*ConstructorDecl)
+		return ok && equalConstructorDecl(x, y, mode)
+	case *DeclStmt:
+		y, ok := y.(*DeclStmt)
+		return ok && equalDeclStmt(x, y, mode)
+	case *DestructorDecl:
+		y, ok := y.(*DestructorDecl)
+		return ok && equalDestructorDecl(x, y, mode)
+	case *EnumDecl:
+		y, ok := y.(*EnumDecl)
+		return ok && equalEnumDecl(x, y, mode)
+	case *ExprStmt:
+		y, ok := y.(*ExprStmt)
+		return ok && equalExprStmt(x, y, mode)
+	case *FuncDecl:
+		y, ok := y.(*FuncDecl)
+		return ok && equalFuncDecl(x, y, mode)
+	case *FuncLit:
+		y, ok := y.(*FuncLit)
+		return ok && equalFuncLit(x, y, mode)
+	case *FuncRef:
+		y, ok := y.(*FuncRef)
+		return ok && equalFuncRef(x, y, mode)
+	case *FuncType:
+		y, ok := y.(*FuncType)
+		return ok && equalFuncType(x, y, mode)
+	case *GlobalDecl:
+		y, ok := y.(*GlobalDecl)
+		return ok && equalGlobalDecl(x, y, mode)
+	case *Ident:
+		y, ok := y.(*Ident)
+		return ok && equalIdent(x, y, mode)
+	case *IfStmt:
+		y, ok := y.(*IfStmt)
+		return ok && equalIfStmt(x, y, mode)
+	case *Import:
+		y, ok := y.(*Import)
+		return ok && equalImport(x, y, mode)
+	case *ImportName:
+		y, ok := y.(*ImportName)
+		return ok && equalImportName(x, y, mode)
+	case *IncDecExpr:
+		y, ok := y.(*IncDecExpr)
+		return ok && equalIncDecExpr(x, y, mode)
+	case *IndexExpr:
+		y, ok := y.(*IndexExpr)
+		return ok && equalIndexExpr(x, y, mode)
+	case *Interface:
+		y, ok := y.(*Interface)
+		return ok && equalInterface(x, y, mode)
+	case *InterfaceRef:
+		y, ok := y.(*InterfaceRef)
+		return ok && equalInterfaceRef(x, y, mode)
+	case *ListLit:
+		y, ok := y.(*ListLit)
+		return ok && equalListLit(x, y, mode)
+	case *ListType:
+		y, ok := y.(*ListType)
+		return ok && equalListType(x, y, mode)
+	case *LoopStmt:
+		y, ok := y.(*LoopStmt)
+		return ok && equalLoopStmt(x, y, mode)
+	case *MapLit:
+		y, ok := y.(*MapLit)
+		return ok && equalMapLit(x, y, mode)
+	case *KeyValuePair:
+		y, ok := y.(*KeyValuePair)
+		return ok && equalKeyValuePair(x, y, mode)
+	case *MapType:
+		y, ok := y.(*MapType)
+		return ok && equalMapType(x, y, mode)
+	case *MethodDecl:
+		y, ok := y.(*MethodDecl)
+		return ok && equalMethodDecl(x, y, mode)
+	case *OtherStmt:
+		y, ok := y.(*OtherStmt)
+		return ok && equalOtherStmt(x, y, mode)
+	case *ProtoDecl:
+		y, ok := y.(*ProtoDecl)
+		return ok && equalProtoDecl(x, y, mode)
+	case *RangeLoopStmt:
+		y, ok := y.(*RangeLoopStmt)
+		return ok && equalRangeLoopStmt(x, y, mode)
+	case *ReturnStmt:
+		y, ok := y.(*ReturnStmt)
+		return ok && equalReturnStmt(x, y, mode)
+	case *StructType:
+		y, ok := y.(*StructType)
+		return ok && equalStructType(x, y, mode)
+	case *Field:
+		y, ok := y.(*Field)
+		return ok && equalField(x, y, mode)
+	case *SwitchStmt:
+		y, ok := y.(*SwitchStmt)
+		return ok && equalSwitchStmt(x, y, mode)
+	case *CaseClause:
+		y, ok := y.(*CaseClause)
+		return ok && equalCaseClause(x, y, mode)
+	case *TernaryExpr:
+		y, ok := y.(*TernaryExpr)
+		return ok && equalTernaryExpr(x, y, mode)
+	case *ThrowStmt:
+		y, ok := y.(*ThrowStmt)
+		return ok && equalThrowStmt(x, y, mode)
+	case *Trait:
+		y, ok := y.(*Trait)
+		return ok && equalTrait(x, y, mode)
+	case *TraitRef:
+		y, ok := y.(*TraitRef)
+		return ok && equalTraitRef(x, y, mode)
+	case *TryStmt:
+		y, ok := y.(*TryStmt)
+		return ok && equalTryStmt(x, y, mode)
+	case *CatchClause:
+		y, ok := y.(*CatchClause)
+		return ok && equalCatchClause(x, y, mode)
+	case *TypeSpec:
+		y, ok := y.(*TypeSpec)
+		return ok && equalTypeSpec(x, y, mode)
+	case *UnaryExpr:
+		y, ok := y.(*UnaryExpr)
+		return ok && equalUnaryExpr(x, y, mode)
+	case *ValueSpec:
+		y, ok := y.(*ValueSpec)
+		return ok && equalValueSpec(x, y, mode)
+	case *Var:
+		y, ok := y.(*Var)
+		return ok && equalVar(x, y, mode)
+	}
+	panic(fmt.Sprintf("ast.Equal: unexpected node type %T", x))
+}
+
+// equalExprs reports whether two lists of expressions are equal.
+func equalExprs(xs, ys []Expr, mode CompareMode) bool {
+	if len(xs) != len(ys) {
+		return false
+	}
+	for i := range xs {
+		if !Equal(xs[i], ys[i], mode) {
+			return false
+		}
+	}
+	return true
+}
+
+// equalStmts reports whether two lists of statements are equal.
+func equalStmts(xs, ys []Stmt, mode CompareMode) bool {
+	if len(xs) != len(ys) {
+		return false
+	}
+	for i := range xs {
+		if !Equal(xs[i], ys[i], mode) {
+			return false
+		}
+	}
+	return true
+}
+
+// equalStrings reports whether two lists of strings are equal.
+func equalStrings(xs, ys []string, mode CompareMode) bool {
+	if len(xs) != len(ys) {
+		return false
+	}
+	for i := range xs {
+		if xs[i] != ys[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// equalInt64s reports whether two lists of int 64 are equal.
+func equalInt64s(xs, ys []int64, mode CompareMode) bool {
+	if len(xs) != len(ys) {
+		return false
+	}
+	for i := range xs {
+		if xs[i] != ys[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func equalArrayExpr(x, y *ArrayExpr, mode CompareMode) bool {
+	if x == nil || y == nil {
+		return x == y
+	}
+	if x.ExprName != y.ExprName {
+		return false
+	}
+	if !equalArrayType(x.Type, y.Type, mode) {
+		return false
+	}
+	return true
+}
+
+func equalArrayExprs(xs, ys []*ArrayExpr, mode CompareMode) bool {
+	if len(xs) != len(ys) {
+		return false
+	}
+	for i := range xs {
+		if !equalArrayExpr(xs[i], ys[i], mode) {
+			return false
+		}
+	}
+	return true
+}
+
+func equalArrayLit(x, y *ArrayLit, mode CompareMode) bool {
+	if x == nil || y == nil {
+		return x == y
+	}
+	if x.ExprName != y.ExprName {
+		return false
+	}
+	if !equalArrayType(x.Type, y.Type, mode) {
+		return false
+	}
+	if !equalExprs(x.Elts, y.Elts, mode) {
+		return false
+	}
+	return true
+}
+
+func equalArrayLits(xs, ys []*ArrayLit, mode CompareMode) bool {
+	if len(xs) != len(ys) {
+		return false
+	}
+	for i := range xs {
+		if !equalArrayLit(xs[i], ys[i], mode) {
+			return false
+		}
+	}
+	return true
+}
+
+func equalArrayType(x, y *ArrayType, mode CompareMode) bool {
+	if x == nil || y == nil {
+		return x == y
+	}
+	if !equalInt64s(x.Dims, y.Dims, mode) {
+		return false
+	}
+	if !Equal(x.Elt, y.Elt, mode) {
+		return false
+	}
+	return true
+}
+
+func equalArrayTypes(xs, ys []*ArrayType, mode CompareMode) bool {
+	if len(xs) != len(ys) {
+		return false
+	}
+	for i := range xs {
+		if !equalArrayType(xs[i], ys[i], mode) {
+			return false
+		}
+	}
+	return true
+}
+
+func equalAssignStmt(x, y *AssignStmt, mode CompareMode) bool {
+	if x == nil || y == nil {
+		return x == y
+	}
+	if x.StmtName != y.StmtName {
+		return false
+	}
+	if !equalExprs(x.LHS, y.LHS, mode) {
+		return false
+	}
+	if !equalExprs(x.RHS, y.RHS, mode) {
+		return false
+	}
+	if mode&IgnorePositions == 0 && x.Line != y.Line {
+		return false
+	}
+	return true
+}
+
+func equalAssignStmts(xs, ys []*AssignStmt, mode CompareMode) bool {
+	if len(xs) != len(ys) {
+		return false
+	}
+	for i := range xs {
+		if !equalAssignStmt(xs[i], ys[i], mode) {
+			return false
+		}
+	}
+	return true
+}
+
+func equalAttr(x, y *Attr, mode CompareMode) bool {
+	if x == nil || y == nil {
+		return x == y
+	}
+	if mode&IgnoreDocs == 0 && !equalStrings(x.Doc, y.Doc, mode) {
+		return false
+	}
+	if x.Name != y.Name {
+		return false
+	}
+	if x.Type != y.Type {
+		return false
+	}
+	if x.Value != y.Value {
+		return false
+	}
+	if x.IsPointer != y.IsPointer {
+		return false
+	}
+	if x.Visibility != y.Visibility {
+		return false
+	}
+	if x.Constant != y.Constant {
+		return false
+	}
+	if x.Static != y.Static {
+		return false
+	}
+	return true
+}
+
+func equalAttrs(xs, ys []*Attr, mode CompareMode) bool {
+	if len(xs) != len(ys) {
+		return false
+	}
+	for i := range xs {
+		if !equalAttr(xs[i], ys[i], mode) {
+			return false
+		}
+	}
+	return true
+}
+
+func equalAttrRef(x, y *AttrRef, mode CompareMode) bool {
+	if x == nil || y == nil {
+		return x == y
+	}
+	if x.ExprName != y.ExprName {
+		return false
+	}
+	if !Equal(x.X, y.X, mode) {
+		return false
+	}
+	if !equalIdent(x.Name, y.Name, mode) {
+		return false
+	}
+	return true
+}
+
+func equalAttrRefs(xs, ys []*AttrRef, mode CompareMode) bool {
+	if len(xs) != len(ys) {
+		return false
+	}
+	for i := range xs {
+		if !equalAttrRef(xs[i], ys[i], mode) {
+			return false
+		}
+	}
+	return true
+}
+
+func equalBasicLit(x, y *BasicLit, mode CompareMode) bool {
+	if x == nil || y == nil {
+		return x == y
+	}
+	if x.ExprName != y.ExprName {
+		return false
+	}
+	if x.Kind != y.Kind {
+		return false
+	}
+	if x.Value != y.Value {
+		return false
+	}
+	return true
+}
+
+func equalBasicLits(xs, ys []*BasicLit, mode CompareMode) bool {
+	if len(xs) != len(ys) {
+		return false
+	}
+	for i := range xs {
+		if !equalBasicLit(xs[i], ys[i], mode) {
+			return false
+		}
+	}
+	return true
+}
+
+func equalBinaryExpr(x, y *BinaryExpr, mode CompareMode) bool {
+	if x == nil || y == nil {
+		return x == y
+	}
+	if x.ExprName != y.ExprName {
+		return false
+	}
+	if !Equal(x.LeftExpr, y.LeftExpr, mode) {
+		return false
+	}
+	if x.Op != y.Op {
+		return false
+	}
+	if !Equal(x.RightExpr, y.RightExpr, mode) {
+		return false
+	}
+	return true
+}
+
+func equalBinaryExprs(xs, ys []*BinaryExpr, mode CompareMode) bool {
+	if len(xs) != len(ys) {
+		return false
+	}
+	for i := range xs {
+		if !equalBinaryExpr(xs[i], ys[i], mode) {
+			return false
+		}
+	}
+	return true
+}
+
+func equalCallExpr(x, y *CallExpr, mode CompareMode) bool {
+	if x == nil || y == nil {
+		return x == y
+	}
+	if x.ExprName != y.ExprName {
+		return false
+	}
+	if !equalFuncRef(x.Fun, y.Fun, mode) {
+		return false
+	}
+	if !equalExprs(x.Args, y.Args, mode) {
+		return false
+	}
+	if mode&IgnorePositions == 0 && x.Line != y.Line {
+		return false
+	}
+	return true
+}
+
+func equalCallExprs(xs, ys []*CallExpr, mode CompareMode) bool {
+	if len(xs) != len(ys) {
+		return false
+	}
+	for i := range xs {
+		if !equalCallExpr(xs[i], ys[i], mode) {
+			return false
+		}
+	}
+	return true
+}
+
+func equalClassDecl(x, y *ClassDecl, mode CompareMode) bool {
+	if x == nil || y == nil {
+		return x == y
+	}
+	if mode&IgnoreDocs == 0 && !equalStrings(x.Doc, y.Doc, mode) {
+		return false
+	}
+	if x.Name != y.Name {
+		return false
+	}
+	if x.Visibility != y.Visibility {
+		return false
+	}
+	if !equalClassRefs(x.ExtendedClasses, y.ExtendedClasses, mode) {
+		return false
+	}
+	if !equalInterfaceRefs(x.ImplementedInterfaces, y.ImplementedInterfaces, mode) {
+		return false
+	}
+	if !equalAttrs(x.Attrs, y.Attrs, mode) {
+		return false
+	}
+	if !equalConstructorDecls(x.Constructors, y.Constructors, mode) {
+		return false
+	}
+	if !equalDestructorDecls(x.Destructors, y.Destructors, mode) {
+		return false
+	}
+	if !equalMethodDecls(x.Methods, y.Methods, mode) {
+		return false
+	}
+	if !equalClassDecls(x.NestedClasses, y.NestedClasses, mode) {
+		return false
+	}
+	if !equalTraitRefs(x.Mixins, y.Mixins, mode) {
+		return false
+	}
+	return true
+}
+
+func equalClassDecls(xs, ys []*ClassDecl, mode CompareMode) bool {
+	if len(xs) != len(ys) {
+		return false
+	}
+	for i := range xs {
+		if !equalClassDecl(xs[i], ys[i], mode) {
+			return false
+		}
+	}
+	return true
+}
+
+func equalClassLit(x, y *ClassLit, mode CompareMode) bool {
+	if x == nil || y == nil {
+		return x == y
+	}
+	if x.ExprName != y.ExprName {
+		return false
+	}
+	if !equalClassRefs(x.ExtendedClasses, y.ExtendedClasses, mode) {
+		return false
+	}
+	if !equalInterfaceRefs(x.ImplementedInterfaces, y.ImplementedInterfaces, mode) {
+		return false
+	}
+	if !equalAttrs(x.Attrs, y.Attrs, mode) {
+		return false
+	}
+	if !equalConstructorDecls(x.Constructors, y.Constructors, mode) {
+		return false
+	}
+	if !equalDestructorDecls(x.Destructors, y.Destructors, mode) {
+		return false
+	}
+	if !equalMethodDecls(x.Methods, y.Methods, mode) {
+		return false
+	}
+	return true
+}
+
+func equalClassLits(xs, ys []*ClassLit, mode CompareMode) bool {
+	if len(xs) != len(ys) {
+		return false
+	}
+	for i := range xs {
+		if !equalClassLit(xs[i], ys[i], mode) {
+			return false
+		}
+	}
+	return true
+}
+
+func equalClassRef(x, y *ClassRef, mode CompareMode) bool {
+	if x == nil || y == nil {
+		return x == y
+	}
+	if x.Namespace != y.Namespace {
+		return false
+	}
+	if x.ClassName != y.ClassName {
+		return false
+	}
+	return true
+}
+
+func equalClassRefs(xs, ys []*ClassRef, mode CompareMode) bool {
+	if len(xs) != len(ys) {
+		return false
+	}
+	for i := range xs {
+		if !equalClassRef(xs[i], ys[i], mode) {
+			return false
+		}
+	}
+	return true
+}
+
+func equalComment(x, y *Comment, mode CompareMode) bool {
+	if x == nil || y == nil {
+		return x == y
+	}
+	if x.Kind != y.Kind {
+		return false
+	}
+	if x.Text != y.Text {
+		return false
+	}
+	if mode&IgnorePositions == 0 && x.Line != y.Line {
+		return false
+	}
+	return true
+}
+
+func equalComments(xs, ys []*Comment, mode CompareMode) bool {
+	if len(xs) != len(ys) {
+		return false
+	}
+	for i := range xs {
+		if !equalComment(xs[i], ys[i], mode) {
+			return false
+		}
+	}
+	return true
+}
+
+func equalConstant(x, y *Constant, mode CompareMode) bool {
+	if x == nil || y == nil {
+		return x == y
+	}
+	if mode&IgnoreDocs == 0 && !equalStrings(x.Doc, y.Doc, mode) {
+		return false
+	}
+	if x.Name != y.Name {
+		return false
+	}
+	if x.Type != y.Type {
+		return false
+	}
+	if x.Value != y.Value {
+		return false
+	}
+	if x.IsPointer != y.IsPointer {
+		return false
+	}
+	if x.Visibility != y.Visibility {
+		return false
+	}
+	return true
+}
+
+func equalConstants(xs, ys []*Constant, mode CompareMode) bool {
+	if len(xs) != len(ys) {
+		return false
+	}
+	for i := range xs {
+		if !equalConstant(xs[i], ys[i], mode) {
+			return false
+		}
+	}
+	return true
+}
+
+func equalConstructorCallExpr(x, y *ConstructorCallExpr, mode CompareMode) bool {
+	if x == nil || y == nil {
+		return x == y
+	}
+	if x.ExprName != y.ExprName {
+		return false
+	}
+	if !equalFuncRef(x.Fun, y.Fun, mode) {
+		return false
+	}
+	if !equalExprs(x.Args, y.Args, mode) {
+		return false
+	}
+	if mode&IgnorePositions == 0 && x.Line != y.Line {
+		return false
+	}
+	return true
+}
+
+func equalConstructorCallExprs(xs, ys []*ConstructorCallExpr, mode CompareMode) bool {
+	if len(xs) != len(ys) {
+		return false
+	}
+	for i := range xs {
+		if !equalConstructorCallExpr(xs[i], ys[i], mode) {
+			return false
+		}
+	}
+	return true
+}
+
+func equalConstructorDecl(x, y *ConstructorDecl, mode CompareMode) bool {
+	if x == nil || y == nil {
+		return x == y
+	}
+	if mode&IgnoreDocs == 0 && !equalStrings(x.Doc, y.Doc, mode) {
+		return false
+	}
+	if x.Name != y.Name {
+		return false
+	}
+	if !equalFields(x.Params, y.Params, mode) {
+		return false
+	}
+	if !equalStmts(x.Body, y.Body, mode) {
+		return false
+	}
+	if x.Visibility != y.Visibility {
+		return false
+	}
+	if x.LoC != y.LoC {
+		return false
+	}
+	return true
+}
+
+func equalConstructorDecls(xs, ys []*ConstructorDecl, mode CompareMode) bool {
+	if len(xs) != len(ys) {
+		return false
+	}
+	for i := range xs {
+		if !equalConstructorDecl(xs[i], ys[i], mode) {
+			return false
+		}
+	}
+	return true
+}
+
+func equalDeclStmt(x, y *DeclStmt, mode CompareMode) bool {
+	if x == nil || y == nil {
+		return x == y
+	}
+	if x.StmtName != y.StmtName {
+		return false
+	}
+	if !equalExprs(x.LHS, y.LHS, mode) {
+		return false
+	}
+	if !equalExprs(x.RHS, y.RHS, mode) {
+		return false
+	}
+	if mode&IgnorePositions == 0 && x.Line != y.Line {
+		return false
+	}
+	if x.Kind != y.Kind {
+		return false
+	}
+	return true
+}
+
+func equalDeclStmts(xs, ys []*DeclStmt, mode CompareMode) bool {
+	if len(xs) != len(ys) {
+		return false
+	}
+	for i := range xs {
+		if !equalDeclStmt(xs[i], ys[i], mode) {
+			return false
+		}
+	}
+	return true
+}
+
+func equalDestructorDecl(x, y *DestructorDecl, mode CompareMode) bool {
+	if x == nil || y == nil {
+		return x == y
+	}
+	if mode&IgnoreDocs == 0 && !equalStrings(x.Doc, y.Doc, mode) {
+		return false
+	}
+	if x.Name != y.Name {
+		return false
+	}
+	if !equalFields(x.Params, y.Params, mode) {
+		return false
+	}
+	if !equalStmts(x.Body, y.Body, mode) {
+		return false
+	}
+	if x.Visibility != y.Visibility {
+		return false
+	}
+	if x.LoC != y.LoC {
+		return false
+	}
+	return true
+}
+
+func equalDestructorDecls(xs, ys []*DestructorDecl, mode CompareMode) bool {
+	if len(xs) != len(ys) {
+		return false
+	}
+	for i := range xs {
+		if !equalDestructorDecl(xs[i], ys[i], mode) {
+			return false
+		}
+	}
+	return true
+}
+
+func equalEnumDecl(x, y *EnumDecl, mode CompareMode) bool {
+	if x == nil || y == nil {
+		return x == y
+	}
+	if mode&IgnoreDocs == 0 && !equalStrings(x.Doc, y.Doc, mode) {
+		return false
+	}
+	if x.Name != y.Name {
+		return false
+	}
+	if x.Visibility != y.Visibility {
+		return false
+	}
+	if !equalInterfaceRefs(x.ImplementedInterfaces, y.ImplementedInterfaces, mode) {
+		return false
+	}
+	if !equalIdents(x.EnumConstants, y.EnumConstants, mode) {
+		return false
+	}
+	if !equalAttrs(x.Attrs, y.Attrs, mode) {
+		return false
+	}
+	if !equalConstructorDecls(x.Constructors, y.Constructors, mode) {
+		return false
+	}
+	if !equalDestructorDecls(x.Destructors, y.Destructors, mode) {
+		return false
+	}
+	if !equalMethodDecls(x.Methods, y.Methods, mode) {
+		return false
+	}
+	return true
+}
+
+func equalEnumDecls(xs, ys []*EnumDecl, mode CompareMode) bool {
+	if len(xs) != len(ys) {
+		return false
+	}
+	for i := range xs {
+		if !equalEnumDecl(xs[i], ys[i], mode) {
+			return false
+		}
+	}
+	return true
+}
+
+func equalExprStmt(x, y *ExprStmt, mode CompareMode) bool {
+	if x == nil || y == nil {
+		return x == y
+	}
+	if x.StmtName != y.StmtName {
+		return false
+	}
+	if !Equal(x.X, y.X, mode) {
+		return false
+	}
+	return true
+}
+
+func equalExprStmts(xs, ys []*ExprStmt, mode CompareMode) bool {
+	if len(xs) != len(ys) {
+		return false
+	}
+	for i := range xs {
+		if !equalExprStmt(xs[i], ys[i], mode) {
+			return false
+		}
+	}
+	return true
+}
+
+func equalFuncDecl(x, y *FuncDecl, mode CompareMode) bool {
+	if x == nil || y == nil {
+		return x == y
+	}
+	if mode&IgnoreDocs == 0 && !equalStrings(x.Doc, y.Doc, mode) {
+		return false
+	}
+	if x.Name != y.Name {
+		return false
+	}
+	if !equalFuncType(x.Type, y.Type, mode) {
+		return false
+	}
+	if !equalStmts(x.Body, y.Body, mode) {
+		return false
+	}
+	if x.Visibility != y.Visibility {
+		return false
+	}
+	if x.LoC != y.LoC {
+		return false
+	}
+	return true
+}
+
+func equalFuncDecls(xs, ys []*FuncDecl, mode CompareMode) bool {
+	if len(xs) != len(ys) {
+		return false
+	}
+	for i := range xs {
+		if !equalFuncDecl(xs[i], ys[i], mode) {
+			return false
+		}
+	}
+	return true
+}
+
+func equalFuncLit(x, y *FuncLit, mode CompareMode) bool {
+	if x == nil || y == nil {
+		return x == y
+	}
+	if x.ExprName != y.ExprName {
+		return false
+	}
+	if !equalFuncType(x.Type, y.Type, mode) {
+		return false
+	}
+	if !equalStmts(x.Body, y.Body, mode) {
+		return false
+	}
+	if x.LoC != y.LoC {
+		return false
+	}
+	return true
+}
+
+func equalFuncLits(xs, ys []*FuncLit, mode CompareMode) bool {
+	if len(xs) != len(ys) {
+		return false
+	}
+	for i := range xs {
+		if !equalFuncLit(xs[i], ys[i], mode) {
+			return false
+		}
+	}
+	return true
+}
+
+func equalFuncRef(x, y *FuncRef, mode CompareMode) bool {
+	if x == nil || y == nil {
+		return x == y
+	}
+	if x.Namespace != y.Namespace {
+		return false
+	}
+	if x.FuncName != y.FuncName {
+		return false
+	}
+	return true
+}
+
+func equalFuncRefs(xs, ys []*FuncRef, mode CompareMode) bool {
+	if len(xs) != len(ys) {
+		return false
+	}
+	for i := range xs {
+		if !equalFuncRef(xs[i], ys[i], mode) {
+			return false
+		}
+	}
+	return true
+}
+
+func equalFuncType(x, y *FuncType, mode CompareMode) bool {
+	if x == nil || y == nil {
+		return x == y
+	}
+	if !equalFields(x.Params, y.Params, mode) {
+		return false
+	}
+	if !equalFields(x.Results, y.Results, mode) {
+		return false
+	}
+	return true
+}
+
+func equalFuncTypes(xs, ys []*FuncType, mode CompareMode) bool {
+	if len(xs) != len(ys) {
+		return false
+	}
+	for i := range xs {
+		if !equalFuncType(xs[i], ys[i], mode) {
+			return false
+		}
+	}
+	return true
+}
+
+func equalGlobalDecl(x, y *GlobalDecl, mode CompareMode) bool {
+	if x == nil || y == nil {
+		return x == y
+	}
+	if mode&IgnoreDocs == 0 && !equalStrings(x.Doc, y.Doc, mode) {
+		return false
+	}
+	if !equalIdent(x.Name, y.Name, mode) {
+		return false
+	}
+	if !Equal(x.Value, y.Value, mode) {
+		return false
+	}
+	if !equalIdent(x.Type, y.Type, mode) {
+		return false
+	}
+	if x.Visibility != y.Visibility {
+		return false
+	}
+	return true
+}
+
+func equalGlobalDecls(xs, ys []*GlobalDecl, mode CompareMode) bool {
+	if len(xs) != len(ys) {
+		return false
+	}
+	for i := range xs {
+		if !equalGlobalDecl(xs[i], ys[i], mode) {
+			return false
+		}
+	}
+	return true
+}
+
+func equalIdent(x, y *Ident, mode CompareMode) bool {
+	if x == nil || y == nil {
+		return x == y
+	}
+	if x.ExprName != y.ExprName {
+		return false
+	}
+	if x.Name != y.Name {
+		return false
+	}
+	return true
+}
+
+func equalIdents(xs, ys []*Ident, mode CompareMode) bool {
+	if len(xs) != len(ys) {
+		return false
+	}
+	for i := range xs {
+		if !equalIdent(xs[i], ys[i], mode) {
+			return false
+		}
+	}
+	return true
+}
+
+func equalIfStmt(x, y *IfStmt, mode CompareMode) bool {
+	if x == nil || y == nil {
+		return x == y
+	}
+	if x.StmtName != y.StmtName {
+		return false
+	}
+	if !Equal(x.Init, y.Init, mode) {
+		return false
+	}
+	if !Equal(x.Cond, y.Cond, mode) {
+		return false
+	}
+	if !equalStmts(x.Body, y.Body, mode) {
+		return false
+	}
+	if !equalStmts(x.Else, y.Else, mode) {
+		return false
+	}
+	if mode&IgnorePositions == 0 && x.Line != y.Line {
+		return false
+	}
+	return true
+}
+
+func equalIfStmts(xs, ys []*IfStmt, mode CompareMode) bool {
+	if len(xs) != len(ys) {
+		return false
+	}
+	for i := range xs {
+		if !equalIfStmt(xs[i], ys[i], mode) {
+			return false
+		}
+	}
+	return true
+}
+
+func equalImport(x, y *Import, mode CompareMode) bool {
+	if x == nil || y == nil {
+		return x == y
+	}
+	if x.Path != y.Path {
+		return false
+	}
+	if x.Alias != y.Alias {
+		return false
+	}
+	if !equalImportNames(x.Names, y.Names, mode) {
+		return false
+	}
+	if x.Wildcard != y.Wildcard {
+		return false
+	}
+	if x.Static != y.Static {
+		return false
+	}
+	if x.Resolved != y.Resolved {
+		return false
+	}
+	if mode&IgnorePositions == 0 && x.Line != y.Line {
+		return false
+	}
+	return true
+}
+
+func equalImports(xs, ys []*Import, mode CompareMode) bool {
+	if len(xs) != len(ys) {
+		return false
+	}
+	for i := range xs {
+		if !equalImport(xs[i], ys[i], mode) {
+			return false
+		}
+	}
+	return true
+}
+
+func equalImportName(x, y *ImportName, mode CompareMode) bool {
+	if x == nil || y == nil {
+		return x == y
+	}
+	if x.Name != y.Name {
+		return false
+	}
+	if x.Alias != y.Alias {
+		return false
+	}
+	return true
+}
+
+func equalImportNames(xs, ys []*ImportName, mode CompareMode) bool {
+	if len(xs) != len(ys) {
+		return false
+	}
+	for i := range xs {
+		if !equalImportName(xs[i], ys[i], mode) {
+			return false
+		}
+	}
+	return true
+}
+
+func equalIncDecExpr(x, y *IncDecExpr, mode CompareMode) bool {
+	if x == nil || y == nil {
+		return x == y
+	}
+	if x.ExprName != y.ExprName {
+		return false
+	}
+	if !Equal(x.X, y.X, mode) {
+		return false
+	}
+	if x.Op != y.Op {
+		return false
+	}
+	if x.IsPre != y.IsPre {
+		return false
+	}
+	return true
+}
+
+func equalIncDecExprs(xs, ys []*IncDecExpr, mode CompareMode) bool {
+	if len(xs) != len(ys) {
+		return false
+	}
+	for i := range xs {
+		if !equalIncDecExpr(xs[i], ys[i], mode) {
+			return false
+		}
+	}
+	return true
+}
+
+func equalIndexExpr(x, y *IndexExpr, mode CompareMode) bool {
+	if x == nil || y == nil {
+		return x == y
+	}
+	if x.ExprName != y.ExprName {
+		return false
+	}
+	if !Equal(x.X, y.X, mode) {
+		return false
+	}
+	if !Equal(x.Index, y.Index, mode) {
+		return false
+	}
+	return true
+}
+
+func equalIndexExprs(xs, ys []*IndexExpr, mode CompareMode) bool {
+	if len(xs) != len(ys) {
+		return false
+	}
+	for i := range xs {
+		if !equalIndexExpr(xs[i], ys[i], mode) {
+			return false
+		}
+	}
+	return true
+}
+
+func equalInterface(x, y *Interface, mode CompareMode) bool {
+	if x == nil || y == nil {
+		return x == y
+	}
+	if mode&IgnoreDocs == 0 && !equalStrings(x.Doc, y.Doc, mode) {
+		return false
+	}
+	if x.Name != y.Name {
+		return false
+	}
+	if !equalInterfaceRefs(x.ImplementedInterfaces, y.ImplementedInterfaces, mode) {
+		return false
+	}
+	if !equalProtoDecls(x.Protos, y.Protos, mode) {
+		return false
+	}
+	if x.Visibility != y.Visibility {
+		return false
+	}
+	return true
+}
+
+func equalInterfaces(xs, ys []*Interface, mode CompareMode) bool {
+	if len(xs) != len(ys) {
+		return false
+	}
+	for i := range xs {
+		if !equalInterface(xs[i], ys[i], mode) {
+			return false
+		}
+	}
+	return true
+}
+
+func equalInterfaceRef(x, y *InterfaceRef, mode CompareMode) bool {
+	if x == nil || y == nil {
+		return x == y
+	}
+	if x.Namespace != y.Namespace {
+		return false
+	}
+	if x.InterfaceName != y.InterfaceName {
+		return false
+	}
+	return true
+}
+
+func equalInterfaceRefs(xs, ys []*InterfaceRef, mode CompareMode) bool {
+	if len(xs) != len(ys) {
+		return false
+	}
+	for i := range xs {
+		if !equalInterfaceRef(xs[i], ys[i], mode) {
+			return false
+		}
+	}
+	return true
+}
+
+func equalListLit(x, y *ListLit, mode CompareMode) bool {
+	if x == nil || y == nil {
+		return x == y
+	}
+	if !equalListType(x.Type, y.Type, mode) {
+		return false
+	}
+	if !equalExprs(x.Elts, y.Elts, mode) {
+		return false
+	}
+	return true
+}
+
+func equalListLits(xs, ys []*ListLit, mode CompareMode) bool {
+	if len(xs) != len(ys) {
+		return false
+	}
+	for i := range xs {
+		if !equalListLit(xs[i], ys[i], mode) {
+			return false
+		}
+	}
+	return true
+}
+
+func equalListType(x, y *ListType, mode CompareMode) bool {
+	if x == nil || y == nil {
+		return x == y
+	}
+	if x.Len != y.Len {
+		return false
+	}
+	if x.Max != y.Max {
+		return false
+	}
+	if !Equal(x.Elt, y.Elt, mode) {
+		return false
+	}
+	return true
+}
+
+func equalListTypes(xs, ys []*ListType, mode CompareMode) bool {
+	if len(xs) != len(ys) {
+		return false
+	}
+	for i := range xs {
+		if !equalListType(xs[i], ys[i], mode) {
+			return false
+		}
+	}
+	return true
+}
+
+func equalLoopStmt(x, y *LoopStmt, mode CompareMode) bool {
+	if x == nil || y == nil {
+		return x == y
+	}
+	if x.StmtName != y.StmtName {
+		return false
+	}
+	if !equalStmts(x.Init, y.Init, mode) {
+		return false
+	}
+	if !Equal(x.Cond, y.Cond, mode) {
+		return false
+	}
+	if !equalStmts(x.Post, y.Post, mode) {
+		return false
+	}
+	if !equalStmts(x.Body, y.Body, mode) {
+		return false
+	}
+	if !equalStmts(x.Else, y.Else, mode) {
+		return false
+	}
+	if x.IsPostEval != y.IsPostEval {
+		return false
+	}
+	if mode&IgnorePositions == 0 && x.Line != y.Line {
+		return false
+	}
+	return true
+}
+
+func equalLoopStmts(xs, ys []*LoopStmt, mode CompareMode) bool {
+	if len(xs) != len(ys) {
+		return false
+	}
+	for i := range xs {
+		if !equalLoopStmt(xs[i], ys[i], mode) {
+			return false
+		}
+	}
+	return true
+}
+
+func equalMapLit(x, y *MapLit, mode CompareMode) bool {
+	if x == nil || y == nil {
+		return x == y
+	}
+	if !equalMapType(x.Type, y.Type, mode) {
+		return false
+	}
+	if !equalKeyValuePairs(x.Elts, y.Elts, mode) {
+		return false
+	}
+	return true
+}
+
+func equalMapLits(xs, ys []*MapLit, mode CompareMode) bool {
+	if len(xs) != len(ys) {
+		return false
+	}
+	for i := range xs {
+		if !equalMapLit(xs[i], ys[i], mode) {
+			return false
+		}
+	}
+	return true
+}
+
+func equalKeyValuePair(x, y *KeyValuePair, mode CompareMode) bool {
+	if x == nil || y == nil {
+		return x == y
+	}
+	if !Equal(x.Key, y.Key, mode) {
+		return false
+	}
+	if !Equal(x.Value, y.Value, mode) {
+		return false
+	}
+	return true
+}
+
+func equalKeyValuePairs(xs, ys []*KeyValuePair, mode CompareMode) bool {
+	if len(xs) != len(ys) {
+		return false
+	}
+	for i := range xs {
+		if !equalKeyValuePair(xs[i], ys[i], mode) {
+			return false
+		}
+	}
+	return true
+}
+
+func equalMapType(x, y *MapType, mode CompareMode) bool {
+	if x == nil || y == nil {
+		return x == y
+	}
+	if !Equal(x.KeyType, y.KeyType, mode) {
+		return false
+	}
+	if !Equal(x.ValueType, y.ValueType, mode) {
+		return false
+	}
+	return true
+}
+
+func equalMapTypes(xs, ys []*MapType, mode CompareMode) bool {
+	if len(xs) != len(ys) {
+		return false
+	}
+	for i := range xs {
+		if !equalMapType(xs[i], ys[i], mode) {
+			return false
+		}
+	}
+	return true
+}
+
+func equalMethodDecl(x, y *MethodDecl, mode CompareMode) bool {
+	if x == nil || y == nil {
+		return x == y
+	}
+	if mode&IgnoreDocs == 0 && !equalStrings(x.Doc, y.Doc, mode) {
+		return false
+	}
+	if x.Name != y.Name {
+		return false
+	}
+	if !equalFuncType(x.Type, y.Type, mode) {
+		return false
+	}
+	if !equalStmts(x.Body, y.Body, mode) {
+		return false
+	}
+	if x.Visibility != y.Visibility {
+		return false
+	}
+	if x.LoC != y.LoC {
+		return false
+	}
+	if x.Override != y.Override {
+		return false
+	}
+	return true
+}
+
+func equalMethodDecls(xs, ys []*MethodDecl, mode CompareMode) bool {
+	if len(xs) != len(ys) {
+		return false
+	}
+	for i := range xs {
+		if !equalMethodDecl(xs[i], ys[i], mode) {
+			return false
+		}
+	}
+	return true
+}
+
+func equalOtherStmt(x, y *OtherStmt, mode CompareMode) bool {
+	if x == nil || y == nil {
+		return x == y
+	}
+	if x.StmtName != y.StmtName {
+		return false
+	}
+	if !equalStmts(x.Body, y.Body, mode) {
+		return false
+	}
+	if mode&IgnorePositions == 0 && x.Line != y.Line {
+		return false
+	}
+	return true
+}
+
+func equalOtherStmts(xs, ys []*OtherStmt, mode CompareMode) bool {
+	if len(xs) != len(ys) {
+		return false
+	}
+	for i := range xs {
+		if !equalOtherStmt(xs[i], ys[i], mode) {
+			return false
+		}
+	}
+	return true
+}
+
+func equalProtoDecl(x, y *ProtoDecl, mode CompareMode) bool {
+	if x == nil || y == nil {
+		return x == y
+	}
+	if mode&IgnoreDocs == 0 && !equalStrings(x.Doc, y.Doc, mode) {
+		return false
+	}
+	if !equalIdent(x.Name, y.Name, mode) {
+		return false
+	}
+	if !equalFuncType(x.Type, y.Type, mode) {
+		return false
+	}
+	if x.Visibility != y.Visibility {
+		return false
+	}
+	return true
+}
+
+func equalProtoDecls(xs, ys []*ProtoDecl, mode CompareMode) bool {
+	if len(xs) != len(ys) {
+		return false
+	}
+	for i := range xs {
+		if !equalProtoDecl(xs[i], ys[i], mode) {
+			return false
+		}
+	}
+	return true
+}
+
+func equalRangeLoopStmt(x, y *RangeLoopStmt, mode CompareMode) bool {
+	if x == nil || y == nil {
+		return x == y
+	}
+	if x.StmtName != y.StmtName {
+		return false
+	}
+	if !equalExprs(x.Vars, y.Vars, mode) {
+		return false
+	}
+	if !Equal(x.Iterable, y.Iterable, mode) {
+		return false
+	}
+	if !equalStmts(x.Body, y.Body, mode) {
+		return false
+	}
+	if mode&IgnorePositions == 0 && x.Line != y.Line {
+		return false
+	}
+	return true
+}
+
+func equalRangeLoopStmts(xs, ys []*RangeLoopStmt, mode CompareMode) bool {
+	if len(xs) != len(ys) {
+		return false
+	}
+	for i := range xs {
+		if !equalRangeLoopStmt(xs[i], ys[i], mode) {
+			return false
+		}
+	}
+	return true
+}
+
+func equalReturnStmt(x, y *ReturnStmt, mode CompareMode) bool {
+	if x == nil || y == nil {
+		return x == y
+	}
+	if x.StmtName != y.StmtName {
+		return false
+	}
+	if !equalExprs(x.Results, y.Results, mode) {
+		return false
+	}
+	if mode&IgnorePositions == 0 && x.Line != y.Line {
+		return false
+	}
+	return true
+}
+
+func equalReturnStmts(xs, ys []*ReturnStmt, mode CompareMode) bool {
+	if len(xs) != len(ys) {
+		return false
+	}
+	for i := range xs {
+		if !equalReturnStmt(xs[i], ys[i], mode) {
+			return false
+		}
+	}
+	return true
+}
+
+func equalStructType(x, y *StructType, mode CompareMode) bool {
+	if x == nil || y == nil {
+		return x == y
+	}
+	if x.ExprName != y.ExprName {
+		return false
+	}
+	if mode&IgnoreDocs == 0 && !equalStrings(x.Doc, y.Doc, mode) {
+		return false
+	}
+	if !equalIdent(x.Name, y.Name, mode) {
+		return false
+	}
+	if !equalFields(x.Fields, y.Fields, mode) {
+		return false
+	}
+	return true
+}
+
+func equalStructTypes(xs, ys []*StructType, mode CompareMode) bool {
+	if len(xs) != len(ys) {
+		return false
+	}
+	for i := range xs {
+		if !equalStructType(xs[i], ys[i], mode) {
+			return false
+		}
+	}
+	return true
+}
+
+func equalField(x, y *Field, mode CompareMode) bool {
+	if x == nil || y == nil {
+		return x == y
+	}
+	if mode&IgnoreDocs == 0 && !equalStrings(x.Doc, y.Doc, mode) {
+		return false
+	}
+	if x.Name != y.Name {
+		return false
+	}
+	if x.Type != y.Type {
+		return false
+	}
+	return true
+}
+
+func equalFields(xs, ys []*Field, mode CompareMode) bool {
+	if len(xs) != len(ys) {
+		return false
+	}
+	for i := range xs {
+		if !equalField(xs[i], ys[i], mode) {
+			return false
+		}
+	}
+	return true
+}
+
+func equalSwitchStmt(x, y *SwitchStmt, mode CompareMode) bool {
+	if x == nil || y == nil {
+		return x == y
+	}
+	if x.StmtName != y.StmtName {
+		return false
+	}
+	if !Equal(x.Init, y.Init, mode) {
+		return false
+	}
+	if !Equal(x.Cond, y.Cond, mode) {
+		return false
+	}
+	if !equalCaseClauses(x.CaseClauses, y.CaseClauses, mode) {
+		return false
+	}
+	if !equalStmts(x.Default, y.Default, mode) {
+		return false
+	}
+	return true
+}
+
+func equalSwitchStmts(xs, ys []*SwitchStmt, mode CompareMode) bool {
+	if len(xs) != len(ys) {
+		return false
+	}
+	for i := range xs {
+		if !equalSwitchStmt(xs[i], ys[i], mode) {
+			return false
+		}
+	}
+	return true
+}
+
+func equalCaseClause(x, y *CaseClause, mode CompareMode) bool {
+	if x == nil || y == nil {
+		return x == y
+	}
+	if !equalExprs(x.Conds, y.Conds, mode) {
+		return false
+	}
+	if !equalStmts(x.Body, y.Body, mode) {
+		return false
+	}
+	return true
+}
+
+func equalCaseClauses(xs, ys []*CaseClause, mode CompareMode) bool {
+	if len(xs) != len(ys) {
+		return false
+	}
+	for i := range xs {
+		if !equalCaseClause(xs[i], ys[i], mode) {
+			return false
+		}
+	}
+	return true
+}
+
+func equalTernaryExpr(x, y *TernaryExpr, mode CompareMode) bool {
+	if x == nil || y == nil {
+		return x == y
+	}
+	if x.ExprName != y.ExprName {
+		return false
+	}
+	if !Equal(x.Cond, y.Cond, mode) {
+		return false
+	}
+	if !Equal(x.Then, y.Then, mode) {
+		return false
+	}
+	if !Equal(x.Else, y.Else, mode) {
+		return false
+	}
+	return true
+}
+
+func equalTernaryExprs(xs, ys []*TernaryExpr, mode CompareMode) bool {
+	if len(xs) != len(ys) {
+		return false
+	}
+	for i := range xs {
+		if !equalTernaryExpr(xs[i], ys[i], mode) {
+			return false
+		}
+	}
+	return true
+}
+
+func equalThrowStmt(x, y *ThrowStmt, mode CompareMode) bool {
+	if x == nil || y == nil {
+		return x == y
+	}
+	if x.StmtName != y.StmtName {
+		return false
+	}
+	if !Equal(x.X, y.X, mode) {
+		return false
+	}
+	return true
+}
+
+func equalThrowStmts(xs, ys []*ThrowStmt, mode CompareMode) bool {
+	if len(xs) != len(ys) {
+		return false
+	}
+	for i := range xs {
+		if !equalThrowStmt(xs[i], ys[i], mode) {
+			return false
+		}
+	}
+	return true
+}
+
+func equalTrait(x, y *Trait, mode CompareMode) bool {
+	if x == nil || y == nil {
+		return x == y
+	}
+	if x.Name != y.Name {
+		return false
+	}
+	if !equalAttrs(x.Attrs, y.Attrs, mode) {
+		return false
+	}
+	if !equalMethodDecls(x.Methods, y.Methods, mode) {
+		return false
+	}
+	if !equalClassDecls(x.Classes, y.Classes, mode) {
+		return false
+	}
+	if !equalTraits(x.Traits, y.Traits, mode) {
+		return false
+	}
+	return true
+}
+
+func equalTraits(xs, ys []*Trait, mode CompareMode) bool {
+	if len(xs) != len(ys) {
+		return false
+	}
+	for i := range xs {
+		if !equalTrait(xs[i], ys[i], mode) {
+			return false
+		}
+	}
+	return true
+}
+
+func equalTraitRef(x, y *TraitRef, mode CompareMode) bool {
+	if x == nil || y == nil {
+		return x == y
+	}
+	if x.Namespace != y.Namespace {
+		return false
+	}
+	if x.TraitName != y.TraitName {
+		return false
+	}
+	return true
+}
+
+func equalTraitRefs(xs, ys []*TraitRef, mode CompareMode) bool {
+	if len(xs) != len(ys) {
+		return false
+	}
+	for i := range xs {
+		if !equalTraitRef(xs[i], ys[i], mode) {
+			return false
+		}
+	}
+	return true
+}
+
+func equalTryStmt(x, y *TryStmt, mode CompareMode) bool {
+	if x == nil || y == nil {
+		return x == y
+	}
+	if x.StmtName != y.StmtName {
+		return false
+	}
+	if !equalStmts(x.Body, y.Body, mode) {
+		return false
+	}
+	if !equalCatchClauses(x.CatchClauses, y.CatchClauses, mode) {
+		return false
+	}
+	if !equalStmts(x.Finally, y.Finally, mode) {
+		return false
+	}
+	return true
+}
+
+func equalTryStmts(xs, ys []*TryStmt, mode CompareMode) bool {
+	if len(xs) != len(ys) {
+		return false
+	}
+	for i := range xs {
+		if !equalTryStmt(xs[i], ys[i], mode) {
+			return false
+		}
+	}
+	return true
+}
+
+func equalCatchClause(x, y *CatchClause, mode CompareMode) bool {
+	if x == nil || y == nil {
+		return x == y
+	}
+	if !equalFields(x.Params, y.Params, mode) {
+		return false
+	}
+	if !equalStmts(x.Body, y.Body, mode) {
+		return false
+	}
+	return true
+}
+
+func equalCatchClauses(xs, ys []*CatchClause, mode CompareMode) bool {
+	if len(xs) != len(ys) {
+		return false
+	}
+	for i := range xs {
+		if !equalCatchClause(xs[i], ys[i], mode) {
+			return false
+		}
+	}
+	return true
+}
+
+func equalTypeSpec(x, y *TypeSpec, mode CompareMode) bool {
+	if x == nil || y == nil {
+		return x == y
+	}
+	if mode&IgnoreDocs == 0 && !equalStrings(x.Doc, y.Doc, mode) {
+		return false
+	}
+	if !equalIdent(x.Name, y.Name, mode) {
+		return false
+	}
+	if !Equal(x.Type, y.Type, mode) {
+		return false
+	}
+	return true
+}
+
+func equalTypeSpecs(xs, ys []*TypeSpec, mode CompareMode) bool {
+	if len(xs) != len(ys) {
+		return false
+	}
+	for i := range xs {
+		if !equalTypeSpec(xs[i], ys[i], mode) {
+			return false
+		}
+	}
+	return true
+}
+
+func equalUnaryExpr(x, y *UnaryExpr, mode CompareMode) bool {
+	if x == nil || y == nil {
+		return x == y
+	}
+	if x.ExprName != y.ExprName {
+		return false
+	}
+	if x.Op != y.Op {
+		return false
+	}
+	if !Equal(x.X, y.X, mode) {
+		return false
+	}
+	return true
+}
+
+func equalUnaryExprs(xs, ys []*UnaryExpr, mode CompareMode) bool {
+	if len(xs) != len(ys) {
+		return false
+	}
+	for i := range xs {
+		if !equalUnaryExpr(xs[i], ys[i], mode) {
+			return false
+		}
+	}
+	return true
+}
+
+func equalValueSpec(x, y *ValueSpec, mode CompareMode) bool {
+	if x == nil || y == nil {
+		return x == y
+	}
+	if x.ExprName != y.ExprName {
+		return false
+	}
+	if !equalIdent(x.Name, y.Name, mode) {
+		return false
+	}
+	if !equalIdent(x.Type, y.Type, mode) {
+		return false
+	}
+	return true
+}
+
+func equalValueSpecs(xs, ys []*ValueSpec, mode CompareMode) bool {
+	if len(xs) != len(ys) {
+		return false
+	}
+	for i := range xs {
+		if !equalValueSpec(xs[i], ys[i], mode) {
+			return false
+		}
+	}
+	return true
+}
+
+func equalVar(x, y *Var, mode CompareMode) bool {
+	if x == nil || y == nil {
+		return x == y
+	}
+	if mode&IgnoreDocs == 0 && !equalStrings(x.Doc, y.Doc, mode) {
+		return false
+	}
+	if x.Name != y.Name {
+		return false
+	}
+	if x.Type != y.Type {
+		return false
+	}
+	if x.Value != y.Value {
+		return false
+	}
+	if x.IsPointer != y.IsPointer {
+		return false
+	}
+	if x.Visibility != y.Visibility {
+		return false
+	}
+	return true
+}
+
+func equalVars(xs, ys []*Var, mode CompareMode) bool {
+	if len(xs) != len(ys) {
+		return false
+	}
+	for i := range xs {
+		if !equalVar(xs[i], ys[i], mode) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,492 @@
+// Copyright 2014-2015 The project AUTHORS. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io/ioutil"
+	"os"
+	"text/template"
+
+	"golang.org/x/tools/imports"
+)
+
+// go source file header
+const header = `// Copyright 2014-2015 The project AUTHORS. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// DO NOT EDIT: This source file has been generated by gen/gen_ast_funcs.go
+
+package ast
+`
+
+const tmplClone = `
+// Clone returns a deep copy of node, which must be nil or a pointer to one of
+// the node types defined by this package. Since Expr and Stmt are interfaces,
+// Clone can be used on any expression or statement.
+//
+// Clone panics if node has an unexpected type.
+func Clone(node interface{}) interface{} {
+	switch n := node.(type) {
+	case nil:
+		return nil
+	{{- range . }}
+	case *{{ .Name }}:
+		return clone{{ .Name }}(n)
+	{{- end }}
+	}
+	panic(fmt.Sprintf("ast.Clone: unexpected node type %T", node))
+}
+
+// cloneExprs returns a deep copy of a list of expressions.
+func cloneExprs(xs []Expr) []Expr {
+	if xs == nil {
+		return nil
+	}
+	c := make([]Expr, len(xs))
+	for i, x := range xs {
+		c[i] = Clone(x)
+	}
+	return c
+}
+
+// cloneStmts returns a deep copy of a list of statements.
+func cloneStmts(xs []Stmt) []Stmt {
+	if xs == nil {
+		return nil
+	}
+	c := make([]Stmt, len(xs))
+	for i, x := range xs {
+		c[i] = Clone(x)
+	}
+	return c
+}
+
+// cloneStrings returns a copy of a list of strings.
+func cloneStrings(xs []string) []string {
+	if xs == nil {
+		return nil
+	}
+	return append([]string{}, xs...)
+}
+
+// cloneInt64s returns a copy of a list of int 64.
+func cloneInt64s(xs []int64) []int64 {
+	if xs == nil {
+		return nil
+	}
+	return append([]int64{}, xs...)
+}
+
+{{ range . }}
+func clone{{ .Name }}(x *{{ .Name }}) *{{ .Name }} {
+	if x == nil {
+		return nil
+	}
+	c := *x
+	{{- range .Fields }}
+	{{- if .Slice }}
+	c.{{ .Name }} = clone{{ .Type }}s(x.{{ .Name }})
+	{{- else if .Iface }}
+	c.{{ .Name }} = Clone(x.{{ .Name }})
+	{{- else if not .Basic }}
+	c.{{ .Name }} = clone{{ .Type }}(x.{{ .Name }})
+	{{- end }}
+	{{- end }}
+	return &c
+}
+
+func clone{{ .Name }}s(xs []*{{ .Name }}) []*{{ .Name }} {
+	if xs == nil {
+		return nil
+	}
+	c := make([]*{{ .Name }}, len(xs))
+	for i, x := range xs {
+		c[i] = clone{{ .Name }}(x)
+	}
+	return c
+}
+{{ end }}
+`
+
+const tmplEqual = `
+// Equal reports whether the nodes x and y are structurally equal. The nodes
+// must be nil or pointers to one of the node types defined by this package.
+// The mode controls which parts of the nodes are ignored by the comparison.
+//
+// A nil list and an empty list are considered as equal.
+//
+// Equal panics if x has an unexpected type.
+func Equal(x, y interface{}, mode CompareMode) bool {
+	if x == nil || y == nil {
+		return x == y
+	}
+	switch x := x.(type) {
+	{{- range . }}
+	case *{{ .Name }}:
+		y, ok := y.(*{{ .Name }})
+		return ok && equal{{ .Name }}(x, y, mode)
+	{{- end }}
+	}
+	panic(fmt.Sprintf("ast.Equal: unexpected node type %T", x))
+}
+
+// equalExprs reports whether two lists of expressions are equal.
+func equalExprs(xs, ys []Expr, mode CompareMode) bool {
+	if len(xs) != len(ys) {
+		return false
+	}
+	for i := range xs {
+		if !Equal(xs[i], ys[i], mode) {
+			return false
+		}
+	}
+	return true
+}
+
+// equalStmts reports whether two lists of statements are equal.
+func equalStmts(xs, ys []Stmt, mode CompareMode) bool {
+	if len(xs) != len(ys) {
+		return false
+	}
+	for i := range xs {
+		if !Equal(xs[i], ys[i], mode) {
+			return false
+		}
+	}
+	return true
+}
+
+// equalStrings reports whether two lists of strings are equal.
+func equalStrings(xs, ys []string, mode CompareMode) bool {
+	if len(xs) != len(ys) {
+		return false
+	}
+	for i := range xs {
+		if xs[i] != ys[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// equalInt64s reports whether two lists of int 64 are equal.
+func equalInt64s(xs, ys []int64, mode CompareMode) bool {
+	if len(xs) != len(ys) {
+		return false
+	}
+	for i := range xs {
+		if xs[i] != ys[i] {
+			return false
+		}
+	}
+	return true
+}
+
+{{ range . }}
+func equal{{ .Name }}(x, y *{{ .Name }}, mode CompareMode) bool {
+	if x == nil || y == nil {
+		return x == y
+	}
+	{{- range .Fields }}
+	{{- if .Position }}
+	if mode&IgnorePositions == 0 && x.{{ .Name }} != y.{{ .Name }} {
+		return false
+	}
+	{{- else if .Doc }}
+	if mode&IgnoreDocs == 0 && !equal{{ .Type }}s(x.{{ .Name }}, y.{{ .Name }}, mode) {
+		return false
+	}
+	{{- else if .Slice }}
+	if !equal{{ .Type }}s(x.{{ .Name }}, y.{{ .Name }}, mode) {
+		return false
+	}
+	{{- else if .Iface }}
+	if !Equal(x.{{ .Name }}, y.{{ .Name }}, mode) {
+		return false
+	}
+	{{- else if .Basic }}
+	if x.{{ .Name }} != y.{{ .Name }} {
+		return false
+	}
+	{{- else }}
+	if !equal{{ .Type }}(x.{{ .Name }}, y.{{ .Name }}, mode) {
+		return false
+	}
+	{{- end }}
+	{{- end }}
+	return true
+}
+
+func equal{{ .Name }}s(xs, ys []*{{ .Name }}, mode CompareMode) bool {
+	if len(xs) != len(ys) {
+		return false
+	}
+	for i := range xs {
+		if !equal{{ .Name }}(xs[i], ys[i], mode) {
+			return false
+		}
+	}
+	return true
+}
+{{ end }}
+`
+
+const tmplWalk = `
+// Inspect traverses the tree rooted at node in depth-first order: it starts by
+// calling f(node); node must be nil or a pointer to one of the node types
+// defined by this package. If f returns true, Inspect invokes f recursively
+// for each of the non-nil children of node, followed by a call of f(nil).
+//
+// Inspect panics if node has an unexpected type.
+func Inspect(node interface{}, f func(interface{}) bool) {
+	switch n := node.(type) {
+	case nil:
+	{{- range . }}
+	case *{{ .Name }}:
+		walk{{ .Name }}(n, f)
+	{{- end }}
+	default:
+		panic(fmt.Sprintf("ast.Inspect: unexpected node type %T", node))
+	}
+}
+
+// walkExprs traverses a list of expressions.
+func walkExprs(xs []Expr, f func(interface{}) bool) {
+	for _, x := range xs {
+		Inspect(x, f)
+	}
+}
+
+// walkStmts traverses a list of statements.
+func walkStmts(xs []Stmt, f func(interface{}) bool) {
+	for _, x := range xs {
+		Inspect(x, f)
+	}
+}
+
+{{ range . }}
+func walk{{ .Name }}(x *{{ .Name }}, f func(interface{}) bool) {
+	if x == nil || !f(x) {
+		return
+	}
+	{{- range .Fields }}
+	{{- if .Basic }}
+	{{- else if .Slice }}
+	walk{{ .Type }}s(x.{{ .Name }}, f)
+	{{- else if .Iface }}
+	Inspect(x.{{ .Name }}, f)
+	{{- else }}
+	walk{{ .Type }}(x.{{ .Name }}, f)
+	{{- end }}
+	{{- end }}
+	f(nil)
+}
+
+func walk{{ .Name }}s(xs []*{{ .Name }}, f func(interface{}) bool) {
+	for _, x := range xs {
+		walk{{ .Name }}(x, f)
+	}
+}
+{{ end }}
+`
+
+const tmplApply = `
+// applyChildren calls a.apply or a.applyList for each child of node, in the
+// order of the fields of the node type.
+//
+// applyChildren panics if node has an unexpected type.
+func (a *application) applyChildren(node interface{}) {
+	switch n := node.(type) {
+	case nil:
+	{{- range . }}
+	case *{{ .Name }}:
+		{{- range .Fields }}
+		{{- if .Basic }}
+		{{- else if .Slice }}
+		a.applyList(n, "{{ .Name }}")
+		{{- else }}
+		a.apply(n, "{{ .Name }}", nil, n.{{ .Name }})
+		{{- end }}
+		{{- end }}
+	{{- end }}
+	default:
+		panic(fmt.Sprintf("ast.Apply: unexpected node type %T", node))
+	}
+}
+`
+
+// Node describes a node type of the ast package.
+type Node struct {
+	Name   string
+	Fields []Field
+}
+
+// Field describes a field of a node, embedded fields being flattened.
+type Field struct {
+	Name string
+	Type string // type of the field, or of its elements for a slice
+
+	Basic bool // string, int64, float64 or bool
+	Iface bool // Expr or Stmt
+	Slice bool
+
+	Position bool // line number
+	Doc      bool // documentation
+}
+
+func extractField(name string, typ ast.Expr) (Field, bool) {
+	f := Field{
+		Name:     name,
+		Position: name == "Line",
+		Doc:      name == "Doc",
+	}
+
+	switch t := typ.(type) {
+	case *ast.Ident:
+		switch t.Name {
+		case "string", "int64", "float64", "bool":
+			f.Basic, f.Type = true, t.Name
+		case "Expr", "Stmt":
+			f.Iface, f.Type = true, t.Name
+		default:
+			return f, false
+		}
+	case *ast.StarExpr:
+		ident, ok := t.X.(*ast.Ident)
+		if !ok {
+			return f, false
+		}
+		f.Type = ident.Name
+	case *ast.ArrayType:
+		f.Slice = true
+		switch elt := t.Elt.(type) {
+		case *ast.Ident:
+			switch elt.Name {
+			case "string":
+				f.Basic, f.Type = true, "String"
+			case "int64":
+				f.Basic, f.Type = true, "Int64"
+			case "Expr", "Stmt":
+				f.Iface, f.Type = true, elt.Name
+			default:
+				return f, false
+			}
+		case *ast.StarExpr:
+			ident, ok := elt.X.(*ast.Ident)
+			if !ok {
+				return f, false
+			}
+			f.Type = ident.Name
+		default:
+			return f, false
+		}
+	default:
+		return f, false
+	}
+	return f, true
+}
+
+// extractFields returns the fields of a structure, including the ones
+// promoted from embedded structures.
+func extractFields(name string, st *ast.StructType) []Field {
+	var fields []Field
+	for _, field := range st.Fields.List {
+		if len(field.Names) == 0 {
+			// XXX: unsafe
+			ident := field.Type.(*ast.Ident)
+			embedded := ident.Obj.Decl.(*ast.TypeSpec).Type.(*ast.StructType)
+			fields = append(fields, extractFields(name, embedded)...)
+			continue
+		}
+		for _, n := range field.Names {
+			f, ok := extractField(n.Name, field.Type)
+			if !ok {
+				fatalf("unsupported type for %s.%s", name, n.Name)
+			}
+			fields = append(fields, f)
+		}
+	}
+	return fields
+}
+
+func warn(a ...interface{}) {
+	fmt.Fprintln(os.Stderr, a...)
+}
+
+func warnf(format string, a ...interface{}) {
+	warn(fmt.Sprintf(format, a...) + "\n")
+}
+
+func fatal(a ...interface{}) {
+	warn(a...)
+	os.Exit(1)
+}
+
+func fatalf(format string, a ...interface{}) {
+	warnf(format, a...)
+	os.Exit(1)
+}
+
+func generate(path, tmpl string, nodes []Node) {
+	buf := bytes.NewBufferString(header)
+
+	t := template.Must(template.New(path).Parse(tmpl))
+	if err := t.Execute(buf, nodes); err != nil {
+		fatal(err)
+	}
+
+	// format and write final source file
+	bs, err := imports.Process(path, buf.Bytes(), nil)
+	if err != nil {
+		fatal(err)
+	}
+	if err := ioutil.WriteFile(path, bs, 0644); err != nil {
+		fatal(err)
+	}
+}
+
+func main() {
+	flag.Parse()
+
+	fset := token.NewFileSet()
+
+	f, err := parser.ParseFile(fset, "./ast.go", nil, 0)
+	if err != nil {
+		fatal(err)
+	}
+
+	nodes := []Node{}
+	for _, decl := range f.Decls {
+		genDecl, ok := decl.(*ast.GenDecl)
+		if !ok {
+			continue
+		}
+
+		for _, spec := range genDecl.Specs {
+			typeSpec, ok := spec.(*ast.TypeSpec)
+			if !ok {
+				continue
+			}
+			structType, ok := typeSpec.Type.(*ast.StructType)
+			if !ok {
+				continue
+			}
+			name := typeSpec.Name.Name
+			nodes = append(nodes, Node{Name: name, Fields: extractFields(name, structType)})
+		}
+	}
+
+	generate("clone.gen.go", tmplClone, nodes)
+	generate("equal.gen.go", tmplEqual, nodes)
+	generate("walk.gen.go", tmplWalk, nodes)
+	generate("apply.gen.go", tmplApply, nodes)
+}
@@ -0,0 +1,42 @@
+// Copyright 2014-2015 The project AUTHORS. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package ast_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/DevMine/srcanlzr/src/ast"
+)
+
+func TestInspect(t *testing.T) {
+	var found []string
+	depth := 0
+	ast.Inspect(newFunc(), func(node interface{}) bool {
+		if node == nil {
+			depth--
+			return false
+		}
+		found = append(found, fmt.Sprintf("%d %T", depth, node))
+		depth++
+		return true
+	})
+
+	expected := []string{
+		"0 *ast.FuncDecl",
+		"1 *ast.IfStmt",
+		"2 *ast.BinaryExpr",
+		"3 *ast.Ident",
+		"3 *ast.Ident",
+		"2 *ast.ReturnStmt",
+	}
+	if strings.Join(found, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("Inspect: found %q, expected %q", found, expected)
+	}
+	if depth != 0 {
+		t.Errorf("Inspect: found %d unbalanced calls, expected 0", depth)
+	}
+}
@@ -0,0 +1,145 @@
+// Copyright 2014-2015 The project AUTHORS. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package src
+
+import "github.com/DevMine/srcanlzr/src/ast"
+
+// Clone returns a deep copy of the project.
+//
+// Since the repository uses an external type, only its top level value is
+// copied.
+func (p *Project) Clone() *Project {
+	if p == nil {
+		return nil
+	}
+	c := *p
+	if p.Repo != nil {
+		repo := *p.Repo
+		c.Repo = &repo
+	}
+	if p.Langs != nil {
+		c.Langs = make([]*Language, len(p.Langs))
+		for i, lang := range p.Langs {
+			c.Langs[i] = lang.Clone()
+		}
+	}
+	if p.Packages != nil {
+		c.Packages = make([]*Package, len(p.Packages))
+		for i, pkg := range p.Packages {
+			c.Packages[i] = pkg.Clone()
+		}
+	}
+	return &c
+}
+
+// Clone returns a deep copy of the language.
+func (l *Language) Clone() *Language {
+	if l == nil {
+		return nil
+	}
+	c := *l
+	c.Paradigms = cloneStrings(l.Paradigms)
+	return &c
+}
+
+// Clone returns a deep copy of the package.
+func (pkg *Package) Clone() *Package {
+	if pkg == nil {
+		return nil
+	}
+	c := *pkg
+	c.Doc = cloneStrings(pkg.Doc)
+	if pkg.SrcFiles != nil {
+		c.SrcFiles = make([]*SrcFile, len(pkg.SrcFiles))
+		for i, sf := range pkg.SrcFiles {
+			c.SrcFiles[i] = sf.Clone()
+		}
+	}
+	return &c
+}
+
+// Clone returns a deep copy of the source file.
+func (sf *SrcFile) Clone() *SrcFile {
+	if sf == nil {
+		return nil
+	}
+	c := *sf
+	c.Lang = sf.Lang.Clone()
+	if sf.Imports != nil {
+		c.Imports = make([]*ast.Import, len(sf.Imports))
+		for i, x := range sf.Imports {
+			c.Imports[i] = ast.Clone(x).(*ast.Import)
+		}
+	}
+	if sf.TypeSpecs != nil {
+		c.TypeSpecs = make([]*ast.TypeSpec, len(sf.TypeSpecs))
+		for i, x := range sf.TypeSpecs {
+			c.TypeSpecs[i] = ast.Clone(x).(*ast.TypeSpec)
+		}
+	}
+	if sf.Structs != nil {
+		c.Structs = make([]*ast.StructType, len(sf.Structs))
+		for i, x := range sf.Structs {
+			c.Structs[i] = ast.Clone(x).(*ast.StructType)
+		}
+	}
+	if sf.Constants != nil {
+		c.Constants = make([]*ast.GlobalDecl, len(sf.Constants))
+		for i, x := range sf.Constants {
+			c.Constants[i] = ast.Clone(x).(*ast.GlobalDecl)
+		}
+	}
+	if sf.Vars != nil {
+		c.Vars = make([]*ast.GlobalDecl, len(sf.Vars))
+		for i, x := range sf.Vars {
+			c.Vars[i] = ast.Clone(x).(*ast.GlobalDecl)
+		}
+	}
+	if sf.Funcs != nil {
+		c.Funcs = make([]*ast.FuncDecl, len(sf.Funcs))
+		for i, x := range sf.Funcs {
+			c.Funcs[i] = ast.Clone(x).(*ast.FuncDecl)
+		}
+	}
+	if sf.Interfaces != nil {
+		c.Interfaces = make([]*ast.Interface, len(sf.Interfaces))
+		for i, x := range sf.Interfaces {
+			c.Interfaces[i] = ast.Clone(x).(*ast.Interface)
+		}
+	}
+	if sf.Classes != nil {
+		c.Classes = make([]*ast.ClassDecl, len(sf.Classes))
+		for i, x := range sf.Classes {
+			c.Classes[i] = ast.Clone(x).(*ast.ClassDecl)
+		}
+	}
+	if sf.Enums != nil {
+		c.Enums = make([]*ast.EnumDecl, len(sf.Enums))
+		for i, x := range sf.Enums {
+			c.Enums[i] = ast.Clone(x).(*ast.EnumDecl)
+		}
+	}
+	if sf.Traits != nil {
+		c.Traits = make([]*ast.Trait, len(sf.Traits))
+		for i, x := range sf.Traits {
+			c.Traits[i] = ast.Clone(x).(*ast.Trait)
+		}
+	}
+	if sf.Comments != nil {
+		c.Comments = make([]*ast.Comment, len(sf.Comments))
+		for i, x := range sf.Comments {
+			c.Comments[i] = ast.Clone(x).(*ast.Comment)
+		}
+	}
+	return &c
+}
+
+// cloneStrings returns a copy of a list of strings.
+func cloneStrings(sl []string) []string {
+	if sl == nil {
+		return nil
+	}
+	return append([]string{}, sl...)
+}
@@ -0,0 +1,65 @@
+// Copyright 2014-2015 The project AUTHORS. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package src
+
+import (
+	"testing"
+
+	"github.com/DevMine/srcanlzr/src/ast"
+)
+
+func TestProjectClone(t *testing.T) {
+	prj, err := DecodeFile(inputJSON)
+	if err != nil {
+		t.Fatalf("DecodeFile '%s': %v", inputJSON, err)
+	}
+
+	c := prj.Clone()
+	if !c.Equal(prj, 0) {
+		t.Fatal("Clone: the copy differs from the original project")
+	}
+
+	// Modifying a node nested in the copy must not alter the original
+	// project.
+	orig, copied := firstIdent(prj), firstIdent(c)
+	if orig == nil || copied == nil {
+		t.Fatal("Clone: no identifier found in the function bodies")
+	}
+	if orig == copied {
+		t.Fatal("Clone: the copy shares its identifiers with the original project")
+	}
+	name := orig.Name
+	copied.Name += "_copy"
+	if orig.Name != name {
+		t.Errorf("Clone: found original identifier '%s', expected '%s'", orig.Name, name)
+	}
+	if c.Equal(prj, 0) {
+		t.Error("Clone: the copy shares memory with the original project")
+	}
+}
+
+// firstIdent returns the first identifier of the first function body of the
+// project which has one, or nil.
+func firstIdent(p *Project) *ast.Ident {
+	var found *ast.Ident
+	for _, pkg := range p.Packages {
+		for _, sf := range pkg.SrcFiles {
+			for _, f := range sf.Funcs {
+				for _, stmt := range f.Body {
+					ast.Inspect(stmt, func(n interface{}) bool {
+						if id, ok := n.(*ast.Ident); ok && found == nil {
+							found = id
+						}
+						return found == nil
+					})
+					if found != nil {
+						return found
+					}
+				}
+			}
+		}
+	}
+	return nil
+}
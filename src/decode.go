@@ -13,6 +13,7 @@ import (
 	"strconv"
 
 	"github.com/DevMine/repotool/model"
+	"github.com/DevMine/srcanlzr/src/ast"
 )
 
 type decoder struct {
@@ -298,7 +299,7 @@ func (dec *decoder) decodeSrcFile() *SrcFile {
 			sf.Lang = dec.decodeLanguage()
 		case "imports":
 			dec.scan.back()
-			sf.Imports = dec.decodeStrings()
+			sf.Imports = dec.decodeSrcFileImports()
 		case "type_specifiers":
 			dec.scan.back()
 			sf.TypeSpecs = dec.decodeTypeSpecs()
@@ -326,6 +327,9 @@ func (dec *decoder) decodeSrcFile() *SrcFile {
 		case "traits":
 			dec.scan.back()
 			sf.Traits = dec.decodeTraits()
+		case "comments":
+			dec.scan.back()
+			sf.Comments = dec.decodeComments()
 		case "loc":
 			if tok != scanInt64Lit {
 				dec.err = fmt.Errorf("expected integer literal, found %v", tok)
@@ -352,6 +356,60 @@ func (dec *decoder) decodeSrcFile() *SrcFile {
 	return &sf
 }
 
+// decodeSrcFileImports decodes the list of imports of a source file. Each
+// element of the list is either an import object or a string, in which case
+// the string is the imported path.
+func (dec *decoder) decodeSrcFileImports() []*ast.Import {
+	if dec.isNull() {
+		return nil
+	}
+	if !dec.assertNewArray() {
+		return nil
+	}
+
+	imps := []*ast.Import{}
+
+	if dec.isEmptyArray() {
+		return imps
+	}
+	if dec.err != nil {
+		return nil
+	}
+
+	for {
+		val, tok, err := dec.scan.nextValue()
+		if err != nil {
+			dec.err = err
+			return nil
+		}
+
+		var imp *ast.Import
+		switch tok {
+		case scanStringLit:
+			imp = &ast.Import{}
+			imp.Path, dec.err = dec.unmarshalString(val)
+		case scanBeginObject:
+			dec.scan.back()
+			imp = dec.decodeImport()
+		default:
+			dec.err = fmt.Errorf("expected import object or string, found %v", tok)
+		}
+		if dec.err != nil {
+			return nil
+		}
+
+		imps = append(imps, imp)
+
+		if dec.isEndArray() {
+			break
+		}
+		if dec.err != nil {
+			return nil
+		}
+	}
+	return imps
+}
+
 // decoderStrings decodes a list of strings.
 func (dec *decoder) decodeStrings() []string {
 	if dec.isNull() {
@@ -374,6 +374,12 @@ func (dec *decoder) decodeAttrRefAttrs() *ast.AttrRef {
 				}
 				expr.ExprName, dec.err = dec.unmarshalString(val)
 
+			case "expression":
+
+				dec.scan.back()
+
+				expr.X = dec.decodeExpr()
+
 			case "name":
 
 				dec.scan.back()
@@ -3857,6 +3863,88 @@ func (dec *decoder) decodeClassRef() *ast.ClassRef {
 	return &any
 }
 
+func (dec *decoder) decodeComment() *ast.Comment {
+	if dec.isNull() {
+		return nil
+	}
+	if !dec.assertNewObject() {
+		return nil
+	}
+	if dec.isEmptyObject() {
+		return nil
+	}
+	if dec.err != nil {
+		return nil
+	}
+
+	any := ast.Comment{}
+	for {
+		key, err := dec.scan.nextKey()
+		if err != nil {
+			if err == io.EOF {
+				break
+			}
+			dec.err = err
+			return nil
+		}
+		if key == "" {
+			dec.err = errors.New("empty key")
+			return nil
+		}
+
+		val, tok, err := dec.scan.nextValue()
+
+		if err != nil {
+			dec.err = err
+			return nil
+		}
+
+		if tok != scanNullVal {
+			switch key {
+
+			case "kind":
+
+				if tok != scanStringLit {
+					dec.err = fmt.Errorf("expected 'String literal', found '%v'", tok)
+					return nil
+				}
+				any.Kind, dec.err = dec.unmarshalString(val)
+
+			case "text":
+
+				if tok != scanStringLit {
+					dec.err = fmt.Errorf("expected 'String literal', found '%v'", tok)
+					return nil
+				}
+				any.Text, dec.err = dec.unmarshalString(val)
+
+			case "line":
+
+				if tok != scanInt64Lit {
+					dec.err = fmt.Errorf("expected 'Int64 literal', found '%v'", tok)
+					return nil
+				}
+				any.Line, dec.err = dec.unmarshalInt64(val)
+
+			default:
+				dec.err = fmt.Errorf("unexpected key '%s' for Comment object", key)
+			}
+		}
+
+		if dec.err != nil {
+			return nil
+		}
+
+		if dec.isEndObject() {
+			break
+		}
+		if err != nil {
+			return nil
+		}
+	}
+	return &any
+}
+
 func (dec *decoder) decodeConstant() *ast.Constant {
 	if dec.isNull() {
 		return nil
@@ -4611,6 +4699,192 @@ func (dec *decoder) decodeGlobalDecl() *ast.GlobalDecl {
 	return &any
 }
 
+func (dec *decoder) decodeImport() *ast.Import {
+	if dec.isNull() {
+		return nil
+	}
+	if !dec.assertNewObject() {
+		return nil
+	}
+	if dec.isEmptyObject() {
+		return nil
+	}
+	if dec.err != nil {
+		return nil
+	}
+
+	any := ast.Import{}
+	for {
+		key, err := dec.scan.nextKey()
+		if err != nil {
+			if err == io.EOF {
+				break
+			}
+			dec.err = err
+			return nil
+		}
+		if key == "" {
+			dec.err = errors.New("empty key")
+			return nil
+		}
+
+		val, tok, err := dec.scan.nextValue()
+
+		if err != nil {
+			dec.err = err
+			return nil
+		}
+
+		if tok != scanNullVal {
+			switch key {
+
+			case "path":
+
+				if tok != scanStringLit {
+					dec.err = fmt.Errorf("expected 'String literal', found '%v'", tok)
+					return nil
+				}
+				any.Path, dec.err = dec.unmarshalString(val)
+
+			case "alias":
+
+				if tok != scanStringLit {
+					dec.err = fmt.Errorf("expected 'String literal', found '%v'", tok)
+					return nil
+				}
+				any.Alias, dec.err = dec.unmarshalString(val)
+
+			case "names":
+
+				dec.scan.back()
+
+				any.Names = dec.decodeImportNames()
+
+			case "wildcard":
+
+				if tok != scanBoolLit {
+					dec.err = fmt.Errorf("expected 'Bool literal', found '%v'", tok)
+					return nil
+				}
+				any.Wildcard, dec.err = dec.unmarshalBool(val)
+
+			case "static":
+
+				if tok != scanBoolLit {
+					dec.err = fmt.Errorf("expected 'Bool literal', found '%v'", tok)
+					return nil
+				}
+				any.Static, dec.err = dec.unmarshalBool(val)
+
+			case "resolved":
+
+				if tok != scanStringLit {
+					dec.err = fmt.Errorf("expected 'String literal', found '%v'", tok)
+					return nil
+				}
+				any.Resolved, dec.err = dec.unmarshalString(val)
+
+			case "line":
+
+				if tok != scanInt64Lit {
+					dec.err = fmt.Errorf("expected 'Int64 literal', found '%v'", tok)
+					return nil
+				}
+				any.Line, dec.err = dec.unmarshalInt64(val)
+
+			default:
+				dec.err = fmt.Errorf("unexpected key '%s' for Import object", key)
+			}
+		}
+
+		if dec.err != nil {
+			return nil
+		}
+
+		if dec.isEndObject() {
+			break
+		}
+		if err != nil {
+			return nil
+		}
+	}
+	return &any
+}
+
+func (dec *decoder) decodeImportName() *ast.ImportName {
+	if dec.isNull() {
+		return nil
+	}
+	if !dec.assertNewObject() {
+		return nil
+	}
+	if dec.isEmptyObject() {
+		return nil
+	}
+	if dec.err != nil {
+		return nil
+	}
+
+	any := ast.ImportName{}
+	for {
+		key, err := dec.scan.nextKey()
+		if err != nil {
+			if err == io.EOF {
+				break
+			}
+			dec.err = err
+			return nil
+		}
+		if key == "" {
+			dec.err = errors.New("empty key")
+			return nil
+		}
+
+		val, tok, err := dec.scan.nextValue()
+
+		if err != nil {
+			dec.err = err
+			return nil
+		}
+
+		if tok != scanNullVal {
+			switch key {
+
+			case "name":
+
+				if tok != scanStringLit {
+					dec.err = fmt.Errorf("expected 'String literal', found '%v'", tok)
+					return nil
+				}
+				any.Name, dec.err = dec.unmarshalString(val)
+
+			case "alias":
+
+				if tok != scanStringLit {
+					dec.err = fmt.Errorf("expected 'String literal', found '%v'", tok)
+					return nil
+				}
+				any.Alias, dec.err = dec.unmarshalString(val)
+
+			default:
+				dec.err = fmt.Errorf("unexpected key '%s' for ImportName object", key)
+			}
+		}
+
+		if dec.err != nil {
+			return nil
+		}
+
+		if dec.isEndObject() {
+			break
+		}
+		if err != nil {
+			return nil
+		}
+	}
+	return &any
+}
+
 func (dec *decoder) decodeInterface() *ast.Interface {
 	if dec.isNull() {
 		return nil
@@ -6025,6 +6299,39 @@ func (dec *decoder) decodeClassRefs() []*ast.ClassRef {
 	return a
 }
 
+func (dec *decoder) decodeComments() []*ast.Comment {
+	if !dec.assertNewArray() {
+		return nil
+	}
+
+	a := []*ast.Comment{}
+
+	if dec.isEmptyArray() {
+		return a
+	}
+	if dec.err != nil {
+		return nil
+	}
+
+	for {
+		elt := dec.decodeComment()
+		if dec.err != nil {
+			return nil
+		}
+
+		a = append(a, elt)
+
+		if dec.isEndArray() {
+			break
+		}
+		if dec.err != nil {
+			return nil
+		}
+	}
+
+	return a
+}
+
 func (dec *decoder) decodeConstants() []*ast.Constant {
 	if !dec.assertNewArray() {
 		return nil
@@ -6289,6 +6596,72 @@ func (dec *decoder) decodeGlobalDecls() []*ast.GlobalDecl {
 	return a
 }
 
+func (dec *decoder) decodeImports() []*ast.Import {
+	if !dec.assertNewArray() {
+		return nil
+	}
+
+	a := []*ast.Import{}
+
+	if dec.isEmptyArray() {
+		return a
+	}
+	if dec.err != nil {
+		return nil
+	}
+
+	for {
+		elt := dec.decodeImport()
+		if dec.err != nil {
+			return nil
+		}
+
+		a = append(a, elt)
+
+		if dec.isEndArray() {
+			break
+		}
+		if dec.err != nil {
+			return nil
+		}
+	}
+
+	return a
+}
+
+func (dec *decoder) decodeImportNames() []*ast.ImportName {
+	if !dec.assertNewArray() {
+		return nil
+	}
+
+	a := []*ast.ImportName{}
+
+	if dec.isEmptyArray() {
+		return a
+	}
+	if dec.err != nil {
+		return nil
+	}
+
+	for {
+		elt := dec.decodeImportName()
+		if dec.err != nil {
+			return nil
+		}
+
+		a = append(a, elt)
+
+		if dec.isEndArray() {
+			break
+		}
+		if dec.err != nil {
+			return nil
+		}
+	}
+
+	return a
+}
+
 func (dec *decoder) decodeInterfaces() []*ast.Interface {
 	if !dec.assertNewArray() {
 		return nil
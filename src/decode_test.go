@@ -7,6 +7,7 @@ package src
 import (
 	"bytes"
 	"errors"
+	"reflect"
 	"testing"
 
 	"github.com/DevMine/srcanlzr/src/ast"
@@ -104,6 +105,64 @@ func TestDecodeIdent(t *testing.T) {
 	}
 }
 
+func TestDecodeAttrRef(t *testing.T) {
+	input := map[string]ast.Expr{
+		`{"expression_name": "ATTR_REF", "name": {"expression_name": "IDENT", "name": "b"}}`:                                                          nil,
+		`{"expression_name": "ATTR_REF", "expression": {"expression_name": "IDENT", "name": "a"}, "name": {"expression_name": "IDENT", "name": "b"}}`: &ast.Ident{ExprName: token.IdentName, Name: "a"},
+	}
+	for in, expected := range input {
+		dec := newDecoder(bytes.NewBufferString(in))
+		x := dec.decodeExpr()
+		if dec.err != nil {
+			t.Fatal(dec.err)
+		}
+		attr, ok := x.(*ast.AttrRef)
+		if !ok || attr.Name == nil || attr.Name.Name != "b" {
+			t.Fatalf("decodeExpr %s: found %#v, expected a reference to b", in, x)
+		}
+		if !reflect.DeepEqual(attr.X, expected) {
+			t.Errorf("decodeExpr %s: found receiver %#v, expected %#v", in, attr.X, expected)
+		}
+	}
+}
+
+func TestDecodeSrcFileImports(t *testing.T) {
+	buf := bytes.NewBufferString(`["fmt",{"path": "os.path", "names": [{"name": "join", "alias": "pjoin"}], "line": 3}]`)
+	dec := newDecoder(buf)
+	imps := dec.decodeSrcFileImports()
+	if dec.err != nil {
+		t.Fatal(dec.err)
+	}
+	if l := len(imps); l != 2 {
+		t.Fatalf("decodeSrcFileImports: found %d imports, expected 2", l)
+	}
+	if imps[0].Path != "fmt" {
+		t.Errorf("decodeSrcFileImports: found '%s', expected 'fmt'", imps[0].Path)
+	}
+	if imps[1].Path != "os.path" || imps[1].Line != 3 {
+		t.Errorf("decodeSrcFileImports: found '%s' at line %d, expected 'os.path' at line 3",
+			imps[1].Path, imps[1].Line)
+	}
+	if len(imps[1].Names) != 1 || imps[1].Names[0].Name != "join" || imps[1].Names[0].Alias != "pjoin" {
+		t.Errorf("decodeSrcFileImports: found names %v, expected [join as pjoin]", imps[1].Names)
+	}
+}
+
+func TestDecodeSrcFileComments(t *testing.T) {
+	buf := bytes.NewBufferString(`{"path": "foo.c", "comments": [{"kind": "BLOCK", "text": "foo\nTODO: bar", "line": 7}], "loc": 3}`)
+	dec := newDecoder(buf)
+	sf := dec.decodeSrcFile()
+	if dec.err != nil {
+		t.Fatal(dec.err)
+	}
+	if l := len(sf.Comments); l != 1 {
+		t.Fatalf("decodeSrcFile: found %d comments, expected 1", l)
+	}
+	if c := sf.Comments[0]; c.Kind != token.BlockComment || c.Text != `foo\nTODO: bar` || c.Line != 7 {
+		t.Errorf("decodeSrcFile: found comment %+v, expected a block comment at line 7", c)
+	}
+}
+
 func TestExtractFirstKey(t *testing.T) {
 	// test success
 	buf := bytes.NewBufferString(`"expression_name": "IDENT"`)
@@ -0,0 +1,117 @@
+// Copyright 2014-2015 The project AUTHORS. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package src
+
+import (
+	"reflect"
+
+	"github.com/DevMine/srcanlzr/src/ast"
+)
+
+// Equal reports whether the projects p and q are structurally equal. The mode
+// controls which parts of the projects are ignored by the comparison (see
+// ast.Equal).
+func (p *Project) Equal(q *Project, mode ast.CompareMode) bool {
+	if p == nil || q == nil {
+		return p == q
+	}
+	if p.Name != q.Name || p.LoC != q.LoC {
+		return false
+	}
+	if !reflect.DeepEqual(p.Repo, q.Repo) {
+		return false
+	}
+	if !equalLists(len(p.Langs), len(q.Langs), func(i int) bool {
+		return p.Langs[i].Equal(q.Langs[i])
+	}) {
+		return false
+	}
+	return equalLists(len(p.Packages), len(q.Packages), func(i int) bool {
+		return p.Packages[i].Equal(q.Packages[i], mode)
+	})
+}
+
+// Equal reports whether the languages l and m are equal.
+func (l *Language) Equal(m *Language) bool {
+	if l == nil || m == nil {
+		return l == m
+	}
+	return l.Lang == m.Lang && equalStrings(l.Paradigms, m.Paradigms)
+}
+
+// Equal reports whether the packages pkg and other are structurally equal.
+// See Project.Equal.
+func (pkg *Package) Equal(other *Package, mode ast.CompareMode) bool {
+	if pkg == nil || other == nil {
+		return pkg == other
+	}
+	if pkg.Name != other.Name || pkg.Path != other.Path || pkg.LoC != other.LoC {
+		return false
+	}
+	if mode&ast.IgnoreDocs == 0 && !equalStrings(pkg.Doc, other.Doc) {
+		return false
+	}
+	return equalLists(len(pkg.SrcFiles), len(other.SrcFiles), func(i int) bool {
+		return pkg.SrcFiles[i].Equal(other.SrcFiles[i], mode)
+	})
+}
+
+// Equal reports whether the source files sf and other are structurally equal.
+// See Project.Equal.
+func (sf *SrcFile) Equal(other *SrcFile, mode ast.CompareMode) bool {
+	if sf == nil || other == nil {
+		return sf == other
+	}
+	if sf.Path != other.Path || sf.LoC != other.LoC || !sf.Lang.Equal(other.Lang) {
+		return false
+	}
+
+	eq := func(x, y interface{}) bool { return ast.Equal(x, y, mode) }
+
+	return equalLists(len(sf.Imports), len(other.Imports), func(i int) bool {
+		return eq(sf.Imports[i], other.Imports[i])
+	}) && equalLists(len(sf.TypeSpecs), len(other.TypeSpecs), func(i int) bool {
+		return eq(sf.TypeSpecs[i], other.TypeSpecs[i])
+	}) && equalLists(len(sf.Structs), len(other.Structs), func(i int) bool {
+		return eq(sf.Structs[i], other.Structs[i])
+	}) && equalLists(len(sf.Constants), len(other.Constants), func(i int) bool {
+		return eq(sf.Constants[i], other.Constants[i])
+	}) && equalLists(len(sf.Vars), len(other.Vars), func(i int) bool {
+		return eq(sf.Vars[i], other.Vars[i])
+	}) && equalLists(len(sf.Funcs), len(other.Funcs), func(i int) bool {
+		return eq(sf.Funcs[i], other.Funcs[i])
+	}) && equalLists(len(sf.Interfaces), len(other.Interfaces), func(i int) bool {
+		return eq(sf.Interfaces[i], other.Interfaces[i])
+	}) && equalLists(len(sf.Classes), len(other.Classes), func(i int) bool {
+		return eq(sf.Classes[i], other.Classes[i])
+	}) && equalLists(len(sf.Enums), len(other.Enums), func(i int) bool {
+		return eq(sf.Enums[i], other.Enums[i])
+	}) && equalLists(len(sf.Traits), len(other.Traits), func(i int) bool {
+		return eq(sf.Traits[i], other.Traits[i])
+	}) && equalLists(len(sf.Comments), len(other.Comments), func(i int) bool {
+		return eq(sf.Comments[i], other.Comments[i])
+	})
+}
+
+// equalLists reports whether two lists of respective lengths n and m are
+// equal, eq being used to compare the elements at index i of both lists.
+func equalLists(n, m int, eq func(i int) bool) bool {
+	if n != m {
+		return false
+	}
+	for i := 0; i < n; i++ {
+		if !eq(i) {
+			return false
+		}
+	}
+	return true
+}
+
+// equalStrings reports whether two lists of strings are equal.
+func equalStrings(sl1, sl2 []string) bool {
+	return equalLists(len(sl1), len(sl2), func(i int) bool {
+		return sl1[i] == sl2[i]
+	})
+}
@@ -0,0 +1,211 @@
+// Copyright 2014-2015 The project AUTHORS. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package src
+
+import (
+	"path"
+	"strings"
+
+	"github.com/DevMine/srcanlzr/src/ast"
+)
+
+// ResolveImports resolves the imports of every source file of the project.
+// For each import denoting a package of the project, the path of that package
+// is stored into ast.Import.Resolved. Imports of external packages are left
+// unresolved.
+//
+// The imports are written, hence they must not be resolved concurrently with
+// another use of the project. Use ImportedPackages to resolve them without
+// modifying the project.
+func ResolveImports(p *Project) {
+	pkgs := ImportedPackages(p)
+	for _, pkg := range p.Packages {
+		for _, sf := range pkg.SrcFiles {
+			for _, imp := range sf.Imports {
+				imp.Resolved = ""
+				if ipkg := pkgs[imp]; ipkg != nil {
+					imp.Resolved = ipkg.Path
+				}
+			}
+		}
+	}
+}
+
+// ImportedPackages returns the package of the project denoted by each import of
+// the source files of the project. Imports of external packages are left out.
+// The project is only read.
+func ImportedPackages(p *Project) map[*ast.Import]*Package {
+	pkgs := make(map[*ast.Import]*Package)
+	for _, pkg := range p.Packages {
+		for _, sf := range pkg.SrcFiles {
+			for _, imp := range sf.Imports {
+				if ipkg := p.importedPackage(pkg, imp); ipkg != nil {
+					pkgs[imp] = ipkg
+				}
+			}
+		}
+	}
+	return pkgs
+}
+
+// ImportedPackage returns the package of the project denoted by imp, or nil
+// if imp refers to a package that is external to the project.
+//
+// Relative imports (e.g. Python's "from ..foo import bar") cannot be resolved
+// without knowing the importing package. Use ResolveImports for them.
+func (p *Project) ImportedPackage(imp *ast.Import) *Package {
+	return p.importedPackage(nil, imp)
+}
+
+// importedPackage looks for the project package denoted by imp when imported
+// from the package from, which may be nil.
+func (p *Project) importedPackage(from *Package, imp *ast.Import) *Package {
+	if imp == nil {
+		return nil
+	}
+	for _, cand := range importCandidates(from, imp) {
+		if pkg := p.findPackageBySuffix(cand); pkg != nil {
+			return pkg
+		}
+	}
+	return nil
+}
+
+// findPackageBySuffix returns the package denoted by the given path elements.
+//
+// Package paths are either relative to the root of the project or absolute,
+// whereas imported paths may be fully qualified (e.g.
+// "github.com/DevMine/srcanlzr/src/ast" for the package "src/ast"). Hence,
+// a package matches when one of the paths is a suffix of the other one. In the
+// latter case, the element preceding the package path must be the project
+// name, if known. A single path element must match exactly though: it would
+// otherwise turn standard imports such as "log" into imports of project
+// packages such as "internal/log". When several packages match, the one with
+// the shortest path is returned.
+func (p *Project) findPackageBySuffix(elts string) *Package {
+	var found *Package
+	for _, pkg := range p.Packages {
+		switch {
+		case pkg.Path == elts:
+		case strings.Contains(elts, "/") && strings.HasSuffix(pkg.Path, "/"+elts):
+		case strings.HasSuffix(elts, "/"+pkg.Path):
+			prefix := strings.TrimSuffix(elts, "/"+pkg.Path)
+			if p.Name != "" && path.Base(prefix) != p.Name {
+				continue
+			}
+		default:
+			continue
+		}
+		if found == nil || len(pkg.Path) < len(found.Path) {
+			found = pkg
+		}
+	}
+	return found
+}
+
+// importCandidates returns the list of package paths, from the most to the
+// least specific, that an import may refer to.
+//
+// Go-like imports use slash separated paths and denote exactly one package.
+// Dot separated paths (Java, Scala, Python, ...) may also designate a class,
+// a module or a member inside a package, hence the trailing elements are
+// dropped one by one.
+func importCandidates(from *Package, imp *ast.Import) []string {
+	p := strings.TrimSuffix(strings.TrimSuffix(imp.Path, ".*"), "/*")
+	if p == "" {
+		return nil
+	}
+
+	if strings.HasPrefix(p, ".") {
+		// relative import: each leading dot after the first one goes up one
+		// package
+		if from == nil {
+			return nil
+		}
+		rel := strings.TrimLeft(p, ".")
+		base := from.Path
+		for i := 1; i < len(p)-len(rel); i++ {
+			base = path.Dir(base)
+		}
+		if rel == "" {
+			return []string{base}
+		}
+		return []string{path.Join(base, strings.Replace(rel, ".", "/", -1))}
+	}
+
+	if strings.Contains(p, "/") || !strings.Contains(p, ".") {
+		return []string{p}
+	}
+
+	elts := strings.Split(p, ".")
+	cands := make([]string, 0, len(elts))
+	for i := len(elts); i > 0; i-- {
+		cands = append(cands, strings.Join(elts[:i], "/"))
+	}
+	return cands
+}
+
+// ImportFor returns the import of the source file that provides the given
+// namespace, as found in ast.FuncRef.Namespace, or nil if no import matches.
+//
+// Explicit matches (alias, imported name, full path or last element of the
+// path) take precedence over wildcard imports whose path is a prefix of the
+// namespace.
+func (sf *SrcFile) ImportFor(namespace string) *ast.Import {
+	if namespace == "" {
+		return nil
+	}
+
+	for _, imp := range sf.Imports {
+		if imp.Alias != "" {
+			if imp.Alias == namespace {
+				return imp
+			}
+		} else if imp.Path == namespace || localName(imp.Path) == namespace {
+			if !imp.Wildcard && len(imp.Names) == 0 {
+				return imp
+			}
+		}
+
+		for _, n := range imp.Names {
+			local := n.Alias
+			if local == "" {
+				local = n.Name
+			}
+			if local == namespace || imp.Path+"."+n.Name == namespace {
+				return imp
+			}
+		}
+	}
+
+	for _, imp := range sf.Imports {
+		if !imp.Wildcard {
+			continue
+		}
+		p := strings.TrimSuffix(strings.TrimSuffix(imp.Path, ".*"), "/*")
+		if strings.HasPrefix(namespace, p+".") || strings.HasPrefix(namespace, p+"/") {
+			return imp
+		}
+	}
+
+	return nil
+}
+
+// localName returns the name under which an imported path is known when it is
+// not aliased. This is the last element of the path, without any version
+// suffix for slash separated paths (e.g. "gopkg.in/yaml.v2" gives "yaml").
+func localName(p string) string {
+	if i := strings.LastIndex(p, "/"); i >= 0 {
+		p = p[i+1:]
+		if j := strings.Index(p, "."); j > 0 {
+			p = p[:j]
+		}
+		return p
+	}
+	if i := strings.LastIndex(p, "."); i >= 0 {
+		return p[i+1:]
+	}
+	return p
+}
@@ -0,0 +1,114 @@
+// Copyright 2014-2015 The project AUTHORS. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package src
+
+import (
+	"testing"
+
+	"github.com/DevMine/srcanlzr/src/ast"
+)
+
+func TestResolveImports(t *testing.T) {
+	imps := []*ast.Import{
+		&ast.Import{Path: "github.com/DevMine/foo/bar"},
+		&ast.Import{Path: "fmt"},
+		&ast.Import{Path: "foo.baz.Qux", Static: true},
+		&ast.Import{Path: "foo.baz.*", Wildcard: true},
+		&ast.Import{Path: "..bar", Names: []*ast.ImportName{&ast.ImportName{Name: "x"}}},
+		&ast.Import{Path: "java.util.List"},
+		&ast.Import{Path: "github.com/DevMine/other/bar"},
+	}
+	prj := &Project{
+		Name: "foo",
+		Packages: []*Package{
+			&Package{Name: "bar", Path: "bar"},
+			&Package{Name: "baz", Path: "baz", SrcFiles: []*SrcFile{&SrcFile{Imports: imps}}},
+		},
+	}
+	ResolveImports(prj)
+
+	expected := []string{"bar", "", "baz", "baz", "bar", "", ""}
+	for i, imp := range imps {
+		if imp.Resolved != expected[i] {
+			t.Errorf("ResolveImports '%s': found '%s', expected '%s'", imp.Path, imp.Resolved, expected[i])
+		}
+	}
+}
+
+func TestResolveImportsSingleElement(t *testing.T) {
+	imps := []*ast.Import{
+		&ast.Import{Path: "log"},
+		&ast.Import{Path: "errors"},
+		&ast.Import{Path: "pkg/errors"},
+		&ast.Import{Path: "github.com/DevMine/foo/log"},
+	}
+	prj := &Project{
+		Name: "foo",
+		Packages: []*Package{
+			&Package{Name: "log", Path: "internal/log"},
+			&Package{Name: "errors", Path: "pkg/errors"},
+			&Package{Name: "main", Path: "cmd/foo", SrcFiles: []*SrcFile{&SrcFile{Imports: imps}}},
+		},
+	}
+	ResolveImports(prj)
+
+	expected := []string{"", "", "pkg/errors", ""}
+	for i, imp := range imps {
+		if imp.Resolved != expected[i] {
+			t.Errorf("ResolveImports '%s': found '%s', expected '%s'", imp.Path, imp.Resolved, expected[i])
+		}
+	}
+}
+
+func TestImportedPackages(t *testing.T) {
+	imps := []*ast.Import{&ast.Import{Path: "foo/bar"}, &ast.Import{Path: "fmt"}}
+	bar := &Package{Name: "bar", Path: "bar"}
+	prj := &Project{
+		Name:     "foo",
+		Packages: []*Package{bar, &Package{Path: "baz", SrcFiles: []*SrcFile{&SrcFile{Imports: imps}}}},
+	}
+
+	pkgs := ImportedPackages(prj)
+	if len(pkgs) != 1 || pkgs[imps[0]] != bar {
+		t.Errorf("ImportedPackages: found %v, expected foo/bar to denote bar only", pkgs)
+	}
+	for _, imp := range imps {
+		if imp.Resolved != "" {
+			t.Errorf("ImportedPackages '%s': found resolved to '%s', expected the import to be left as is", imp.Path, imp.Resolved)
+		}
+	}
+}
+
+func TestImportFor(t *testing.T) {
+	sf := &SrcFile{
+		Imports: []*ast.Import{
+			&ast.Import{Path: "crypto/md5"},
+			&ast.Import{Path: "github.com/x/y", Alias: "foo"},
+			&ast.Import{Path: "os.path", Names: []*ast.ImportName{&ast.ImportName{Name: "join", Alias: "pjoin"}}},
+			&ast.Import{Path: "java.util", Wildcard: true},
+			&ast.Import{Path: "gopkg.in/yaml.v2"},
+		},
+	}
+
+	input := map[string]int{
+		"md5":            0,
+		"foo":            1,
+		"y":              -1,
+		"pjoin":          2,
+		"path":           -1,
+		"java.util.List": 3,
+		"yaml":           4,
+		"":               -1,
+	}
+	for ns, idx := range input {
+		imp := sf.ImportFor(ns)
+		switch {
+		case idx < 0 && imp != nil:
+			t.Errorf("ImportFor '%s': found '%s', expected nil", ns, imp.Path)
+		case idx >= 0 && imp != sf.Imports[idx]:
+			t.Errorf("ImportFor '%s': found %v, expected '%s'", ns, imp, sf.Imports[idx].Path)
+		}
+	}
+}
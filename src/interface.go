@@ -30,8 +30,9 @@ func DecodeFile(path string) (*Project, error) {
 // There must be at least one project. In this case, it just returns a copy of
 // the project. Moreover, the projects must be distinct.
 //
-// The merge only performs shallow copies, which means that if the field value
-// is a pointer it copies the memory address and not the value pointed.
+// The merge performs deep copies (see Project.Clone), hence the given
+// projects are never modified and the resulting project does not share any
+// memory with them.
 func MergeAll(ps ...*Project) (*Project, error) {
 	return mergeAll(ps...)
 }
@@ -39,12 +40,8 @@ func MergeAll(ps ...*Project) (*Project, error) {
 // Merge merges two project. See MergeAll for more details.
 func Merge(p1, p2 *Project) *Project {
 	// merge() merges p2 into p1, therefore we need to copy p1 before merging.
-	newPrj := &Project{
-		Name:     p1.Name,
-		Langs:    p1.Langs,
-		Packages: p1.Packages,
-		LoC:      p1.LoC,
-	}
-	merge(newPrj, p2)
+	newPrj := p1.Clone()
+	newPrj.Repo = nil
+	merge(newPrj, p2.Clone())
 	return newPrj
 }
@@ -14,13 +14,13 @@ func mergeAll(ps ...*Project) (*Project, error) {
 		return nil, errors.New("MergeAll: at least one project must be supplied")
 	}
 
-	newPrj := &Project{
-		Name:     ps[0].Name,
-		Langs:    ps[0].Langs,
-		Packages: ps[0].Packages,
-		LoC:      ps[0].LoC,
+	if ps[0] == nil {
+		return nil, errors.New("MergeAll: ps[0] is nil")
 	}
 
+	newPrj := ps[0].Clone()
+	newPrj.Repo = nil
+
 	if len(ps) == 1 {
 		return newPrj, nil
 	}
@@ -30,13 +30,14 @@ func mergeAll(ps ...*Project) (*Project, error) {
 		if curr == nil {
 			return nil, fmt.Errorf("MergeAll: ps[%d] is nil", i)
 		}
-		merge(newPrj, curr)
+		merge(newPrj, curr.Clone())
 	}
 
 	return newPrj, nil
 }
 
-// merge p2 into p1
+// merge p2 into p1. The elements of p2 are moved into p1 without being
+// copied, hence neither p1 nor p2 must be shared with the caller.
 func merge(p1, p2 *Project) {
 	if p1.Name == "" {
 		p1.Name = p2.Name
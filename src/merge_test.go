@@ -108,7 +108,7 @@ func TestMergeAll(t *testing.T) {
 	if err != nil {
 		t.Fatal(err)
 	}
-	if !equals(mergedP1, p1) {
+	if !mergedP1.Equal(p1, 0) {
 		t.Errorf("mergeAll:")
 		fmt.Println("found:")
 		prettyPrint(mergedP1)
@@ -121,85 +121,46 @@ func TestMergeAll(t *testing.T) {
 	if err != nil {
 		t.Fatal(err)
 	}
-	if !equals(mergedP1P2, p1p2) {
+	if !mergedP1P2.Equal(p1p2, 0) {
 		t.Errorf("mergeAll:")
 		fmt.Println("found:")
 		prettyPrint(mergedP1P2)
 		fmt.Println("\nexpected:")
 		prettyPrint(p1p2)
 	}
-}
-
-func equals(p1, p2 *Project) bool {
-	if p1 == nil || p2 == nil {
-		return false
-	}
 
-	if p1.Name != p2.Name {
-		return false
+	// The merged projects must not be modified.
+	if l := len(p1.Packages[0].SrcFiles); l != 1 {
+		t.Errorf("mergeAll: p1 modified, found %d source files, expected 1", l)
 	}
-
-	if len(p1.Langs) != len(p2.Langs) {
-		return false
+	mergedP1P2.Packages[0].SrcFiles[0].Path = "modified"
+	if p1.Packages[0].SrcFiles[0].Path != "foo/foo.go" {
+		t.Error("mergeAll: merged project shares memory with p1")
 	}
-	for i := 0; i < len(p1.Langs); i++ {
-		lang1 := p1.Langs[i]
-		lang2 := p2.Langs[i]
-
-		if lang1.Lang != lang2.Lang {
-			return false
-		}
+}
 
-		if len(lang1.Paradigms) != len(lang2.Paradigms) {
-			return false
-		}
-		for j := 0; j < len(lang1.Paradigms); j++ {
-			if lang1.Paradigms[j] != lang2.Paradigms[j] {
-				return false
-			}
-		}
+func TestMerge(t *testing.T) {
+	p := &Project{
+		Name: "foobar",
+		Packages: []*Package{
+			&Package{Name: "foo", Path: "foo", SrcFiles: []*SrcFile{&SrcFile{Path: "foo/foo.go"}}},
+		},
 	}
-
-	if len(p1.Packages) != len(p2.Packages) {
-		return false
+	q := &Project{
+		Name: "foobar",
+		Packages: []*Package{
+			&Package{Name: "foo", Path: "foo", SrcFiles: []*SrcFile{&SrcFile{Path: "foo/bar.go"}}},
+		},
 	}
-	for i := 0; i < len(p1.Packages); i++ {
-		pkg1 := p1.Packages[i]
-		pkg2 := p2.Packages[i]
-
-		if pkg1.Name != pkg2.Name {
-			return false
-		}
-		if pkg1.LoC != pkg2.LoC {
-			return false
-		}
+	orig := p.Clone()
 
-		if len(pkg1.Doc) != len(pkg2.Doc) {
-			return false
-		}
-		for j := 0; j < len(pkg1.Doc); j++ {
-			if pkg1.Doc[j] != pkg2.Doc[j] {
-				return false
-			}
-		}
-
-		if len(pkg1.SrcFiles) != len(pkg2.SrcFiles) {
-			return false
-		}
-		for j := 0; j < len(pkg1.SrcFiles); j++ {
-			sf1 := pkg1.SrcFiles[j]
-			sf2 := pkg2.SrcFiles[j]
-			if sf1.Path != sf2.Path {
-				return false
-			}
-		}
+	pq := Merge(p, q)
+	if l := len(pq.Packages[0].SrcFiles); l != 2 {
+		t.Errorf("Merge: found %d source files, expected 2", l)
 	}
-
-	if p1.LoC != p2.LoC {
-		return false
+	if !p.Equal(orig, 0) {
+		t.Error("Merge: p1 has been modified")
 	}
-
-	return true
 }
 
 func prettyPrint(p *Project) {
@@ -0,0 +1,1146 @@
+// Copyright 2014-2015 The project AUTHORS. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package printer renders projects, source files and AST nodes as readable
+// code.
+//
+// By default, nodes are printed as a language agnostic, indented pseudo-code
+// in which operators are printed using their names as defined by the token
+// package (e.g. "a LAND b"). Go-like syntax may be requested instead with the
+// GoSyntax mode.
+//
+// The output is meant to be read by humans (typically while debugging a
+// language parser) and is neither valid code nor meant to be parsed back.
+package printer
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/DevMine/srcanlzr/src"
+	"github.com/DevMine/srcanlzr/src/ast"
+	"github.com/DevMine/srcanlzr/src/token"
+)
+
+// A Mode value is a set of flags (or 0). They control printing.
+type Mode uint
+
+const (
+	GoSyntax Mode = 1 << iota // use a Go-like syntax instead of pseudo-code
+	SkipDocs                  // do not print the documentation of the declarations
+)
+
+// A Config controls the output of Fprint.
+type Config struct {
+	Mode     Mode // default: 0
+	Tabwidth int  // number of spaces per indentation level; 0 means a tab
+}
+
+// Fprint "pretty-prints" a node to output using the default configuration.
+// See Config.Fprint.
+func Fprint(output io.Writer, node interface{}) error {
+	return (&Config{}).Fprint(output, node)
+}
+
+// Fprint "pretty-prints" a node to output. The node must be one of *src.Project,
+// *src.Package, *src.SrcFile or a pointer to one of the node types of the ast
+// package.
+func (cfg *Config) Fprint(output io.Writer, node interface{}) error {
+	p := &printer{cfg: cfg}
+	if err := p.node(node); err != nil {
+		return err
+	}
+	_, err := output.Write(p.buf.Bytes())
+	return err
+}
+
+// Go operators, indexed by token names.
+var goOperators = map[string]string{
+	token.ADD:     "+",
+	token.SUB:     "-",
+	token.MUL:     "*",
+	token.QUO:     "/",
+	token.MOD:     "%",
+	token.AND:     "&",
+	token.OR:      "|",
+	token.XOR:     "^",
+	token.SHL:     "<<",
+	token.SHR:     ">>",
+	token.AND_NOT: "&^",
+	token.NEQ:     "!=",
+	token.LEQ:     "<=",
+	token.GEQ:     ">=",
+	token.EQ:      "==",
+	token.LSS:     "<",
+	token.GTR:     ">",
+	token.LAND:    "&&",
+	token.LOR:     "||",
+	token.INC:     "++",
+	token.DEC:     "--",
+	token.NOT:     "!",
+	token.ADDR:    "&",
+	token.STAR:    "*",
+	token.NEG:     "-",
+	token.POS:     "+",
+}
+
+// Go types, indexed by token type names.
+var goTypes = map[string]string{
+	token.TypeMapName:       "map[" + unknown + "]" + unknown,
+	token.TypeStructName:    "struct{}",
+	token.TypeArrayName:     "[]" + unknown,
+	token.TypeFuncName:      "func()",
+	token.TypeInterfaceName: "interface{}",
+}
+
+// unknown is written in place of the parts of the source code missing from
+// the model.
+const unknown = "<?>"
+
+type printer struct {
+	cfg    *Config
+	buf    bytes.Buffer
+	indent int
+}
+
+func (p *printer) goSyntax() bool {
+	return p.cfg.Mode&GoSyntax != 0
+}
+
+// write writes strings on the current line.
+func (p *printer) write(strs ...string) {
+	for _, s := range strs {
+		p.buf.WriteString(s)
+	}
+}
+
+// writeIndent writes the indentation of the current level.
+func (p *printer) writeIndent() {
+	for i := 0; i < p.indent; i++ {
+		if p.cfg.Tabwidth <= 0 {
+			p.buf.WriteByte('\t')
+		} else {
+			p.buf.WriteString(strings.Repeat(" ", p.cfg.Tabwidth))
+		}
+	}
+}
+
+// line writes an entire line at the current indentation level.
+func (p *printer) line(strs ...string) {
+	p.writeIndent()
+	p.write(strs...)
+	p.buf.WriteByte('\n')
+}
+
+// open ends the header of a block and increments the indentation level.
+func (p *printer) open() {
+	if p.goSyntax() {
+		p.write(" {")
+	}
+	p.buf.WriteByte('\n')
+	p.indent++
+}
+
+// close decrements the indentation level and writes the end of a block,
+// without ending the line.
+func (p *printer) close() {
+	p.indent--
+	p.writeIndent()
+	if p.goSyntax() {
+		p.write("}")
+	} else {
+		p.write("end")
+	}
+}
+
+// block writes a list of statements as the body of a block whose header has
+// already been written.
+func (p *printer) block(stmts []ast.Stmt) {
+	p.open()
+	p.stmts(stmts)
+	p.close()
+}
+
+// branch ends the current branch of a block and starts an alternative one
+// (else, catch, finally, ...).
+func (p *printer) branch(strs ...string) {
+	p.indent--
+	p.writeIndent()
+	if p.goSyntax() {
+		p.write("} ")
+	}
+	p.write(strs...)
+	p.open()
+}
+
+func (p *printer) doc(doc []string) {
+	if p.cfg.Mode&SkipDocs != 0 {
+		return
+	}
+	prefix := "# "
+	if p.goSyntax() {
+		prefix = "// "
+	}
+	for _, d := range doc {
+		for _, l := range strings.Split(strings.TrimRight(d, "\n"), "\n") {
+			p.line(prefix, strings.TrimSpace(l))
+		}
+	}
+}
+
+func (p *printer) node(node interface{}) error {
+	switch n := node.(type) {
+	case *src.Project:
+		p.project(n)
+	case *src.Package:
+		p.pkg(n)
+	case *src.SrcFile:
+		p.srcFile(n)
+	case *ast.FuncDecl:
+		p.funcDecl(n, "", false)
+	case *ast.MethodDecl:
+		p.methodDecl(n, "")
+	case *ast.ConstructorDecl:
+		p.constructorDecl(n, "constructor", "")
+	case *ast.DestructorDecl:
+		p.constructorDecl(&n.ConstructorDecl, "destructor", "")
+	case *ast.ClassDecl:
+		p.classDecl(n)
+	case *ast.EnumDecl:
+		p.enumDecl(n)
+	case *ast.Interface:
+		p.iface(n)
+	case *ast.Trait:
+		p.trait(n)
+	case *ast.TypeSpec:
+		p.typeSpec(n)
+	case *ast.StructType:
+		p.structDecl(n)
+	case *ast.GlobalDecl:
+		p.globalDecl(n, "declare")
+	case *ast.Import:
+		p.importDecl(n)
+	case *ast.Attr:
+		p.attr(n)
+	case *ast.ProtoDecl:
+		p.proto(n)
+	// Since ast.Expr and ast.Stmt are both empty interfaces, statements and
+	// expressions are told apart by their types.
+	case *ast.AssignStmt, *ast.DeclStmt, *ast.ExprStmt, *ast.IfStmt, *ast.LoopStmt,
+		*ast.OtherStmt, *ast.RangeLoopStmt, *ast.ReturnStmt, *ast.SwitchStmt,
+		*ast.ThrowStmt, *ast.TryStmt:
+		p.stmt(n)
+	case *ast.ArrayExpr, *ast.ArrayLit, *ast.ArrayType, *ast.AttrRef, *ast.BasicLit,
+		*ast.BinaryExpr, *ast.CallExpr, *ast.ClassLit, *ast.ConstructorCallExpr,
+		*ast.FuncLit, *ast.FuncType, *ast.Ident, *ast.IncDecExpr, *ast.IndexExpr,
+		*ast.KeyValuePair, *ast.ListLit, *ast.ListType, *ast.MapLit, *ast.MapType,
+		*ast.TernaryExpr, *ast.UnaryExpr, *ast.ValueSpec:
+		p.writeIndent()
+		p.expr(n)
+		p.buf.WriteByte('\n')
+	default:
+		return fmt.Errorf("printer: unsupported node type %T", node)
+	}
+	return nil
+}
+
+func (p *printer) project(prj *src.Project) {
+	p.line(p.comment(fmt.Sprintf("project %s (%d LoC)", prj.Name, prj.LoC)))
+	for _, pkg := range prj.Packages {
+		p.buf.WriteByte('\n')
+		p.pkg(pkg)
+	}
+}
+
+func (p *printer) pkg(pkg *src.Package) {
+	p.doc(pkg.Doc)
+	p.line("package ", pkg.Name, " ", p.comment(pkg.Path))
+	for _, sf := range pkg.SrcFiles {
+		p.buf.WriteByte('\n')
+		p.srcFile(sf)
+	}
+}
+
+func (p *printer) srcFile(sf *src.SrcFile) {
+	header := "file " + sf.Path
+	if sf.Lang != nil {
+		header += " (" + sf.Lang.Lang + ")"
+	}
+	p.line(p.comment(header))
+
+	for _, imp := range sf.Imports {
+		p.importDecl(imp)
+	}
+	for _, ts := range sf.TypeSpecs {
+		p.typeSpec(ts)
+	}
+	for _, st := range sf.Structs {
+		p.structDecl(st)
+	}
+	for _, c := range sf.Constants {
+		p.globalDecl(c, "const")
+	}
+	for _, v := range sf.Vars {
+		p.globalDecl(v, "var")
+	}
+	for _, i := range sf.Interfaces {
+		p.buf.WriteByte('\n')
+		p.iface(i)
+	}
+	for _, c := range sf.Classes {
+		p.buf.WriteByte('\n')
+		p.classDecl(c)
+	}
+	for _, e := range sf.Enums {
+		p.buf.WriteByte('\n')
+		p.enumDecl(e)
+	}
+	for _, t := range sf.Traits {
+		p.buf.WriteByte('\n')
+		p.trait(t)
+	}
+	for _, f := range sf.Funcs {
+		p.buf.WriteByte('\n')
+		p.funcDecl(f, "", false)
+	}
+}
+
+// comment returns s as a comment.
+func (p *printer) comment(s string) string {
+	if p.goSyntax() {
+		return "// " + s
+	}
+	return "# " + s
+}
+
+// visibility returns the visibility followed by a space, or an empty string
+// in Go syntax since it is implied by the names.
+func (p *printer) visibility(v string) string {
+	if v == "" || p.goSyntax() {
+		return ""
+	}
+	return v + " "
+}
+
+func (p *printer) importDecl(imp *ast.Import) {
+	path := imp.Path
+	if imp.Wildcard && !strings.HasSuffix(path, "*") {
+		path += ".*"
+	}
+	kw := "import "
+	if imp.Static {
+		kw = "import static "
+	}
+	if p.goSyntax() {
+		path = `"` + path + `"`
+	}
+
+	if len(imp.Names) > 0 {
+		names := make([]string, 0, len(imp.Names))
+		for _, n := range imp.Names {
+			names = append(names, alias(n.Name, n.Alias))
+		}
+		p.line("from ", path, " ", kw, strings.Join(names, ", "))
+		return
+	}
+	if imp.Alias != "" && p.goSyntax() {
+		p.line(kw, imp.Alias, " ", path)
+		return
+	}
+	p.line(kw, alias(path, imp.Alias))
+}
+
+func alias(name, alias string) string {
+	if alias == "" {
+		return name
+	}
+	return name + " as " + alias
+}
+
+func (p *printer) typeSpec(ts *ast.TypeSpec) {
+	p.doc(ts.Doc)
+	p.writeIndent()
+	p.write("type ", identName(ts.Name), " ")
+	p.expr(ts.Type)
+	p.buf.WriteByte('\n')
+}
+
+func (p *printer) structDecl(st *ast.StructType) {
+	p.doc(st.Doc)
+	p.writeIndent()
+	if p.goSyntax() {
+		p.write("type ", identName(st.Name), " struct")
+	} else {
+		p.write("struct ", identName(st.Name))
+	}
+	p.fieldsBlock(st.Fields)
+	p.buf.WriteByte('\n')
+}
+
+// fieldsBlock writes a list of fields as a block.
+func (p *printer) fieldsBlock(fields []*ast.Field) {
+	p.open()
+	for _, f := range fields {
+		if f == nil {
+			continue
+		}
+		p.doc(f.Doc)
+		p.line(p.field(f))
+	}
+	p.close()
+}
+
+// field returns the name and the type of a field, or a placeholder when the
+// field is missing.
+func (p *printer) field(f *ast.Field) string {
+	if f == nil {
+		return unknown
+	}
+	return strings.TrimSpace(f.Name + " " + p.typeName(f.Type))
+}
+
+// typeName returns the name of a type. In Go syntax, the kinds of types the
+// model only knows by name are written as Go types whose unknown parts are
+// placeholders.
+func (p *printer) typeName(typ string) string {
+	if p.goSyntax() {
+		if s, ok := goTypes[typ]; ok {
+			return s
+		}
+	}
+	return typ
+}
+
+func (p *printer) globalDecl(gd *ast.GlobalDecl, kind string) {
+	p.doc(gd.Doc)
+	p.writeIndent()
+	p.write(p.visibility(gd.Visibility), kind, " ", identName(gd.Name))
+	if gd.Type != nil {
+		p.write(" ", identName(gd.Type))
+	}
+	if gd.Value != nil {
+		p.write(" = ")
+		p.expr(gd.Value)
+	}
+	p.buf.WriteByte('\n')
+}
+
+func (p *printer) params(fields []*ast.Field) string {
+	strs := make([]string, 0, len(fields))
+	for _, f := range fields {
+		strs = append(strs, p.field(f))
+	}
+	return "(" + strings.Join(strs, ", ") + ")"
+}
+
+// signature returns the parameters and the results of a function type.
+func (p *printer) signature(ft *ast.FuncType) string {
+	if ft == nil {
+		return "()"
+	}
+	sig := p.params(ft.Params)
+	switch {
+	case len(ft.Results) == 0:
+	case p.goSyntax() && len(ft.Results) == 1 && (ft.Results[0] == nil || ft.Results[0].Name == ""):
+		sig += " " + p.field(ft.Results[0])
+	case p.goSyntax():
+		sig += " " + p.params(ft.Results)
+	default:
+		sig += " -> " + p.params(ft.Results)
+	}
+	return sig
+}
+
+// funcDecl writes a function declaration, or a method declaration of the
+// class recv, which may be unknown.
+func (p *printer) funcDecl(f *ast.FuncDecl, recv string, method bool) {
+	p.doc(f.Doc)
+	p.writeIndent()
+	if p.goSyntax() {
+		p.write("func ")
+		if recv != "" {
+			p.write("(", recv, ") ")
+		}
+	} else {
+		p.write(p.visibility(f.Visibility))
+		if method {
+			p.write("method ")
+		} else {
+			p.write("function ")
+		}
+	}
+	p.write(f.Name, p.signature(f.Type))
+	p.block(f.Body)
+	p.buf.WriteByte('\n')
+}
+
+func (p *printer) methodDecl(m *ast.MethodDecl, recv string) {
+	if m.Override {
+		p.line(p.comment("override"))
+	}
+	p.funcDecl(&m.FuncDecl, recv, true)
+}
+
+// constructorDecl writes a constructor or a destructor.
+func (p *printer) constructorDecl(c *ast.ConstructorDecl, kind, class string) {
+	p.doc(c.Doc)
+	p.writeIndent()
+	if p.goSyntax() {
+		if kind == "constructor" {
+			p.write("func New", c.Name)
+		} else {
+			p.write("func (", class, ") Destroy", c.Name)
+		}
+	} else {
+		p.write(p.visibility(c.Visibility), kind, " ", c.Name)
+	}
+	p.write(p.params(c.Params))
+	p.block(c.Body)
+	p.buf.WriteByte('\n')
+}
+
+func (p *printer) attr(a *ast.Attr) {
+	p.doc(a.Doc)
+	p.writeIndent()
+	if !p.goSyntax() {
+		p.write(p.visibility(a.Visibility))
+		if a.Static {
+			p.write("static ")
+		}
+		if a.Constant {
+			p.write("constant ")
+		}
+	}
+	p.write(strings.TrimSpace(a.Name + " " + p.typeName(a.Type)))
+	if a.Value != "" {
+		p.write(" = ", a.Value)
+	}
+	p.buf.WriteByte('\n')
+}
+
+func (p *printer) classDecl(c *ast.ClassDecl) {
+	p.doc(c.Doc)
+	p.writeIndent()
+	if p.goSyntax() {
+		p.write("type ", c.Name, " struct")
+	} else {
+		p.write(p.visibility(c.Visibility), "class ", c.Name)
+		p.write(p.hierarchy(c.ExtendedClasses, c.ImplementedInterfaces, c.Mixins))
+	}
+	p.open()
+	if p.goSyntax() {
+		// embedded types
+		for _, ref := range c.ExtendedClasses {
+			p.line(qualified(ref.Namespace, ref.ClassName))
+		}
+		for _, ref := range c.Mixins {
+			p.line(qualified(ref.Namespace, ref.TraitName))
+		}
+	}
+	for _, a := range c.Attrs {
+		p.attr(a)
+	}
+	if p.goSyntax() {
+		// constructors, methods and nested classes are printed after the
+		// structure definition
+		p.close()
+		p.buf.WriteByte('\n')
+	}
+	p.classMembers(c.Name, c.Constructors, c.Destructors, c.Methods)
+	for _, nc := range c.NestedClasses {
+		p.classDecl(nc)
+	}
+	if !p.goSyntax() {
+		p.close()
+		p.buf.WriteByte('\n')
+	}
+}
+
+func (p *printer) classMembers(class string, cstrs []*ast.ConstructorDecl, dstrs []*ast.DestructorDecl, mthds []*ast.MethodDecl) {
+	for _, c := range cstrs {
+		p.constructorDecl(c, "constructor", class)
+	}
+	for _, d := range dstrs {
+		p.constructorDecl(&d.ConstructorDecl, "destructor", class)
+	}
+	for _, m := range mthds {
+		p.methodDecl(m, class)
+	}
+}
+
+// hierarchy returns the list of extended classes, implemented interfaces and
+// mixins of a type.
+func (p *printer) hierarchy(classes []*ast.ClassRef, ifaces []*ast.InterfaceRef, mixins []*ast.TraitRef) string {
+	var s string
+	if len(classes) > 0 {
+		names := make([]string, 0, len(classes))
+		for _, ref := range classes {
+			names = append(names, qualified(ref.Namespace, ref.ClassName))
+		}
+		s += " extends " + strings.Join(names, ", ")
+	}
+	if len(ifaces) > 0 {
+		s += " implements " + interfaceNames(ifaces)
+	}
+	if len(mixins) > 0 {
+		names := make([]string, 0, len(mixins))
+		for _, ref := range mixins {
+			names = append(names, qualified(ref.Namespace, ref.TraitName))
+		}
+		s += " with " + strings.Join(names, ", ")
+	}
+	return s
+}
+
+func interfaceNames(refs []*ast.InterfaceRef) string {
+	names := make([]string, 0, len(refs))
+	for _, ref := range refs {
+		names = append(names, qualified(ref.Namespace, ref.InterfaceName))
+	}
+	return strings.Join(names, ", ")
+}
+
+func qualified(namespace, name string) string {
+	if namespace == "" {
+		return name
+	}
+	return namespace + "." + name
+}
+
+func (p *printer) enumDecl(e *ast.EnumDecl) {
+	p.doc(e.Doc)
+	p.writeIndent()
+	p.write(p.visibility(e.Visibility), "enum ", e.Name)
+	if len(e.ImplementedInterfaces) > 0 && !p.goSyntax() {
+		p.write(" implements ", interfaceNames(e.ImplementedInterfaces))
+	}
+	p.open()
+	for _, c := range e.EnumConstants {
+		p.line(identName(c))
+	}
+	for _, a := range e.Attrs {
+		p.attr(a)
+	}
+	p.classMembers(e.Name, e.Constructors, e.Destructors, e.Methods)
+	p.close()
+	p.buf.WriteByte('\n')
+}
+
+func (p *printer) iface(i *ast.Interface) {
+	p.doc(i.Doc)
+	p.writeIndent()
+	if p.goSyntax() {
+		p.write("type ", i.Name, " interface")
+	} else {
+		p.write(p.visibility(i.Visibility), "interface ", i.Name)
+		if len(i.ImplementedInterfaces) > 0 {
+			p.write(" extends ", interfaceNames(i.ImplementedInterfaces))
+		}
+	}
+	p.open()
+	if p.goSyntax() {
+		for _, ref := range i.ImplementedInterfaces {
+			p.line(qualified(ref.Namespace, ref.InterfaceName))
+		}
+	}
+	for _, proto := range i.Protos {
+		p.proto(proto)
+	}
+	p.close()
+	p.buf.WriteByte('\n')
+}
+
+func (p *printer) proto(proto *ast.ProtoDecl) {
+	p.doc(proto.Doc)
+	if p.goSyntax() {
+		p.line(identName(proto.Name), p.signature(proto.Type))
+		return
+	}
+	p.line(p.visibility(proto.Visibility), "method ", identName(proto.Name), p.signature(proto.Type))
+}
+
+func (p *printer) trait(t *ast.Trait) {
+	p.writeIndent()
+	p.write("trait ", t.Name)
+	p.open()
+	for _, a := range t.Attrs {
+		p.attr(a)
+	}
+	for _, m := range t.Methods {
+		p.methodDecl(m, t.Name)
+	}
+	for _, c := range t.Classes {
+		p.classDecl(c)
+	}
+	for _, nt := range t.Traits {
+		p.trait(nt)
+	}
+	p.close()
+	p.buf.WriteByte('\n')
+}
+
+func identName(id *ast.Ident) string {
+	if id == nil {
+		return ""
+	}
+	return id.Name
+}
+
+func (p *printer) stmts(stmts []ast.Stmt) {
+	for _, s := range stmts {
+		p.stmt(s)
+	}
+}
+
+func (p *printer) stmt(s ast.Stmt) {
+	switch s := s.(type) {
+	case nil:
+	case *ast.AssignStmt:
+		p.writeIndent()
+		p.assign(s, "=")
+		p.buf.WriteByte('\n')
+	case *ast.DeclStmt:
+		p.writeIndent()
+		switch {
+		case p.goSyntax() && s.Kind == token.ConstDecl:
+			p.write("const ")
+			p.assign(&s.AssignStmt, "=")
+		case p.goSyntax():
+			p.assign(&s.AssignStmt, ":=")
+		case s.Kind == token.ConstDecl:
+			p.write("const ")
+			p.assign(&s.AssignStmt, "=")
+		default:
+			p.write("var ")
+			p.assign(&s.AssignStmt, "=")
+		}
+		p.buf.WriteByte('\n')
+	case *ast.ExprStmt:
+		p.writeIndent()
+		p.expr(s.X)
+		p.buf.WriteByte('\n')
+	case *ast.IfStmt:
+		p.writeIndent()
+		p.write("if ")
+		if s.Init != nil {
+			p.simpleStmt(s.Init)
+			p.write("; ")
+		}
+		p.expr(s.Cond)
+		p.open()
+		p.stmts(s.Body)
+		if len(s.Else) > 0 {
+			p.branch("else")
+			p.stmts(s.Else)
+		}
+		p.close()
+		p.buf.WriteByte('\n')
+	case *ast.LoopStmt:
+		p.loop(s)
+	case *ast.RangeLoopStmt:
+		p.writeIndent()
+		p.write("for ")
+		p.exprList(s.Vars)
+		if p.goSyntax() {
+			p.write(" := range ")
+		} else {
+			p.write(" in ")
+		}
+		p.expr(s.Iterable)
+		p.block(s.Body)
+		p.buf.WriteByte('\n')
+	case *ast.ReturnStmt:
+		p.writeIndent()
+		p.write("return")
+		if len(s.Results) > 0 {
+			p.write(" ")
+			p.exprList(s.Results)
+		}
+		p.buf.WriteByte('\n')
+	case *ast.SwitchStmt:
+		p.switchStmt(s)
+	case *ast.ThrowStmt:
+		p.writeIndent()
+		if p.goSyntax() {
+			p.write("panic(")
+			p.expr(s.X)
+			p.write(")")
+		} else {
+			p.write("throw ")
+			p.expr(s.X)
+		}
+		p.buf.WriteByte('\n')
+	case *ast.TryStmt:
+		p.tryStmt(s)
+	case *ast.OtherStmt:
+		p.writeIndent()
+		p.write(p.comment("unsupported statement"))
+		if len(s.Body) > 0 {
+			p.buf.WriteByte('\n')
+			p.writeIndent()
+			p.write("other")
+			p.block(s.Body)
+		}
+		p.buf.WriteByte('\n')
+	default:
+		p.line(p.comment(fmt.Sprintf("unknown statement %T", s)))
+	}
+}
+
+// simpleStmt writes a statement that is part of another one (e.g. the
+// initialization of an if statement) on the current line.
+func (p *printer) simpleStmt(s ast.Stmt) {
+	switch s := s.(type) {
+	case *ast.AssignStmt:
+		p.assign(s, "=")
+	case *ast.DeclStmt:
+		if p.goSyntax() {
+			p.assign(&s.AssignStmt, ":=")
+		} else {
+			p.write("var ")
+			p.assign(&s.AssignStmt, "=")
+		}
+	case *ast.ExprStmt:
+		p.expr(s.X)
+	default:
+		p.write(fmt.Sprintf("<%T>", s))
+	}
+}
+
+func (p *printer) simpleStmts(stmts []ast.Stmt) {
+	for i, s := range stmts {
+		if i > 0 {
+			p.write(", ")
+		}
+		p.simpleStmt(s)
+	}
+}
+
+func (p *printer) assign(s *ast.AssignStmt, op string) {
+	p.exprList(s.LHS)
+	if len(s.RHS) > 0 {
+		p.write(" ", op, " ")
+		p.exprList(s.RHS)
+	}
+}
+
+func (p *printer) loop(s *ast.LoopStmt) {
+	p.writeIndent()
+	if s.IsPostEval && !p.goSyntax() {
+		p.write("do")
+		p.block(s.Body)
+		p.write(" while ")
+		p.expr(s.Cond)
+		p.buf.WriteByte('\n')
+		return
+	}
+
+	if p.goSyntax() {
+		p.write("for")
+	} else {
+		p.write("loop")
+	}
+	if len(s.Init) > 0 || len(s.Post) > 0 {
+		p.write(" ")
+		p.simpleStmts(s.Init)
+		p.write("; ")
+		p.expr(s.Cond)
+		p.write("; ")
+		p.simpleStmts(s.Post)
+	} else if s.Cond != nil {
+		p.write(" ")
+		p.expr(s.Cond)
+	}
+	p.open()
+	p.stmts(s.Body)
+	if len(s.Else) > 0 {
+		p.branch("else")
+		p.stmts(s.Else)
+	}
+	p.close()
+	p.buf.WriteByte('\n')
+}
+
+func (p *printer) switchStmt(s *ast.SwitchStmt) {
+	p.writeIndent()
+	p.write("switch")
+	if s.Init != nil {
+		p.write(" ")
+		p.simpleStmt(s.Init)
+		p.write(";")
+	}
+	if s.Cond != nil {
+		p.write(" ")
+		p.expr(s.Cond)
+	}
+	p.open()
+	for _, cc := range s.CaseClauses {
+		if len(cc.Conds) == 0 {
+			// some parsers store the default clause as a case without conditions
+			p.line("default:")
+		} else {
+			p.writeIndent()
+			p.write("case ")
+			p.exprList(cc.Conds)
+			p.write(":\n")
+		}
+		p.indent++
+		p.stmts(cc.Body)
+		p.indent--
+	}
+	if s.Default != nil {
+		p.line("default:")
+		p.indent++
+		p.stmts(s.Default)
+		p.indent--
+	}
+	p.close()
+	p.buf.WriteByte('\n')
+}
+
+func (p *printer) tryStmt(s *ast.TryStmt) {
+	p.writeIndent()
+	p.write("try")
+	p.open()
+	p.stmts(s.Body)
+	for _, cc := range s.CatchClauses {
+		p.branch("catch ", p.params(cc.Params))
+		p.stmts(cc.Body)
+	}
+	if len(s.Finally) > 0 {
+		p.branch("finally")
+		p.stmts(s.Finally)
+	}
+	p.close()
+	p.buf.WriteByte('\n')
+}
+
+// operator returns the representation of an operator, or a placeholder when
+// the operator is unknown.
+func (p *printer) operator(op string) string {
+	if op == "" {
+		return unknown
+	}
+	if p.goSyntax() {
+		if s, ok := goOperators[op]; ok {
+			return s
+		}
+	}
+	return op
+}
+
+func (p *printer) exprList(exprs []ast.Expr) {
+	for i, e := range exprs {
+		if i > 0 {
+			p.write(", ")
+		}
+		p.expr(e)
+	}
+}
+
+// operand writes an operand of an expression, between parentheses when it
+// is a compound expression.
+func (p *printer) operand(e ast.Expr) {
+	switch e.(type) {
+	case *ast.BinaryExpr, *ast.TernaryExpr:
+		p.write("(")
+		p.expr(e)
+		p.write(")")
+	default:
+		p.expr(e)
+	}
+}
+
+func (p *printer) expr(e ast.Expr) {
+	switch e := e.(type) {
+	case nil:
+	case *ast.Ident:
+		p.write(e.Name)
+	case *ast.BasicLit:
+		switch e.Kind {
+		case token.StringLit:
+			p.write(`"`, e.Value, `"`)
+		case token.CharLit:
+			p.write("'", e.Value, "'")
+		default:
+			p.write(e.Value)
+		}
+	case *ast.BinaryExpr:
+		p.operand(e.LeftExpr)
+		p.write(" ", p.operator(e.Op), " ")
+		p.operand(e.RightExpr)
+	case *ast.UnaryExpr:
+		op := p.operator(e.Op)
+		if !p.goSyntax() {
+			op += " "
+		}
+		p.write(op)
+		p.operand(e.X)
+	case *ast.IncDecExpr:
+		op := p.operator(e.Op)
+		if e.IsPre {
+			if !p.goSyntax() {
+				op += " "
+			}
+			p.write(op)
+			p.operand(e.X)
+		} else {
+			p.operand(e.X)
+			if !p.goSyntax() {
+				op = " " + op
+			}
+			p.write(op)
+		}
+	case *ast.TernaryExpr:
+		if p.goSyntax() {
+			p.operand(e.Cond)
+			p.write(" ? ")
+			p.operand(e.Then)
+			p.write(" : ")
+			p.operand(e.Else)
+		} else {
+			p.write("if ")
+			p.operand(e.Cond)
+			p.write(" then ")
+			p.operand(e.Then)
+			p.write(" else ")
+			p.operand(e.Else)
+		}
+	case *ast.CallExpr:
+		p.call(e)
+	case *ast.ConstructorCallExpr:
+		p.write("new ")
+		p.call(&e.CallExpr)
+	case *ast.IndexExpr:
+		p.operand(e.X)
+		p.write("[")
+		p.expr(e.Index)
+		p.write("]")
+	case *ast.AttrRef:
+		if e.X != nil {
+			p.operand(e.X)
+			p.write(".")
+		} else {
+			p.write("this.")
+		}
+		p.write(identName(e.Name))
+	case *ast.ValueSpec:
+		p.write(identName(e.Name))
+		if e.Type != nil {
+			p.write(" ", identName(e.Type))
+		}
+	case *ast.KeyValuePair:
+		p.expr(e.Key)
+		p.write(": ")
+		p.expr(e.Value)
+	case *ast.ArrayExpr:
+		if e.Type != nil {
+			p.expr(e.Type)
+		}
+	case *ast.ArrayType:
+		if len(e.Dims) == 0 {
+			p.write("[]")
+		}
+		for _, d := range e.Dims {
+			p.write(fmt.Sprintf("[%d]", d))
+		}
+		p.expr(e.Elt)
+	case *ast.ListType:
+		if p.goSyntax() {
+			p.write("[]")
+			p.expr(e.Elt)
+		} else {
+			p.write("list<")
+			p.expr(e.Elt)
+			p.write(">")
+		}
+	case *ast.MapType:
+		p.write("map[")
+		p.expr(e.KeyType)
+		p.write("]")
+		p.expr(e.ValueType)
+	case *ast.ArrayLit:
+		p.composite(e.Type, e.Elts)
+	case *ast.ListLit:
+		p.composite(e.Type, e.Elts)
+	case *ast.MapLit:
+		elts := make([]ast.Expr, 0, len(e.Elts))
+		for _, kv := range e.Elts {
+			elts = append(elts, kv)
+		}
+		p.composite(e.Type, elts)
+	case *ast.FuncLit:
+		if p.goSyntax() {
+			p.write("func")
+		} else {
+			p.write("function")
+		}
+		p.write(p.signature(e.Type))
+		p.block(e.Body)
+	case *ast.ClassLit:
+		p.write("class")
+		if !p.goSyntax() {
+			p.write(p.hierarchy(e.ExtendedClasses, e.ImplementedInterfaces, nil))
+		}
+		p.open()
+		for _, a := range e.Attrs {
+			p.attr(a)
+		}
+		p.classMembers("", e.Constructors, e.Destructors, e.Methods)
+		p.close()
+	case *ast.StructType:
+		p.write("struct")
+		p.fieldsBlock(e.Fields)
+	case *ast.FuncType:
+		if p.goSyntax() {
+			p.write("func")
+		} else {
+			p.write("function")
+		}
+		p.write(p.signature(e))
+	default:
+		p.write(fmt.Sprintf("<%T>", e))
+	}
+}
+
+func (p *printer) call(c *ast.CallExpr) {
+	if c.Fun != nil {
+		p.write(qualified(c.Fun.Namespace, c.Fun.FuncName))
+	}
+	p.write("(")
+	p.exprList(c.Args)
+	p.write(")")
+}
+
+// composite writes a composite literal.
+func (p *printer) composite(typ ast.Expr, elts []ast.Expr) {
+	if p.goSyntax() {
+		if typ != nil && !isNilPointer(typ) {
+			p.expr(typ)
+		}
+		p.write("{")
+		p.exprList(elts)
+		p.write("}")
+		return
+	}
+	p.write("[")
+	p.exprList(elts)
+	p.write("]")
+}
+
+// isNilPointer reports whether x is a nil pointer to one of the types used
+// in composite literals.
+func isNilPointer(x interface{}) bool {
+	switch x := x.(type) {
+	case *ast.ArrayType:
+		return x == nil
+	case *ast.ListType:
+		return x == nil
+	case *ast.MapType:
+		return x == nil
+	}
+	return false
+}
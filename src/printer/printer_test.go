@@ -0,0 +1,159 @@
+// Copyright 2014-2015 The project AUTHORS. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package printer
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/DevMine/srcanlzr/src"
+	"github.com/DevMine/srcanlzr/src/ast"
+	"github.com/DevMine/srcanlzr/src/token"
+)
+
+var funcDecl = &ast.FuncDecl{
+	Doc:        []string{"max returns the larger of a and b."},
+	Name:       "max",
+	Visibility: token.PublicVisibility,
+	Type: &ast.FuncType{
+		Params:  []*ast.Field{&ast.Field{Name: "a", Type: "int"}, &ast.Field{Name: "b", Type: "int"}},
+		Results: []*ast.Field{&ast.Field{Type: "int"}},
+	},
+	Body: []ast.Stmt{
+		&ast.IfStmt{
+			StmtName: token.IfStmtName,
+			Cond: &ast.BinaryExpr{
+				ExprName:  token.BinaryExprName,
+				LeftExpr:  &ast.Ident{ExprName: token.IdentName, Name: "a"},
+				Op:        token.GTR,
+				RightExpr: &ast.Ident{ExprName: token.IdentName, Name: "b"},
+			},
+			Body: []ast.Stmt{
+				&ast.ReturnStmt{
+					StmtName: token.ReturnStmtName,
+					Results:  []ast.Expr{&ast.Ident{ExprName: token.IdentName, Name: "a"}},
+				},
+			},
+			Else: []ast.Stmt{
+				&ast.ExprStmt{
+					StmtName: token.ExprStmtName,
+					X: &ast.CallExpr{
+						ExprName: token.CallExprName,
+						Fun:      &ast.FuncRef{Namespace: "fmt", FuncName: "Println"},
+						Args: []ast.Expr{
+							&ast.UnaryExpr{
+								ExprName: token.UnaryExprName,
+								Op:       token.NOT,
+								X:        &ast.Ident{ExprName: token.IdentName, Name: "ok"},
+							},
+						},
+					},
+				},
+			},
+		},
+		&ast.ReturnStmt{
+			StmtName: token.ReturnStmtName,
+			Results:  []ast.Expr{&ast.Ident{ExprName: token.IdentName, Name: "b"}},
+		},
+	},
+}
+
+func TestFprint(t *testing.T) {
+	input := map[Mode]string{
+		0: `# max returns the larger of a and b.
+public function max(a int, b int) -> (int)
+  if a GTR b
+    return a
+  else
+    fmt.Println(NOT ok)
+  end
+  return b
+end
+`,
+		GoSyntax | SkipDocs: `func max(a int, b int) int {
+  if a > b {
+    return a
+  } else {
+    fmt.Println(!ok)
+  }
+  return b
+}
+`,
+	}
+
+	for mode, expected := range input {
+		buf := new(bytes.Buffer)
+		cfg := &Config{Mode: mode, Tabwidth: 2}
+		if err := cfg.Fprint(buf, funcDecl); err != nil {
+			t.Fatal(err)
+		}
+		if found := buf.String(); found != expected {
+			t.Errorf("Fprint mode %d: found\n%s\nexpected\n%s", mode, found, expected)
+		}
+	}
+}
+
+func TestFprintUnsupported(t *testing.T) {
+	if err := Fprint(new(bytes.Buffer), 42); err == nil {
+		t.Error("Fprint 42: found no error, expected an error")
+	}
+}
+
+func TestFprintSrcFiles(t *testing.T) {
+	p, err := src.DecodeFile("../testdata/simple.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, pkg := range p.Packages {
+		for _, sf := range pkg.SrcFiles {
+			for _, mode := range []Mode{0, GoSyntax} {
+				buf := new(bytes.Buffer)
+				if err := (&Config{Mode: mode}).Fprint(buf, sf); err != nil {
+					t.Errorf("Fprint %s mode %d: %v", sf.Path, mode, err)
+				}
+			}
+		}
+	}
+}
+
+func TestFprintExpr(t *testing.T) {
+	input := map[string]ast.Expr{
+		"a <?> b": &ast.BinaryExpr{
+			LeftExpr:  &ast.Ident{Name: "a"},
+			RightExpr: &ast.Ident{Name: "b"},
+		},
+		"a.b":    &ast.AttrRef{X: &ast.Ident{Name: "a"}, Name: &ast.Ident{Name: "b"}},
+		"this.b": &ast.AttrRef{Name: &ast.Ident{Name: "b"}},
+	}
+
+	for expected, e := range input {
+		p := &printer{cfg: &Config{Mode: GoSyntax}}
+		p.expr(e)
+		if found := p.buf.String(); found != expected {
+			t.Errorf("expr: found %q, expected %q", found, expected)
+		}
+	}
+}
+
+func TestFprintNilFields(t *testing.T) {
+	st := &ast.StructType{
+		Name:   &ast.Ident{Name: "s"},
+		Fields: []*ast.Field{nil, &ast.Field{Name: "m", Type: token.TypeMapName}},
+	}
+	buf := new(bytes.Buffer)
+	if err := (&Config{Mode: GoSyntax, Tabwidth: 2}).Fprint(buf, st); err != nil {
+		t.Fatal(err)
+	}
+	expected := "type s struct {\n  m map[<?>]<?>\n}\n"
+	if found := buf.String(); found != expected {
+		t.Errorf("Fprint: found %q, expected %q", found, expected)
+	}
+
+	ft := &ast.FuncType{Params: []*ast.Field{nil}, Results: []*ast.Field{nil}}
+	p := &printer{cfg: &Config{Mode: GoSyntax}}
+	if found, expected := p.signature(ft), "(<?>) <?>"; found != expected {
+		t.Errorf("signature: found %q, expected %q", found, expected)
+	}
+}
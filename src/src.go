@@ -120,7 +120,10 @@ type SrcFile struct {
 	Lang *Language `json:"language"`
 
 	// List of the imports used by the srouce file.
-	Imports []string `json:"imports,omitempty"`
+	//
+	// For backward compatibility, the decoder also accepts plain strings
+	// holding the imported path in place of import objects.
+	Imports []*ast.Import `json:"imports,omitempty"`
 
 	// Types definition
 	TypeSpecs []*ast.TypeSpec `json:"type_specifiers,omitempty"`
@@ -151,6 +154,10 @@ type SrcFile struct {
 	// See http://en.wikipedia.org/wiki/Trait_%28computer_programming%29
 	Traits []*ast.Trait `json:"traits,omitempty"`
 
+	// List of all the comments of the source file, in order of appearance,
+	// including the documentation already attached to the declarations.
+	Comments []*ast.Comment `json:"comments,omitempty"`
+
 	// The total number of lines of code.
 	LoC int64 `json:"loc"`
 }
@@ -49,6 +49,14 @@ const (
 	VarDecl   = "VAR"      // variable
 )
 
+// Kinds of comments
+const (
+	LineComment   = "LINE"   // single line comment (// foo, # foo)
+	BlockComment  = "BLOCK"  // block comment (/* foo */)
+	DocComment    = "DOC"    // documentation of a declaration (/** foo */, """foo""")
+	HeaderComment = "HEADER" // file header, such as a license or a copyright notice
+)
+
 // Increment/Decrement operators
 const (
 	INC = "INC" // increment operator (i++)
@@ -5,18 +5,21 @@
 package main
 
 import (
+	"context"
 	"encoding/json"
 	"encoding/xml"
 	"errors"
 	"flag"
 	"fmt"
-	"io"
+	"io/ioutil"
 	"log"
 	"os"
 	"path/filepath"
 	"runtime/pprof"
+	"strings"
 
 	"github.com/DevMine/srcanlzr/anlzr"
+	"github.com/DevMine/srcanlzr/gate"
 	"github.com/DevMine/srcanlzr/src"
 )
 
@@ -47,10 +50,153 @@ func fatal(err error) {
 	os.Exit(1)
 }
 
+// A command is a srcanlzr subcommand, invoked as "srcanlzr NAME [ARGS]".
+type command struct {
+	name  string
+	usage string // arguments of the command, printed in the usage message
+	short string // one line description of the command
+	run   func(cmd *command, args []string)
+}
+
+// commands lists the available subcommands. When no subcommand is given,
+// srcanlzr analyzes the project.
+var commands []*command
+
+func init() {
+	commands = []*command{cmdPrint, cmdQuery, cmdCallgraph, cmdHierarchy}
+}
+
+func lookupCommand(name string) *command {
+	for _, cmd := range commands {
+		if cmd.name == name {
+			return cmd
+		}
+	}
+	return nil
+}
+
+// decodeProject decodes the project from the JSON file at path, or from stdin
+// when path is empty.
+func decodeProject(path string) (*src.Project, error) {
+	if path == "" {
+		return src.Decode(os.Stdin)
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return src.Decode(f)
+}
+
+// loadRules loads the quality rules from the JSON file at path.
+func loadRules(path string) (*gate.Config, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	c, err := gate.Load(f)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %v", path, err)
+	}
+	return c, nil
+}
+
+// selectAnalyzers returns the analyzers selected and configured by the program
+// flags, along with the analyzers needed to check the quality rules, if any.
+func selectAnalyzers(rules *gate.Config) ([]anlzr.Analyzer, error) {
+	sel := anlzr.Selection{
+		Enable:  anlzr.ParseNames(*enable),
+		Disable: anlzr.ParseNames(*disable),
+		Config:  make(map[string]json.RawMessage),
+	}
+
+	if rules != nil {
+		for _, name := range rules.Analyzers() {
+			for _, d := range sel.Disable {
+				if d == name {
+					return nil, fmt.Errorf("analyzer %s is disabled but the quality rules require it", name)
+				}
+			}
+			if len(sel.Enable) > 0 {
+				sel.Enable = append(sel.Enable, name)
+			}
+		}
+	}
+
+	if *configFileName != "" {
+		bs, err := ioutil.ReadFile(*configFileName)
+		if err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(bs, &sel.Config); err != nil {
+			return nil, fmt.Errorf("%s: %v", *configFileName, err)
+		}
+	}
+
+	// the -granularity flag overrides the configuration file, when given
+	if cfg, ok := sel.Config["entities"]; granularitySet() || !ok {
+		if _, err := anlzr.ParseGranularity(*granularity); err != nil {
+			return nil, err
+		}
+		// keep the rest of the configuration of the analyzer
+		fields := make(map[string]json.RawMessage)
+		if ok {
+			if err := json.Unmarshal(cfg, &fields); err != nil {
+				return nil, fmt.Errorf("%s: entities: %v", *configFileName, err)
+			}
+		}
+		bs, err := json.Marshal(*granularity)
+		if err != nil {
+			return nil, err
+		}
+		fields["Granularity"] = bs
+		if bs, err = json.Marshal(fields); err != nil {
+			return nil, err
+		}
+		sel.Config["entities"] = bs
+	}
+
+	return anlzr.Select(sel)
+}
+
+// granularitySet reports whether the -granularity flag is given.
+func granularitySet() bool {
+	set := false
+	flag.Visit(func(f *flag.Flag) { set = set || f.Name == "granularity" })
+	return set
+}
+
+// listAnalyzers prints the registered analyzers and the quality rules.
+func listAnalyzers() {
+	for _, info := range anlzr.Analyzers() {
+		fmt.Printf("%-22s %s", info.Name, info.Doc)
+		if len(info.Requires) > 0 {
+			fmt.Printf(" (requires %s)", strings.Join(info.Requires, ", "))
+		}
+		fmt.Println()
+	}
+
+	fmt.Println("\nquality rules:")
+	for _, name := range gate.Rules() {
+		fmt.Printf("%-26s %s\n", name, gate.Doc(name))
+	}
+}
+
 // program flags
 var (
 	format         = flag.String("f", "JSON", "Output format. Possible values are: JSON, XML, protobuf")
 	outputFileName = flag.String("o", "", "Output file name. By default, the output is set to stdout")
+	granularity    = flag.String("granularity", "project", "Breakdown of the metrics. Possible values are: project, package, file, function. By default, the breakdowns of the sections are complete and there is no entities section")
+	enable         = flag.String("enable", "", "Comma separated list of the analyzers to run. By default, all the analyzers run")
+	disable        = flag.String("disable", "", "Comma separated list of the analyzers not to run")
+	configFileName = flag.String("config", "", "JSON file holding the configuration of the analyzers, by analyzer name")
+	timeout        = flag.Duration("timeout", 0, "Maximum duration of the analysis (e.g. 30s). By default, there is no limit")
+	tflag          = flag.Bool("timings", false, "Print the duration of each analyzer to stderr.")
+	rulesFileName  = flag.String("rules", "", "JSON file holding the quality rules to check. The exit code is 2 when a rule of error severity is broken")
+	lflag          = flag.Bool("list", false, "List the available analyzers and quality rules.")
 	cpuprofile     = flag.String("cpuprofile", "", "write cpu profile to file")
 	memprofile     = flag.String("memprofile", "", "write memory profile to this file")
 	vflag          = flag.Bool("v", false, "Print version.")
@@ -58,10 +204,28 @@ var (
 
 func main() {
 	flag.Usage = func() {
-		fmt.Printf("usage: %s [JSON PATH]\n\n", os.Args[0])
+		fmt.Printf("usage: %s [JSON PATH]\n", os.Args[0])
+		for _, cmd := range commands {
+			fmt.Printf("       %s %s %s\n", os.Args[0], cmd.name, cmd.usage)
+		}
+		fmt.Println()
 		flag.PrintDefaults()
+		if len(commands) > 0 {
+			fmt.Println("\ncommands:")
+			for _, cmd := range commands {
+				fmt.Printf("  %-10s %s\n", cmd.name, cmd.short)
+			}
+		}
 		os.Exit(0)
 	}
+
+	if len(os.Args) > 1 {
+		if cmd := lookupCommand(os.Args[1]); cmd != nil {
+			cmd.run(cmd, os.Args[2:])
+			return
+		}
+	}
+
 	flag.Parse()
 
 	if *vflag {
@@ -69,6 +233,11 @@ func main() {
 		return
 	}
 
+	if *lflag {
+		listAnalyzers()
+		return
+	}
+
 	if *cpuprofile != "" {
 		f, err := os.Create(*cpuprofile)
 		if err != nil {
@@ -88,22 +257,10 @@ func main() {
 		return
 	}
 
-	var reader io.Reader
-
-	if l := len(flag.Args()); l > 1 {
+	if len(flag.Args()) > 1 {
 		// more that one file are passed as arguments
 		fmt.Fprint(os.Stderr, "too many arguments\n\n")
 		flag.Usage()
-	} else if l == 0 {
-		// no argument, we read from stdin
-		reader = os.Stdin
-	} else {
-		f, err := os.Open(flag.Arg(0))
-		if err != nil {
-			fatal(err)
-		}
-		defer f.Close()
-		reader = f
 	}
 
 	out := os.Stdout
@@ -116,20 +273,82 @@ func main() {
 		defer out.Close()
 	}
 
-	p, err := src.Decode(reader)
+	var rules *gate.Config
+	if *rulesFileName != "" {
+		var err error
+		if rules, err = loadRules(*rulesFileName); err != nil {
+			fatal(err)
+		}
+	}
+
+	analyzers, err := selectAnalyzers(rules)
 	if err != nil {
 		fatal(err)
 	}
 
-	res, err := anlzr.RunAnalyzers(p, anlzr.LoC{}, anlzr.Complexity{}, anlzr.LocPerLang{}, anlzr.CommentRatios{})
+	// with no argument, we read from stdin
+	p, err := decodeProject(flag.Arg(0))
 	if err != nil {
 		fatal(err)
 	}
 
+	ctx := context.Background()
+	if *timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, *timeout)
+		defer cancel()
+	}
+
+	res, runs := anlzr.Run(ctx, p, analyzers...)
+	if *tflag {
+		for _, run := range runs {
+			fmt.Fprintf(os.Stderr, "%-22s %v\n", run.Name, run.Duration)
+		}
+	}
+
+	// the rules are checked against the full breakdown of the metrics, even
+	// if the output is coarser
+	var vs []gate.Violation
+	if rules != nil {
+		vs = rules.Check(res)
+	}
+	// the breakdowns are complete unless a granularity is requested
+	if granularitySet() {
+		g, err := anlzr.ParseGranularity(*granularity)
+		if err != nil {
+			fatal(err)
+		}
+		res.Trim(g)
+	}
+
 	bs, err := formatOutput(res)
 	if err != nil {
 		fatal(err)
 	}
 
 	fmt.Fprintln(out, string(bs))
+
+	// the metrics of the analyzers which succeeded are output anyway
+	if errs := anlzr.Errors(runs); errs != nil {
+		for _, run := range errs.(anlzr.AnalyzerErrors) {
+			fmt.Fprintf(os.Stderr, "%s: %v\n", run.Name, run.Err)
+		}
+		os.Exit(1)
+	}
+
+	if rules != nil {
+		var nerrs int
+		for _, v := range vs {
+			fmt.Fprintln(os.Stderr, v)
+			if v.Severity == gate.Error {
+				nerrs++
+			}
+		}
+		if len(vs) > 0 {
+			fmt.Fprintf(os.Stderr, "%d rule violations: %d errors, %d warnings\n", len(vs), nerrs, len(vs)-nerrs)
+		}
+		if gate.Failed(vs) {
+			os.Exit(2)
+		}
+	}
 }